@@ -0,0 +1,2876 @@
+// gRPC API of the elevator simulator.
+//
+// ElevatorService controls one elevator group and streams its events. It is
+// served by package go-elevator-simulator/pkg/grpcserver, which a program
+// embedding the simulator registers on its own grpc.Server, and by the
+// elevator-grpc command.
+//
+// Cars are addressed by their index in the group, from 0. A rejected command
+// fails with a gRPC status whose ErrorInfo detail carries the engine error
+// code as its reason (OutOfRange, Inaccessible, ModeForbids, Overload,
+// Invalid, State, Fault, Unavailable).
+//
+// Regenerate the Go code with protoc-gen-go and protoc-gen-go-grpc:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//	  --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+//	  api/elevator/v1/elevator.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: api/elevator/v1/elevator.proto
+
+package elevatorv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CallType int32
+
+const (
+	// A car call.
+	CallType_CALL_TYPE_UNSPECIFIED CallType = 0
+	CallType_CALL_TYPE_CAR         CallType = 1
+	CallType_CALL_TYPE_HALL_UP     CallType = 2
+	CallType_CALL_TYPE_HALL_DOWN   CallType = 3
+)
+
+// Enum value maps for CallType.
+var (
+	CallType_name = map[int32]string{
+		0: "CALL_TYPE_UNSPECIFIED",
+		1: "CALL_TYPE_CAR",
+		2: "CALL_TYPE_HALL_UP",
+		3: "CALL_TYPE_HALL_DOWN",
+	}
+	CallType_value = map[string]int32{
+		"CALL_TYPE_UNSPECIFIED": 0,
+		"CALL_TYPE_CAR":         1,
+		"CALL_TYPE_HALL_UP":     2,
+		"CALL_TYPE_HALL_DOWN":   3,
+	}
+)
+
+func (x CallType) Enum() *CallType {
+	p := new(CallType)
+	*p = x
+	return p
+}
+
+func (x CallType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CallType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_elevator_v1_elevator_proto_enumTypes[0].Descriptor()
+}
+
+func (CallType) Type() protoreflect.EnumType {
+	return &file_api_elevator_v1_elevator_proto_enumTypes[0]
+}
+
+func (x CallType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CallType.Descriptor instead.
+func (CallType) EnumDescriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{0}
+}
+
+type Direction int32
+
+const (
+	Direction_DIRECTION_UNSPECIFIED Direction = 0
+	Direction_DIRECTION_UP          Direction = 1
+	Direction_DIRECTION_DOWN        Direction = 2
+	Direction_DIRECTION_NONE        Direction = 3
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "DIRECTION_UNSPECIFIED",
+		1: "DIRECTION_UP",
+		2: "DIRECTION_DOWN",
+		3: "DIRECTION_NONE",
+	}
+	Direction_value = map[string]int32{
+		"DIRECTION_UNSPECIFIED": 0,
+		"DIRECTION_UP":          1,
+		"DIRECTION_DOWN":        2,
+		"DIRECTION_NONE":        3,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_elevator_v1_elevator_proto_enumTypes[1].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_api_elevator_v1_elevator_proto_enumTypes[1]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{1}
+}
+
+type DoorSide int32
+
+const (
+	DoorSide_DOOR_SIDE_UNSPECIFIED DoorSide = 0
+	DoorSide_DOOR_SIDE_FRONT       DoorSide = 1
+	DoorSide_DOOR_SIDE_REAR        DoorSide = 2
+	DoorSide_DOOR_SIDE_BOTH        DoorSide = 3
+)
+
+// Enum value maps for DoorSide.
+var (
+	DoorSide_name = map[int32]string{
+		0: "DOOR_SIDE_UNSPECIFIED",
+		1: "DOOR_SIDE_FRONT",
+		2: "DOOR_SIDE_REAR",
+		3: "DOOR_SIDE_BOTH",
+	}
+	DoorSide_value = map[string]int32{
+		"DOOR_SIDE_UNSPECIFIED": 0,
+		"DOOR_SIDE_FRONT":       1,
+		"DOOR_SIDE_REAR":        2,
+		"DOOR_SIDE_BOTH":        3,
+	}
+)
+
+func (x DoorSide) Enum() *DoorSide {
+	p := new(DoorSide)
+	*p = x
+	return p
+}
+
+func (x DoorSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DoorSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_elevator_v1_elevator_proto_enumTypes[2].Descriptor()
+}
+
+func (DoorSide) Type() protoreflect.EnumType {
+	return &file_api_elevator_v1_elevator_proto_enumTypes[2]
+}
+
+func (x DoorSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DoorSide.Descriptor instead.
+func (DoorSide) EnumDescriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{2}
+}
+
+type DoorState int32
+
+const (
+	DoorState_DOOR_STATE_UNSPECIFIED DoorState = 0
+	DoorState_DOOR_STATE_OPEN        DoorState = 1
+	DoorState_DOOR_STATE_OPENING     DoorState = 2
+	DoorState_DOOR_STATE_CLOSING     DoorState = 3
+	DoorState_DOOR_STATE_CLOSE       DoorState = 4
+)
+
+// Enum value maps for DoorState.
+var (
+	DoorState_name = map[int32]string{
+		0: "DOOR_STATE_UNSPECIFIED",
+		1: "DOOR_STATE_OPEN",
+		2: "DOOR_STATE_OPENING",
+		3: "DOOR_STATE_CLOSING",
+		4: "DOOR_STATE_CLOSE",
+	}
+	DoorState_value = map[string]int32{
+		"DOOR_STATE_UNSPECIFIED": 0,
+		"DOOR_STATE_OPEN":        1,
+		"DOOR_STATE_OPENING":     2,
+		"DOOR_STATE_CLOSING":     3,
+		"DOOR_STATE_CLOSE":       4,
+	}
+)
+
+func (x DoorState) Enum() *DoorState {
+	p := new(DoorState)
+	*p = x
+	return p
+}
+
+func (x DoorState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DoorState) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_elevator_v1_elevator_proto_enumTypes[3].Descriptor()
+}
+
+func (DoorState) Type() protoreflect.EnumType {
+	return &file_api_elevator_v1_elevator_proto_enumTypes[3]
+}
+
+func (x DoorState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DoorState.Descriptor instead.
+func (DoorState) EnumDescriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{3}
+}
+
+type OperationMode int32
+
+const (
+	OperationMode_OPERATION_MODE_UNSPECIFIED  OperationMode = 0
+	OperationMode_OPERATION_MODE_AUTO         OperationMode = 1
+	OperationMode_OPERATION_MODE_MANUAL       OperationMode = 2
+	OperationMode_OPERATION_MODE_MOVING       OperationMode = 3
+	OperationMode_OPERATION_MODE_EMERGENCY    OperationMode = 4
+	OperationMode_OPERATION_MODE_FIRE_RECALL  OperationMode = 5
+	OperationMode_OPERATION_MODE_FIRE_SERVICE OperationMode = 6
+)
+
+// Enum value maps for OperationMode.
+var (
+	OperationMode_name = map[int32]string{
+		0: "OPERATION_MODE_UNSPECIFIED",
+		1: "OPERATION_MODE_AUTO",
+		2: "OPERATION_MODE_MANUAL",
+		3: "OPERATION_MODE_MOVING",
+		4: "OPERATION_MODE_EMERGENCY",
+		5: "OPERATION_MODE_FIRE_RECALL",
+		6: "OPERATION_MODE_FIRE_SERVICE",
+	}
+	OperationMode_value = map[string]int32{
+		"OPERATION_MODE_UNSPECIFIED":  0,
+		"OPERATION_MODE_AUTO":         1,
+		"OPERATION_MODE_MANUAL":       2,
+		"OPERATION_MODE_MOVING":       3,
+		"OPERATION_MODE_EMERGENCY":    4,
+		"OPERATION_MODE_FIRE_RECALL":  5,
+		"OPERATION_MODE_FIRE_SERVICE": 6,
+	}
+)
+
+func (x OperationMode) Enum() *OperationMode {
+	p := new(OperationMode)
+	*p = x
+	return p
+}
+
+func (x OperationMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OperationMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_elevator_v1_elevator_proto_enumTypes[4].Descriptor()
+}
+
+func (OperationMode) Type() protoreflect.EnumType {
+	return &file_api_elevator_v1_elevator_proto_enumTypes[4]
+}
+
+func (x OperationMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OperationMode.Descriptor instead.
+func (OperationMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{4}
+}
+
+type EmergencyPhase int32
+
+const (
+	// Not stopped in an emergency.
+	EmergencyPhase_EMERGENCY_PHASE_UNSPECIFIED EmergencyPhase = 0
+	EmergencyPhase_EMERGENCY_PHASE_LATCHED     EmergencyPhase = 1
+	EmergencyPhase_EMERGENCY_PHASE_RESET       EmergencyPhase = 2
+	EmergencyPhase_EMERGENCY_PHASE_RESCUE      EmergencyPhase = 3
+	EmergencyPhase_EMERGENCY_PHASE_RESCUED     EmergencyPhase = 4
+)
+
+// Enum value maps for EmergencyPhase.
+var (
+	EmergencyPhase_name = map[int32]string{
+		0: "EMERGENCY_PHASE_UNSPECIFIED",
+		1: "EMERGENCY_PHASE_LATCHED",
+		2: "EMERGENCY_PHASE_RESET",
+		3: "EMERGENCY_PHASE_RESCUE",
+		4: "EMERGENCY_PHASE_RESCUED",
+	}
+	EmergencyPhase_value = map[string]int32{
+		"EMERGENCY_PHASE_UNSPECIFIED": 0,
+		"EMERGENCY_PHASE_LATCHED":     1,
+		"EMERGENCY_PHASE_RESET":       2,
+		"EMERGENCY_PHASE_RESCUE":      3,
+		"EMERGENCY_PHASE_RESCUED":     4,
+	}
+)
+
+func (x EmergencyPhase) Enum() *EmergencyPhase {
+	p := new(EmergencyPhase)
+	*p = x
+	return p
+}
+
+func (x EmergencyPhase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EmergencyPhase) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_elevator_v1_elevator_proto_enumTypes[5].Descriptor()
+}
+
+func (EmergencyPhase) Type() protoreflect.EnumType {
+	return &file_api_elevator_v1_elevator_proto_enumTypes[5]
+}
+
+func (x EmergencyPhase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EmergencyPhase.Descriptor instead.
+func (EmergencyPhase) EnumDescriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{5}
+}
+
+type FaultKind int32
+
+const (
+	FaultKind_FAULT_KIND_UNSPECIFIED       FaultKind = 0
+	FaultKind_FAULT_KIND_DOOR_JAM          FaultKind = 1
+	FaultKind_FAULT_KIND_DOOR_LOCK         FaultKind = 2
+	FaultKind_FAULT_KIND_DRIVE_TRIP        FaultKind = 3
+	FaultKind_FAULT_KIND_LEVELING          FaultKind = 4
+	FaultKind_FAULT_KIND_OVERSPEED         FaultKind = 5
+	FaultKind_FAULT_KIND_POSITION_SENSOR   FaultKind = 6
+	FaultKind_FAULT_KIND_STUCK_HALL_BUTTON FaultKind = 7
+)
+
+// Enum value maps for FaultKind.
+var (
+	FaultKind_name = map[int32]string{
+		0: "FAULT_KIND_UNSPECIFIED",
+		1: "FAULT_KIND_DOOR_JAM",
+		2: "FAULT_KIND_DOOR_LOCK",
+		3: "FAULT_KIND_DRIVE_TRIP",
+		4: "FAULT_KIND_LEVELING",
+		5: "FAULT_KIND_OVERSPEED",
+		6: "FAULT_KIND_POSITION_SENSOR",
+		7: "FAULT_KIND_STUCK_HALL_BUTTON",
+	}
+	FaultKind_value = map[string]int32{
+		"FAULT_KIND_UNSPECIFIED":       0,
+		"FAULT_KIND_DOOR_JAM":          1,
+		"FAULT_KIND_DOOR_LOCK":         2,
+		"FAULT_KIND_DRIVE_TRIP":        3,
+		"FAULT_KIND_LEVELING":          4,
+		"FAULT_KIND_OVERSPEED":         5,
+		"FAULT_KIND_POSITION_SENSOR":   6,
+		"FAULT_KIND_STUCK_HALL_BUTTON": 7,
+	}
+)
+
+func (x FaultKind) Enum() *FaultKind {
+	p := new(FaultKind)
+	*p = x
+	return p
+}
+
+func (x FaultKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FaultKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_elevator_v1_elevator_proto_enumTypes[6].Descriptor()
+}
+
+func (FaultKind) Type() protoreflect.EnumType {
+	return &file_api_elevator_v1_elevator_proto_enumTypes[6]
+}
+
+func (x FaultKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FaultKind.Descriptor instead.
+func (FaultKind) EnumDescriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{6}
+}
+
+// Backpressure decides what happens to events a slow stream cannot take.
+type Backpressure int32
+
+const (
+	// Discard the oldest buffered event.
+	Backpressure_BACKPRESSURE_UNSPECIFIED Backpressure = 0
+	Backpressure_BACKPRESSURE_DROP_NEWEST Backpressure = 1
+	// Hold up the simulation until the stream catches up.
+	Backpressure_BACKPRESSURE_BLOCK Backpressure = 2
+	// Keep only the latest event of each state (floor, direction, door side,
+	// mode, position).
+	Backpressure_BACKPRESSURE_COALESCE_LATEST Backpressure = 3
+)
+
+// Enum value maps for Backpressure.
+var (
+	Backpressure_name = map[int32]string{
+		0: "BACKPRESSURE_UNSPECIFIED",
+		1: "BACKPRESSURE_DROP_NEWEST",
+		2: "BACKPRESSURE_BLOCK",
+		3: "BACKPRESSURE_COALESCE_LATEST",
+	}
+	Backpressure_value = map[string]int32{
+		"BACKPRESSURE_UNSPECIFIED":     0,
+		"BACKPRESSURE_DROP_NEWEST":     1,
+		"BACKPRESSURE_BLOCK":           2,
+		"BACKPRESSURE_COALESCE_LATEST": 3,
+	}
+)
+
+func (x Backpressure) Enum() *Backpressure {
+	p := new(Backpressure)
+	*p = x
+	return p
+}
+
+func (x Backpressure) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Backpressure) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_elevator_v1_elevator_proto_enumTypes[7].Descriptor()
+}
+
+func (Backpressure) Type() protoreflect.EnumType {
+	return &file_api_elevator_v1_elevator_proto_enumTypes[7]
+}
+
+func (x Backpressure) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Backpressure.Descriptor instead.
+func (Backpressure) EnumDescriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{7}
+}
+
+type GetStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStateRequest) Reset() {
+	*x = GetStateRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStateRequest) ProtoMessage() {}
+
+func (x *GetStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStateRequest.ProtoReflect.Descriptor instead.
+func (*GetStateRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{0}
+}
+
+type CarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Car           int32                  `protobuf:"varint,1,opt,name=car,proto3" json:"car,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CarRequest) Reset() {
+	*x = CarRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CarRequest) ProtoMessage() {}
+
+func (x *CarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CarRequest.ProtoReflect.Descriptor instead.
+func (*CarRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CarRequest) GetCar() int32 {
+	if x != nil {
+		return x.Car
+	}
+	return 0
+}
+
+type AddCallRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Car of a car call; ignored for hall calls.
+	Car           int32    `protobuf:"varint,1,opt,name=car,proto3" json:"car,omitempty"`
+	Floor         int32    `protobuf:"varint,2,opt,name=floor,proto3" json:"floor,omitempty"`
+	CallType      CallType `protobuf:"varint,3,opt,name=call_type,json=callType,proto3,enum=elevator.v1.CallType" json:"call_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCallRequest) Reset() {
+	*x = AddCallRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCallRequest) ProtoMessage() {}
+
+func (x *AddCallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCallRequest.ProtoReflect.Descriptor instead.
+func (*AddCallRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AddCallRequest) GetCar() int32 {
+	if x != nil {
+		return x.Car
+	}
+	return 0
+}
+
+func (x *AddCallRequest) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *AddCallRequest) GetCallType() CallType {
+	if x != nil {
+		return x.CallType
+	}
+	return CallType_CALL_TYPE_UNSPECIFIED
+}
+
+type RemoveCallRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Car of a car call, and the car whose state is returned.
+	Car           int32    `protobuf:"varint,1,opt,name=car,proto3" json:"car,omitempty"`
+	Floor         int32    `protobuf:"varint,2,opt,name=floor,proto3" json:"floor,omitempty"`
+	CallType      CallType `protobuf:"varint,3,opt,name=call_type,json=callType,proto3,enum=elevator.v1.CallType" json:"call_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCallRequest) Reset() {
+	*x = RemoveCallRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCallRequest) ProtoMessage() {}
+
+func (x *RemoveCallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCallRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCallRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RemoveCallRequest) GetCar() int32 {
+	if x != nil {
+		return x.Car
+	}
+	return 0
+}
+
+func (x *RemoveCallRequest) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *RemoveCallRequest) GetCallType() CallType {
+	if x != nil {
+		return x.CallType
+	}
+	return CallType_CALL_TYPE_UNSPECIFIED
+}
+
+type AddPassengerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passenger     *Passenger             `protobuf:"bytes,1,opt,name=passenger,proto3" json:"passenger,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPassengerRequest) Reset() {
+	*x = AddPassengerRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPassengerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPassengerRequest) ProtoMessage() {}
+
+func (x *AddPassengerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPassengerRequest.ProtoReflect.Descriptor instead.
+func (*AddPassengerRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AddPassengerRequest) GetPassenger() *Passenger {
+	if x != nil {
+		return x.Passenger
+	}
+	return nil
+}
+
+type AddWeightRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Car   int32                  `protobuf:"varint,1,opt,name=car,proto3" json:"car,omitempty"`
+	// kg
+	Weight        int32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddWeightRequest) Reset() {
+	*x = AddWeightRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWeightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWeightRequest) ProtoMessage() {}
+
+func (x *AddWeightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWeightRequest.ProtoReflect.Descriptor instead.
+func (*AddWeightRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AddWeightRequest) GetCar() int32 {
+	if x != nil {
+		return x.Car
+	}
+	return 0
+}
+
+func (x *AddWeightRequest) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type SetObstructionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Car           int32                  `protobuf:"varint,1,opt,name=car,proto3" json:"car,omitempty"`
+	Blocked       bool                   `protobuf:"varint,2,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetObstructionRequest) Reset() {
+	*x = SetObstructionRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetObstructionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetObstructionRequest) ProtoMessage() {}
+
+func (x *SetObstructionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetObstructionRequest.ProtoReflect.Descriptor instead.
+func (*SetObstructionRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetObstructionRequest) GetCar() int32 {
+	if x != nil {
+		return x.Car
+	}
+	return 0
+}
+
+func (x *SetObstructionRequest) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+type SetModeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Car           int32                  `protobuf:"varint,1,opt,name=car,proto3" json:"car,omitempty"`
+	Mode          OperationMode          `protobuf:"varint,2,opt,name=mode,proto3,enum=elevator.v1.OperationMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetModeRequest) Reset() {
+	*x = SetModeRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetModeRequest) ProtoMessage() {}
+
+func (x *SetModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetModeRequest.ProtoReflect.Descriptor instead.
+func (*SetModeRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetModeRequest) GetCar() int32 {
+	if x != nil {
+		return x.Car
+	}
+	return 0
+}
+
+func (x *SetModeRequest) GetMode() OperationMode {
+	if x != nil {
+		return x.Mode
+	}
+	return OperationMode_OPERATION_MODE_UNSPECIFIED
+}
+
+type PressJogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Car           int32                  `protobuf:"varint,1,opt,name=car,proto3" json:"car,omitempty"`
+	Direction     Direction              `protobuf:"varint,2,opt,name=direction,proto3,enum=elevator.v1.Direction" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PressJogRequest) Reset() {
+	*x = PressJogRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PressJogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PressJogRequest) ProtoMessage() {}
+
+func (x *PressJogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PressJogRequest.ProtoReflect.Descriptor instead.
+func (*PressJogRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PressJogRequest) GetCar() int32 {
+	if x != nil {
+		return x.Car
+	}
+	return 0
+}
+
+func (x *PressJogRequest) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_DIRECTION_UNSPECIFIED
+}
+
+type InjectFaultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Car           int32                  `protobuf:"varint,1,opt,name=car,proto3" json:"car,omitempty"`
+	Fault         *Fault                 `protobuf:"bytes,2,opt,name=fault,proto3" json:"fault,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InjectFaultRequest) Reset() {
+	*x = InjectFaultRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InjectFaultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InjectFaultRequest) ProtoMessage() {}
+
+func (x *InjectFaultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InjectFaultRequest.ProtoReflect.Descriptor instead.
+func (*InjectFaultRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InjectFaultRequest) GetCar() int32 {
+	if x != nil {
+		return x.Car
+	}
+	return 0
+}
+
+func (x *InjectFaultRequest) GetFault() *Fault {
+	if x != nil {
+		return x.Fault
+	}
+	return nil
+}
+
+type ClearFaultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Car           int32                  `protobuf:"varint,1,opt,name=car,proto3" json:"car,omitempty"`
+	Kind          FaultKind              `protobuf:"varint,2,opt,name=kind,proto3,enum=elevator.v1.FaultKind" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearFaultRequest) Reset() {
+	*x = ClearFaultRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearFaultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearFaultRequest) ProtoMessage() {}
+
+func (x *ClearFaultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearFaultRequest.ProtoReflect.Descriptor instead.
+func (*ClearFaultRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ClearFaultRequest) GetCar() int32 {
+	if x != nil {
+		return x.Car
+	}
+	return 0
+}
+
+func (x *ClearFaultRequest) GetKind() FaultKind {
+	if x != nil {
+		return x.Kind
+	}
+	return FaultKind_FAULT_KIND_UNSPECIFIED
+}
+
+type SetSmokeDetectorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Floor         int32                  `protobuf:"varint,1,opt,name=floor,proto3" json:"floor,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSmokeDetectorRequest) Reset() {
+	*x = SetSmokeDetectorRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSmokeDetectorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSmokeDetectorRequest) ProtoMessage() {}
+
+func (x *SetSmokeDetectorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSmokeDetectorRequest.ProtoReflect.Descriptor instead.
+func (*SetSmokeDetectorRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetSmokeDetectorRequest) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *SetSmokeDetectorRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type WatchEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cars to watch; empty watches every car.
+	Cars []int32 `protobuf:"varint,1,rep,packed,name=cars,proto3" json:"cars,omitempty"`
+	// Event types to stream (FloorChange, DoorChange, Position, ...); empty
+	// streams every type.
+	Types []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	// Events buffered per car; 0 uses the engine default.
+	Buffer        int32        `protobuf:"varint,3,opt,name=buffer,proto3" json:"buffer,omitempty"`
+	Backpressure  Backpressure `protobuf:"varint,4,opt,name=backpressure,proto3,enum=elevator.v1.Backpressure" json:"backpressure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchEventsRequest) GetCars() []int32 {
+	if x != nil {
+		return x.Cars
+	}
+	return nil
+}
+
+func (x *WatchEventsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *WatchEventsRequest) GetBuffer() int32 {
+	if x != nil {
+		return x.Buffer
+	}
+	return 0
+}
+
+func (x *WatchEventsRequest) GetBackpressure() Backpressure {
+	if x != nil {
+		return x.Backpressure
+	}
+	return Backpressure_BACKPRESSURE_UNSPECIFIED
+}
+
+type Passenger struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Origin      int32                  `protobuf:"varint,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination int32                  `protobuf:"varint,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	// kg
+	Mass          int32                  `protobuf:"varint,4,opt,name=mass,proto3" json:"mass,omitempty"`
+	ArrivedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=arrived_at,json=arrivedAt,proto3" json:"arrived_at,omitempty"`
+	BoardedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=boarded_at,json=boardedAt,proto3" json:"boarded_at,omitempty"`
+	AlightedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=alighted_at,json=alightedAt,proto3" json:"alighted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Passenger) Reset() {
+	*x = Passenger{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Passenger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Passenger) ProtoMessage() {}
+
+func (x *Passenger) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Passenger.ProtoReflect.Descriptor instead.
+func (*Passenger) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Passenger) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Passenger) GetOrigin() int32 {
+	if x != nil {
+		return x.Origin
+	}
+	return 0
+}
+
+func (x *Passenger) GetDestination() int32 {
+	if x != nil {
+		return x.Destination
+	}
+	return 0
+}
+
+func (x *Passenger) GetMass() int32 {
+	if x != nil {
+		return x.Mass
+	}
+	return 0
+}
+
+func (x *Passenger) GetArrivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArrivedAt
+	}
+	return nil
+}
+
+func (x *Passenger) GetBoardedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BoardedAt
+	}
+	return nil
+}
+
+func (x *Passenger) GetAlightedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AlightedAt
+	}
+	return nil
+}
+
+type Fault struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kind  FaultKind              `protobuf:"varint,1,opt,name=kind,proto3,enum=elevator.v1.FaultKind" json:"kind,omitempty"`
+	// StuckHallButton: floor and direction of the stuck button.
+	Floor     int32     `protobuf:"varint,2,opt,name=floor,proto3" json:"floor,omitempty"`
+	Direction Direction `protobuf:"varint,3,opt,name=direction,proto3,enum=elevator.v1.Direction" json:"direction,omitempty"`
+	// Leveling: levelling error in m; 0 uses the engine default.
+	Offset        float64 `protobuf:"fixed64,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fault) Reset() {
+	*x = Fault{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fault) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fault) ProtoMessage() {}
+
+func (x *Fault) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fault.ProtoReflect.Descriptor instead.
+func (*Fault) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Fault) GetKind() FaultKind {
+	if x != nil {
+		return x.Kind
+	}
+	return FaultKind_FAULT_KIND_UNSPECIFIED
+}
+
+func (x *Fault) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *Fault) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_DIRECTION_UNSPECIFIED
+}
+
+func (x *Fault) GetOffset() float64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type Doors struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Front         DoorState              `protobuf:"varint,1,opt,name=front,proto3,enum=elevator.v1.DoorState" json:"front,omitempty"`
+	Rear          DoorState              `protobuf:"varint,2,opt,name=rear,proto3,enum=elevator.v1.DoorState" json:"rear,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Doors) Reset() {
+	*x = Doors{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Doors) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Doors) ProtoMessage() {}
+
+func (x *Doors) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Doors.ProtoReflect.Descriptor instead.
+func (*Doors) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Doors) GetFront() DoorState {
+	if x != nil {
+		return x.Front
+	}
+	return DoorState_DOOR_STATE_UNSPECIFIED
+}
+
+func (x *Doors) GetRear() DoorState {
+	if x != nil {
+		return x.Rear
+	}
+	return DoorState_DOOR_STATE_UNSPECIFIED
+}
+
+type CarState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Car           int32                  `protobuf:"varint,1,opt,name=car,proto3" json:"car,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Floor         int32                  `protobuf:"varint,3,opt,name=floor,proto3" json:"floor,omitempty"`
+	Direction     Direction              `protobuf:"varint,4,opt,name=direction,proto3,enum=elevator.v1.Direction" json:"direction,omitempty"`
+	Doors         *Doors                 `protobuf:"bytes,5,opt,name=doors,proto3" json:"doors,omitempty"`
+	Mode          OperationMode          `protobuf:"varint,6,opt,name=mode,proto3,enum=elevator.v1.OperationMode" json:"mode,omitempty"`
+	CarCalls      []int32                `protobuf:"varint,7,rep,packed,name=car_calls,json=carCalls,proto3" json:"car_calls,omitempty"`
+	HallUpCalls   []int32                `protobuf:"varint,8,rep,packed,name=hall_up_calls,json=hallUpCalls,proto3" json:"hall_up_calls,omitempty"`
+	HallDownCalls []int32                `protobuf:"varint,9,rep,packed,name=hall_down_calls,json=hallDownCalls,proto3" json:"hall_down_calls,omitempty"`
+	// kg
+	Weight int32 `protobuf:"varint,10,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Height above the lowest floor in m.
+	Position float64 `protobuf:"fixed64,11,opt,name=position,proto3" json:"position,omitempty"`
+	// m/s, negative going down.
+	Speed float64 `protobuf:"fixed64,12,opt,name=speed,proto3" json:"speed,omitempty"`
+	// Position in floors, fractional between floors.
+	Level         float64        `protobuf:"fixed64,13,opt,name=level,proto3" json:"level,omitempty"`
+	Emergency     EmergencyPhase `protobuf:"varint,14,opt,name=emergency,proto3,enum=elevator.v1.EmergencyPhase" json:"emergency,omitempty"`
+	Obstructed    bool           `protobuf:"varint,15,opt,name=obstructed,proto3" json:"obstructed,omitempty"`
+	Nudging       bool           `protobuf:"varint,16,opt,name=nudging,proto3" json:"nudging,omitempty"`
+	Faults        []*Fault       `protobuf:"bytes,17,rep,name=faults,proto3" json:"faults,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CarState) Reset() {
+	*x = CarState{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CarState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CarState) ProtoMessage() {}
+
+func (x *CarState) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CarState.ProtoReflect.Descriptor instead.
+func (*CarState) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CarState) GetCar() int32 {
+	if x != nil {
+		return x.Car
+	}
+	return 0
+}
+
+func (x *CarState) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CarState) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *CarState) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_DIRECTION_UNSPECIFIED
+}
+
+func (x *CarState) GetDoors() *Doors {
+	if x != nil {
+		return x.Doors
+	}
+	return nil
+}
+
+func (x *CarState) GetMode() OperationMode {
+	if x != nil {
+		return x.Mode
+	}
+	return OperationMode_OPERATION_MODE_UNSPECIFIED
+}
+
+func (x *CarState) GetCarCalls() []int32 {
+	if x != nil {
+		return x.CarCalls
+	}
+	return nil
+}
+
+func (x *CarState) GetHallUpCalls() []int32 {
+	if x != nil {
+		return x.HallUpCalls
+	}
+	return nil
+}
+
+func (x *CarState) GetHallDownCalls() []int32 {
+	if x != nil {
+		return x.HallDownCalls
+	}
+	return nil
+}
+
+func (x *CarState) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *CarState) GetPosition() float64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *CarState) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *CarState) GetLevel() float64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *CarState) GetEmergency() EmergencyPhase {
+	if x != nil {
+		return x.Emergency
+	}
+	return EmergencyPhase_EMERGENCY_PHASE_UNSPECIFIED
+}
+
+func (x *CarState) GetObstructed() bool {
+	if x != nil {
+		return x.Obstructed
+	}
+	return false
+}
+
+func (x *CarState) GetNudging() bool {
+	if x != nil {
+		return x.Nudging
+	}
+	return false
+}
+
+func (x *CarState) GetFaults() []*Fault {
+	if x != nil {
+		return x.Faults
+	}
+	return nil
+}
+
+type GroupState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cars          []*CarState            `protobuf:"bytes,1,rep,name=cars,proto3" json:"cars,omitempty"`
+	HallUpCalls   []int32                `protobuf:"varint,2,rep,packed,name=hall_up_calls,json=hallUpCalls,proto3" json:"hall_up_calls,omitempty"`
+	HallDownCalls []int32                `protobuf:"varint,3,rep,packed,name=hall_down_calls,json=hallDownCalls,proto3" json:"hall_down_calls,omitempty"`
+	// kg
+	MaxWeight int32 `protobuf:"varint,4,opt,name=max_weight,json=maxWeight,proto3" json:"max_weight,omitempty"`
+	// Floors with an active smoke detector.
+	SmokeDetectors []int32 `protobuf:"varint,5,rep,packed,name=smoke_detectors,json=smokeDetectors,proto3" json:"smoke_detectors,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupState) Reset() {
+	*x = GroupState{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupState) ProtoMessage() {}
+
+func (x *GroupState) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupState.ProtoReflect.Descriptor instead.
+func (*GroupState) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GroupState) GetCars() []*CarState {
+	if x != nil {
+		return x.Cars
+	}
+	return nil
+}
+
+func (x *GroupState) GetHallUpCalls() []int32 {
+	if x != nil {
+		return x.HallUpCalls
+	}
+	return nil
+}
+
+func (x *GroupState) GetHallDownCalls() []int32 {
+	if x != nil {
+		return x.HallDownCalls
+	}
+	return nil
+}
+
+func (x *GroupState) GetMaxWeight() int32 {
+	if x != nil {
+		return x.MaxWeight
+	}
+	return 0
+}
+
+func (x *GroupState) GetSmokeDetectors() []int32 {
+	if x != nil {
+		return x.SmokeDetectors
+	}
+	return nil
+}
+
+// Event is a state change of a car. Type names the event as the engine does;
+// the payload depends on it.
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Car   int32                  `protobuf:"varint,1,opt,name=car,proto3" json:"car,omitempty"`
+	Type  string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Time  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Event_Floor
+	//	*Event_Direction
+	//	*Event_Mode
+	//	*Event_Door
+	//	*Event_Arrival
+	//	*Event_Position
+	//	*Event_Error
+	//	*Event_Passenger
+	//	*Event_Emergency
+	//	*Event_SmokeDetector
+	//	*Event_Recall
+	//	*Event_Obstruction
+	//	*Event_Nudging
+	Payload       isEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Event) GetCar() int32 {
+	if x != nil {
+		return x.Car
+	}
+	return 0
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetPayload() isEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Event) GetFloor() int32 {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Floor); ok {
+			return x.Floor
+		}
+	}
+	return 0
+}
+
+func (x *Event) GetDirection() Direction {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Direction); ok {
+			return x.Direction
+		}
+	}
+	return Direction_DIRECTION_UNSPECIFIED
+}
+
+func (x *Event) GetMode() OperationMode {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Mode); ok {
+			return x.Mode
+		}
+	}
+	return OperationMode_OPERATION_MODE_UNSPECIFIED
+}
+
+func (x *Event) GetDoor() *DoorChange {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Door); ok {
+			return x.Door
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetArrival() *Arrival {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Arrival); ok {
+			return x.Arrival
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetPosition() *Position {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Position); ok {
+			return x.Position
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetError() *ErrorDetail {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetPassenger() *PassengerMove {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Passenger); ok {
+			return x.Passenger
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetEmergency() *Emergency {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Emergency); ok {
+			return x.Emergency
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetSmokeDetector() *SmokeDetector {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_SmokeDetector); ok {
+			return x.SmokeDetector
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetRecall() *Recall {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Recall); ok {
+			return x.Recall
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetObstruction() *Obstruction {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Obstruction); ok {
+			return x.Obstruction
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetNudging() *Nudging {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Nudging); ok {
+			return x.Nudging
+		}
+	}
+	return nil
+}
+
+type isEvent_Payload interface {
+	isEvent_Payload()
+}
+
+type Event_Floor struct {
+	// FloorChange
+	Floor int32 `protobuf:"varint,4,opt,name=floor,proto3,oneof"`
+}
+
+type Event_Direction struct {
+	// DirectionChange
+	Direction Direction `protobuf:"varint,5,opt,name=direction,proto3,enum=elevator.v1.Direction,oneof"`
+}
+
+type Event_Mode struct {
+	// ModeChange
+	Mode OperationMode `protobuf:"varint,6,opt,name=mode,proto3,enum=elevator.v1.OperationMode,oneof"`
+}
+
+type Event_Door struct {
+	Door *DoorChange `protobuf:"bytes,7,opt,name=door,proto3,oneof"`
+}
+
+type Event_Arrival struct {
+	Arrival *Arrival `protobuf:"bytes,8,opt,name=arrival,proto3,oneof"`
+}
+
+type Event_Position struct {
+	Position *Position `protobuf:"bytes,9,opt,name=position,proto3,oneof"`
+}
+
+type Event_Error struct {
+	// Error, FaultCleared
+	Error *ErrorDetail `protobuf:"bytes,10,opt,name=error,proto3,oneof"`
+}
+
+type Event_Passenger struct {
+	// PassengerWaiting, PassengerBoarded, PassengerAlighted, PassengerEvacuated
+	Passenger *PassengerMove `protobuf:"bytes,11,opt,name=passenger,proto3,oneof"`
+}
+
+type Event_Emergency struct {
+	// EmergencyStop, EmergencyReset, RescueStarted, RescueComplete
+	Emergency *Emergency `protobuf:"bytes,12,opt,name=emergency,proto3,oneof"`
+}
+
+type Event_SmokeDetector struct {
+	SmokeDetector *SmokeDetector `protobuf:"bytes,13,opt,name=smoke_detector,json=smokeDetector,proto3,oneof"`
+}
+
+type Event_Recall struct {
+	// RecallStarted, RecallComplete
+	Recall *Recall `protobuf:"bytes,14,opt,name=recall,proto3,oneof"`
+}
+
+type Event_Obstruction struct {
+	Obstruction *Obstruction `protobuf:"bytes,15,opt,name=obstruction,proto3,oneof"`
+}
+
+type Event_Nudging struct {
+	Nudging *Nudging `protobuf:"bytes,16,opt,name=nudging,proto3,oneof"`
+}
+
+func (*Event_Floor) isEvent_Payload() {}
+
+func (*Event_Direction) isEvent_Payload() {}
+
+func (*Event_Mode) isEvent_Payload() {}
+
+func (*Event_Door) isEvent_Payload() {}
+
+func (*Event_Arrival) isEvent_Payload() {}
+
+func (*Event_Position) isEvent_Payload() {}
+
+func (*Event_Error) isEvent_Payload() {}
+
+func (*Event_Passenger) isEvent_Payload() {}
+
+func (*Event_Emergency) isEvent_Payload() {}
+
+func (*Event_SmokeDetector) isEvent_Payload() {}
+
+func (*Event_Recall) isEvent_Payload() {}
+
+func (*Event_Obstruction) isEvent_Payload() {}
+
+func (*Event_Nudging) isEvent_Payload() {}
+
+type DoorChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Side          DoorSide               `protobuf:"varint,1,opt,name=side,proto3,enum=elevator.v1.DoorSide" json:"side,omitempty"`
+	State         DoorState              `protobuf:"varint,2,opt,name=state,proto3,enum=elevator.v1.DoorState" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DoorChange) Reset() {
+	*x = DoorChange{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DoorChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoorChange) ProtoMessage() {}
+
+func (x *DoorChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoorChange.ProtoReflect.Descriptor instead.
+func (*DoorChange) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DoorChange) GetSide() DoorSide {
+	if x != nil {
+		return x.Side
+	}
+	return DoorSide_DOOR_SIDE_UNSPECIFIED
+}
+
+func (x *DoorChange) GetState() DoorState {
+	if x != nil {
+		return x.State
+	}
+	return DoorState_DOOR_STATE_UNSPECIFIED
+}
+
+type Arrival struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Floor         int32                  `protobuf:"varint,1,opt,name=floor,proto3" json:"floor,omitempty"`
+	OpenDoorSide  DoorSide               `protobuf:"varint,2,opt,name=open_door_side,json=openDoorSide,proto3,enum=elevator.v1.DoorSide" json:"open_door_side,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Arrival) Reset() {
+	*x = Arrival{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Arrival) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Arrival) ProtoMessage() {}
+
+func (x *Arrival) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Arrival.ProtoReflect.Descriptor instead.
+func (*Arrival) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Arrival) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *Arrival) GetOpenDoorSide() DoorSide {
+	if x != nil {
+		return x.OpenDoorSide
+	}
+	return DoorSide_DOOR_SIDE_UNSPECIFIED
+}
+
+type Position struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      float64                `protobuf:"fixed64,1,opt,name=position,proto3" json:"position,omitempty"`
+	Speed         float64                `protobuf:"fixed64,2,opt,name=speed,proto3" json:"speed,omitempty"`
+	Level         float64                `protobuf:"fixed64,3,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Position) GetPosition() float64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Position) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *Position) GetLevel() float64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type ErrorDetail struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Code  string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Rejected command; empty when a fault is injected or cleared.
+	Op            string `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`
+	Floor         int32  `protobuf:"varint,3,opt,name=floor,proto3" json:"floor,omitempty"`
+	Message       string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Fault         *Fault `protobuf:"bytes,5,opt,name=fault,proto3" json:"fault,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorDetail) Reset() {
+	*x = ErrorDetail{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorDetail) ProtoMessage() {}
+
+func (x *ErrorDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorDetail.ProtoReflect.Descriptor instead.
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ErrorDetail) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *ErrorDetail) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetFault() *Fault {
+	if x != nil {
+		return x.Fault
+	}
+	return nil
+}
+
+type PassengerMove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passenger     *Passenger             `protobuf:"bytes,1,opt,name=passenger,proto3" json:"passenger,omitempty"`
+	Floor         int32                  `protobuf:"varint,2,opt,name=floor,proto3" json:"floor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PassengerMove) Reset() {
+	*x = PassengerMove{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PassengerMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PassengerMove) ProtoMessage() {}
+
+func (x *PassengerMove) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PassengerMove.ProtoReflect.Descriptor instead.
+func (*PassengerMove) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PassengerMove) GetPassenger() *Passenger {
+	if x != nil {
+		return x.Passenger
+	}
+	return nil
+}
+
+func (x *PassengerMove) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+type Emergency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Floor         int32                  `protobuf:"varint,1,opt,name=floor,proto3" json:"floor,omitempty"`
+	Level         float64                `protobuf:"fixed64,2,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Emergency) Reset() {
+	*x = Emergency{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Emergency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Emergency) ProtoMessage() {}
+
+func (x *Emergency) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Emergency.ProtoReflect.Descriptor instead.
+func (*Emergency) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Emergency) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *Emergency) GetLevel() float64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type SmokeDetector struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Floor         int32                  `protobuf:"varint,1,opt,name=floor,proto3" json:"floor,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SmokeDetector) Reset() {
+	*x = SmokeDetector{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SmokeDetector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SmokeDetector) ProtoMessage() {}
+
+func (x *SmokeDetector) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SmokeDetector.ProtoReflect.Descriptor instead.
+func (*SmokeDetector) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SmokeDetector) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *SmokeDetector) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type Recall struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Floor int32                  `protobuf:"varint,1,opt,name=floor,proto3" json:"floor,omitempty"`
+	// Recalled to the alternate floor.
+	Alternate     bool `protobuf:"varint,2,opt,name=alternate,proto3" json:"alternate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Recall) Reset() {
+	*x = Recall{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recall) ProtoMessage() {}
+
+func (x *Recall) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recall.ProtoReflect.Descriptor instead.
+func (*Recall) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Recall) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *Recall) GetAlternate() bool {
+	if x != nil {
+		return x.Alternate
+	}
+	return false
+}
+
+type Obstruction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocked       bool                   `protobuf:"varint,1,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Obstruction) Reset() {
+	*x = Obstruction{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Obstruction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Obstruction) ProtoMessage() {}
+
+func (x *Obstruction) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Obstruction.ProtoReflect.Descriptor instead.
+func (*Obstruction) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Obstruction) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+type Nudging struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Active        bool                   `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Nudging) Reset() {
+	*x = Nudging{}
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Nudging) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nudging) ProtoMessage() {}
+
+func (x *Nudging) ProtoReflect() protoreflect.Message {
+	mi := &file_api_elevator_v1_elevator_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nudging.ProtoReflect.Descriptor instead.
+func (*Nudging) Descriptor() ([]byte, []int) {
+	return file_api_elevator_v1_elevator_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Nudging) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+var File_api_elevator_v1_elevator_proto protoreflect.FileDescriptor
+
+const file_api_elevator_v1_elevator_proto_rawDesc = "" +
+	"\n" +
+	"\x1eapi/elevator/v1/elevator.proto\x12\velevator.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x11\n" +
+	"\x0fGetStateRequest\"\x1e\n" +
+	"\n" +
+	"CarRequest\x12\x10\n" +
+	"\x03car\x18\x01 \x01(\x05R\x03car\"l\n" +
+	"\x0eAddCallRequest\x12\x10\n" +
+	"\x03car\x18\x01 \x01(\x05R\x03car\x12\x14\n" +
+	"\x05floor\x18\x02 \x01(\x05R\x05floor\x122\n" +
+	"\tcall_type\x18\x03 \x01(\x0e2\x15.elevator.v1.CallTypeR\bcallType\"o\n" +
+	"\x11RemoveCallRequest\x12\x10\n" +
+	"\x03car\x18\x01 \x01(\x05R\x03car\x12\x14\n" +
+	"\x05floor\x18\x02 \x01(\x05R\x05floor\x122\n" +
+	"\tcall_type\x18\x03 \x01(\x0e2\x15.elevator.v1.CallTypeR\bcallType\"K\n" +
+	"\x13AddPassengerRequest\x124\n" +
+	"\tpassenger\x18\x01 \x01(\v2\x16.elevator.v1.PassengerR\tpassenger\"<\n" +
+	"\x10AddWeightRequest\x12\x10\n" +
+	"\x03car\x18\x01 \x01(\x05R\x03car\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x05R\x06weight\"C\n" +
+	"\x15SetObstructionRequest\x12\x10\n" +
+	"\x03car\x18\x01 \x01(\x05R\x03car\x12\x18\n" +
+	"\ablocked\x18\x02 \x01(\bR\ablocked\"R\n" +
+	"\x0eSetModeRequest\x12\x10\n" +
+	"\x03car\x18\x01 \x01(\x05R\x03car\x12.\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x1a.elevator.v1.OperationModeR\x04mode\"Y\n" +
+	"\x0fPressJogRequest\x12\x10\n" +
+	"\x03car\x18\x01 \x01(\x05R\x03car\x124\n" +
+	"\tdirection\x18\x02 \x01(\x0e2\x16.elevator.v1.DirectionR\tdirection\"P\n" +
+	"\x12InjectFaultRequest\x12\x10\n" +
+	"\x03car\x18\x01 \x01(\x05R\x03car\x12(\n" +
+	"\x05fault\x18\x02 \x01(\v2\x12.elevator.v1.FaultR\x05fault\"Q\n" +
+	"\x11ClearFaultRequest\x12\x10\n" +
+	"\x03car\x18\x01 \x01(\x05R\x03car\x12*\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x16.elevator.v1.FaultKindR\x04kind\"G\n" +
+	"\x17SetSmokeDetectorRequest\x12\x14\n" +
+	"\x05floor\x18\x01 \x01(\x05R\x05floor\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"\x95\x01\n" +
+	"\x12WatchEventsRequest\x12\x12\n" +
+	"\x04cars\x18\x01 \x03(\x05R\x04cars\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\x12\x16\n" +
+	"\x06buffer\x18\x03 \x01(\x05R\x06buffer\x12=\n" +
+	"\fbackpressure\x18\x04 \x01(\x0e2\x19.elevator.v1.BackpressureR\fbackpressure\"\x9c\x02\n" +
+	"\tPassenger\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06origin\x18\x02 \x01(\x05R\x06origin\x12 \n" +
+	"\vdestination\x18\x03 \x01(\x05R\vdestination\x12\x12\n" +
+	"\x04mass\x18\x04 \x01(\x05R\x04mass\x129\n" +
+	"\n" +
+	"arrived_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tarrivedAt\x129\n" +
+	"\n" +
+	"boarded_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tboardedAt\x12;\n" +
+	"\valighted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"alightedAt\"\x97\x01\n" +
+	"\x05Fault\x12*\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x16.elevator.v1.FaultKindR\x04kind\x12\x14\n" +
+	"\x05floor\x18\x02 \x01(\x05R\x05floor\x124\n" +
+	"\tdirection\x18\x03 \x01(\x0e2\x16.elevator.v1.DirectionR\tdirection\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x01R\x06offset\"a\n" +
+	"\x05Doors\x12,\n" +
+	"\x05front\x18\x01 \x01(\x0e2\x16.elevator.v1.DoorStateR\x05front\x12*\n" +
+	"\x04rear\x18\x02 \x01(\x0e2\x16.elevator.v1.DoorStateR\x04rear\"\xbc\x04\n" +
+	"\bCarState\x12\x10\n" +
+	"\x03car\x18\x01 \x01(\x05R\x03car\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x14\n" +
+	"\x05floor\x18\x03 \x01(\x05R\x05floor\x124\n" +
+	"\tdirection\x18\x04 \x01(\x0e2\x16.elevator.v1.DirectionR\tdirection\x12(\n" +
+	"\x05doors\x18\x05 \x01(\v2\x12.elevator.v1.DoorsR\x05doors\x12.\n" +
+	"\x04mode\x18\x06 \x01(\x0e2\x1a.elevator.v1.OperationModeR\x04mode\x12\x1b\n" +
+	"\tcar_calls\x18\a \x03(\x05R\bcarCalls\x12\"\n" +
+	"\rhall_up_calls\x18\b \x03(\x05R\vhallUpCalls\x12&\n" +
+	"\x0fhall_down_calls\x18\t \x03(\x05R\rhallDownCalls\x12\x16\n" +
+	"\x06weight\x18\n" +
+	" \x01(\x05R\x06weight\x12\x1a\n" +
+	"\bposition\x18\v \x01(\x01R\bposition\x12\x14\n" +
+	"\x05speed\x18\f \x01(\x01R\x05speed\x12\x14\n" +
+	"\x05level\x18\r \x01(\x01R\x05level\x129\n" +
+	"\temergency\x18\x0e \x01(\x0e2\x1b.elevator.v1.EmergencyPhaseR\temergency\x12\x1e\n" +
+	"\n" +
+	"obstructed\x18\x0f \x01(\bR\n" +
+	"obstructed\x12\x18\n" +
+	"\anudging\x18\x10 \x01(\bR\anudging\x12*\n" +
+	"\x06faults\x18\x11 \x03(\v2\x12.elevator.v1.FaultR\x06faults\"\xcb\x01\n" +
+	"\n" +
+	"GroupState\x12)\n" +
+	"\x04cars\x18\x01 \x03(\v2\x15.elevator.v1.CarStateR\x04cars\x12\"\n" +
+	"\rhall_up_calls\x18\x02 \x03(\x05R\vhallUpCalls\x12&\n" +
+	"\x0fhall_down_calls\x18\x03 \x03(\x05R\rhallDownCalls\x12\x1d\n" +
+	"\n" +
+	"max_weight\x18\x04 \x01(\x05R\tmaxWeight\x12'\n" +
+	"\x0fsmoke_detectors\x18\x05 \x03(\x05R\x0esmokeDetectors\"\x8a\x06\n" +
+	"\x05Event\x12\x10\n" +
+	"\x03car\x18\x01 \x01(\x05R\x03car\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x16\n" +
+	"\x05floor\x18\x04 \x01(\x05H\x00R\x05floor\x126\n" +
+	"\tdirection\x18\x05 \x01(\x0e2\x16.elevator.v1.DirectionH\x00R\tdirection\x120\n" +
+	"\x04mode\x18\x06 \x01(\x0e2\x1a.elevator.v1.OperationModeH\x00R\x04mode\x12-\n" +
+	"\x04door\x18\a \x01(\v2\x17.elevator.v1.DoorChangeH\x00R\x04door\x120\n" +
+	"\aarrival\x18\b \x01(\v2\x14.elevator.v1.ArrivalH\x00R\aarrival\x123\n" +
+	"\bposition\x18\t \x01(\v2\x15.elevator.v1.PositionH\x00R\bposition\x120\n" +
+	"\x05error\x18\n" +
+	" \x01(\v2\x18.elevator.v1.ErrorDetailH\x00R\x05error\x12:\n" +
+	"\tpassenger\x18\v \x01(\v2\x1a.elevator.v1.PassengerMoveH\x00R\tpassenger\x126\n" +
+	"\temergency\x18\f \x01(\v2\x16.elevator.v1.EmergencyH\x00R\temergency\x12C\n" +
+	"\x0esmoke_detector\x18\r \x01(\v2\x1a.elevator.v1.SmokeDetectorH\x00R\rsmokeDetector\x12-\n" +
+	"\x06recall\x18\x0e \x01(\v2\x13.elevator.v1.RecallH\x00R\x06recall\x12<\n" +
+	"\vobstruction\x18\x0f \x01(\v2\x18.elevator.v1.ObstructionH\x00R\vobstruction\x120\n" +
+	"\anudging\x18\x10 \x01(\v2\x14.elevator.v1.NudgingH\x00R\anudgingB\t\n" +
+	"\apayload\"e\n" +
+	"\n" +
+	"DoorChange\x12)\n" +
+	"\x04side\x18\x01 \x01(\x0e2\x15.elevator.v1.DoorSideR\x04side\x12,\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x16.elevator.v1.DoorStateR\x05state\"\\\n" +
+	"\aArrival\x12\x14\n" +
+	"\x05floor\x18\x01 \x01(\x05R\x05floor\x12;\n" +
+	"\x0eopen_door_side\x18\x02 \x01(\x0e2\x15.elevator.v1.DoorSideR\fopenDoorSide\"R\n" +
+	"\bPosition\x12\x1a\n" +
+	"\bposition\x18\x01 \x01(\x01R\bposition\x12\x14\n" +
+	"\x05speed\x18\x02 \x01(\x01R\x05speed\x12\x14\n" +
+	"\x05level\x18\x03 \x01(\x01R\x05level\"\x8b\x01\n" +
+	"\vErrorDetail\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x0e\n" +
+	"\x02op\x18\x02 \x01(\tR\x02op\x12\x14\n" +
+	"\x05floor\x18\x03 \x01(\x05R\x05floor\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12(\n" +
+	"\x05fault\x18\x05 \x01(\v2\x12.elevator.v1.FaultR\x05fault\"[\n" +
+	"\rPassengerMove\x124\n" +
+	"\tpassenger\x18\x01 \x01(\v2\x16.elevator.v1.PassengerR\tpassenger\x12\x14\n" +
+	"\x05floor\x18\x02 \x01(\x05R\x05floor\"7\n" +
+	"\tEmergency\x12\x14\n" +
+	"\x05floor\x18\x01 \x01(\x05R\x05floor\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\x01R\x05level\"=\n" +
+	"\rSmokeDetector\x12\x14\n" +
+	"\x05floor\x18\x01 \x01(\x05R\x05floor\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"<\n" +
+	"\x06Recall\x12\x14\n" +
+	"\x05floor\x18\x01 \x01(\x05R\x05floor\x12\x1c\n" +
+	"\talternate\x18\x02 \x01(\bR\talternate\"'\n" +
+	"\vObstruction\x12\x18\n" +
+	"\ablocked\x18\x01 \x01(\bR\ablocked\"!\n" +
+	"\aNudging\x12\x16\n" +
+	"\x06active\x18\x01 \x01(\bR\x06active*h\n" +
+	"\bCallType\x12\x19\n" +
+	"\x15CALL_TYPE_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rCALL_TYPE_CAR\x10\x01\x12\x15\n" +
+	"\x11CALL_TYPE_HALL_UP\x10\x02\x12\x17\n" +
+	"\x13CALL_TYPE_HALL_DOWN\x10\x03*`\n" +
+	"\tDirection\x12\x19\n" +
+	"\x15DIRECTION_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fDIRECTION_UP\x10\x01\x12\x12\n" +
+	"\x0eDIRECTION_DOWN\x10\x02\x12\x12\n" +
+	"\x0eDIRECTION_NONE\x10\x03*b\n" +
+	"\bDoorSide\x12\x19\n" +
+	"\x15DOOR_SIDE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fDOOR_SIDE_FRONT\x10\x01\x12\x12\n" +
+	"\x0eDOOR_SIDE_REAR\x10\x02\x12\x12\n" +
+	"\x0eDOOR_SIDE_BOTH\x10\x03*\x82\x01\n" +
+	"\tDoorState\x12\x1a\n" +
+	"\x16DOOR_STATE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fDOOR_STATE_OPEN\x10\x01\x12\x16\n" +
+	"\x12DOOR_STATE_OPENING\x10\x02\x12\x16\n" +
+	"\x12DOOR_STATE_CLOSING\x10\x03\x12\x14\n" +
+	"\x10DOOR_STATE_CLOSE\x10\x04*\xdd\x01\n" +
+	"\rOperationMode\x12\x1e\n" +
+	"\x1aOPERATION_MODE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13OPERATION_MODE_AUTO\x10\x01\x12\x19\n" +
+	"\x15OPERATION_MODE_MANUAL\x10\x02\x12\x19\n" +
+	"\x15OPERATION_MODE_MOVING\x10\x03\x12\x1c\n" +
+	"\x18OPERATION_MODE_EMERGENCY\x10\x04\x12\x1e\n" +
+	"\x1aOPERATION_MODE_FIRE_RECALL\x10\x05\x12\x1f\n" +
+	"\x1bOPERATION_MODE_FIRE_SERVICE\x10\x06*\xa2\x01\n" +
+	"\x0eEmergencyPhase\x12\x1f\n" +
+	"\x1bEMERGENCY_PHASE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17EMERGENCY_PHASE_LATCHED\x10\x01\x12\x19\n" +
+	"\x15EMERGENCY_PHASE_RESET\x10\x02\x12\x1a\n" +
+	"\x16EMERGENCY_PHASE_RESCUE\x10\x03\x12\x1b\n" +
+	"\x17EMERGENCY_PHASE_RESCUED\x10\x04*\xea\x01\n" +
+	"\tFaultKind\x12\x1a\n" +
+	"\x16FAULT_KIND_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13FAULT_KIND_DOOR_JAM\x10\x01\x12\x18\n" +
+	"\x14FAULT_KIND_DOOR_LOCK\x10\x02\x12\x19\n" +
+	"\x15FAULT_KIND_DRIVE_TRIP\x10\x03\x12\x17\n" +
+	"\x13FAULT_KIND_LEVELING\x10\x04\x12\x18\n" +
+	"\x14FAULT_KIND_OVERSPEED\x10\x05\x12\x1e\n" +
+	"\x1aFAULT_KIND_POSITION_SENSOR\x10\x06\x12 \n" +
+	"\x1cFAULT_KIND_STUCK_HALL_BUTTON\x10\a*\x84\x01\n" +
+	"\fBackpressure\x12\x1c\n" +
+	"\x18BACKPRESSURE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18BACKPRESSURE_DROP_NEWEST\x10\x01\x12\x16\n" +
+	"\x12BACKPRESSURE_BLOCK\x10\x02\x12 \n" +
+	"\x1cBACKPRESSURE_COALESCE_LATEST\x10\x032\x99\v\n" +
+	"\x0fElevatorService\x12A\n" +
+	"\bGetState\x12\x1c.elevator.v1.GetStateRequest\x1a\x17.elevator.v1.GroupState\x12=\n" +
+	"\aAddCall\x12\x1b.elevator.v1.AddCallRequest\x1a\x15.elevator.v1.CarState\x12C\n" +
+	"\n" +
+	"RemoveCall\x12\x1e.elevator.v1.RemoveCallRequest\x1a\x15.elevator.v1.CarState\x12<\n" +
+	"\n" +
+	"ClearCalls\x12\x17.elevator.v1.CarRequest\x1a\x15.elevator.v1.CarState\x12G\n" +
+	"\fAddPassenger\x12 .elevator.v1.AddPassengerRequest\x1a\x15.elevator.v1.CarState\x12A\n" +
+	"\tAddWeight\x12\x1d.elevator.v1.AddWeightRequest\x1a\x15.elevator.v1.CarState\x12A\n" +
+	"\x0fPressOpenButton\x12\x17.elevator.v1.CarRequest\x1a\x15.elevator.v1.CarState\x12C\n" +
+	"\x11ReleaseOpenButton\x12\x17.elevator.v1.CarRequest\x1a\x15.elevator.v1.CarState\x12B\n" +
+	"\x10PressCloseButton\x12\x17.elevator.v1.CarRequest\x1a\x15.elevator.v1.CarState\x12D\n" +
+	"\x12ReleaseCloseButton\x12\x17.elevator.v1.CarRequest\x1a\x15.elevator.v1.CarState\x12K\n" +
+	"\x0eSetObstruction\x12\".elevator.v1.SetObstructionRequest\x1a\x15.elevator.v1.CarState\x12=\n" +
+	"\aSetMode\x12\x1b.elevator.v1.SetModeRequest\x1a\x15.elevator.v1.CarState\x127\n" +
+	"\x05Reset\x12\x17.elevator.v1.CarRequest\x1a\x15.elevator.v1.CarState\x12@\n" +
+	"\x0eResetEmergency\x12\x17.elevator.v1.CarRequest\x1a\x15.elevator.v1.CarState\x128\n" +
+	"\x06Rescue\x12\x17.elevator.v1.CarRequest\x1a\x15.elevator.v1.CarState\x12?\n" +
+	"\bPressJog\x12\x1c.elevator.v1.PressJogRequest\x1a\x15.elevator.v1.CarState\x12<\n" +
+	"\n" +
+	"ReleaseJog\x12\x17.elevator.v1.CarRequest\x1a\x15.elevator.v1.CarState\x12E\n" +
+	"\vInjectFault\x12\x1f.elevator.v1.InjectFaultRequest\x1a\x15.elevator.v1.CarState\x12C\n" +
+	"\n" +
+	"ClearFault\x12\x1e.elevator.v1.ClearFaultRequest\x1a\x15.elevator.v1.CarState\x12Q\n" +
+	"\x10SetSmokeDetector\x12$.elevator.v1.SetSmokeDetectorRequest\x1a\x17.elevator.v1.GroupState\x12D\n" +
+	"\vWatchEvents\x12\x1f.elevator.v1.WatchEventsRequest\x1a\x12.elevator.v1.Event0\x01B2Z0go-elevator-simulator/api/elevator/v1;elevatorv1b\x06proto3"
+
+var (
+	file_api_elevator_v1_elevator_proto_rawDescOnce sync.Once
+	file_api_elevator_v1_elevator_proto_rawDescData []byte
+)
+
+func file_api_elevator_v1_elevator_proto_rawDescGZIP() []byte {
+	file_api_elevator_v1_elevator_proto_rawDescOnce.Do(func() {
+		file_api_elevator_v1_elevator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_elevator_v1_elevator_proto_rawDesc), len(file_api_elevator_v1_elevator_proto_rawDesc)))
+	})
+	return file_api_elevator_v1_elevator_proto_rawDescData
+}
+
+var file_api_elevator_v1_elevator_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_api_elevator_v1_elevator_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_api_elevator_v1_elevator_proto_goTypes = []any{
+	(CallType)(0),                   // 0: elevator.v1.CallType
+	(Direction)(0),                  // 1: elevator.v1.Direction
+	(DoorSide)(0),                   // 2: elevator.v1.DoorSide
+	(DoorState)(0),                  // 3: elevator.v1.DoorState
+	(OperationMode)(0),              // 4: elevator.v1.OperationMode
+	(EmergencyPhase)(0),             // 5: elevator.v1.EmergencyPhase
+	(FaultKind)(0),                  // 6: elevator.v1.FaultKind
+	(Backpressure)(0),               // 7: elevator.v1.Backpressure
+	(*GetStateRequest)(nil),         // 8: elevator.v1.GetStateRequest
+	(*CarRequest)(nil),              // 9: elevator.v1.CarRequest
+	(*AddCallRequest)(nil),          // 10: elevator.v1.AddCallRequest
+	(*RemoveCallRequest)(nil),       // 11: elevator.v1.RemoveCallRequest
+	(*AddPassengerRequest)(nil),     // 12: elevator.v1.AddPassengerRequest
+	(*AddWeightRequest)(nil),        // 13: elevator.v1.AddWeightRequest
+	(*SetObstructionRequest)(nil),   // 14: elevator.v1.SetObstructionRequest
+	(*SetModeRequest)(nil),          // 15: elevator.v1.SetModeRequest
+	(*PressJogRequest)(nil),         // 16: elevator.v1.PressJogRequest
+	(*InjectFaultRequest)(nil),      // 17: elevator.v1.InjectFaultRequest
+	(*ClearFaultRequest)(nil),       // 18: elevator.v1.ClearFaultRequest
+	(*SetSmokeDetectorRequest)(nil), // 19: elevator.v1.SetSmokeDetectorRequest
+	(*WatchEventsRequest)(nil),      // 20: elevator.v1.WatchEventsRequest
+	(*Passenger)(nil),               // 21: elevator.v1.Passenger
+	(*Fault)(nil),                   // 22: elevator.v1.Fault
+	(*Doors)(nil),                   // 23: elevator.v1.Doors
+	(*CarState)(nil),                // 24: elevator.v1.CarState
+	(*GroupState)(nil),              // 25: elevator.v1.GroupState
+	(*Event)(nil),                   // 26: elevator.v1.Event
+	(*DoorChange)(nil),              // 27: elevator.v1.DoorChange
+	(*Arrival)(nil),                 // 28: elevator.v1.Arrival
+	(*Position)(nil),                // 29: elevator.v1.Position
+	(*ErrorDetail)(nil),             // 30: elevator.v1.ErrorDetail
+	(*PassengerMove)(nil),           // 31: elevator.v1.PassengerMove
+	(*Emergency)(nil),               // 32: elevator.v1.Emergency
+	(*SmokeDetector)(nil),           // 33: elevator.v1.SmokeDetector
+	(*Recall)(nil),                  // 34: elevator.v1.Recall
+	(*Obstruction)(nil),             // 35: elevator.v1.Obstruction
+	(*Nudging)(nil),                 // 36: elevator.v1.Nudging
+	(*timestamppb.Timestamp)(nil),   // 37: google.protobuf.Timestamp
+}
+var file_api_elevator_v1_elevator_proto_depIdxs = []int32{
+	0,  // 0: elevator.v1.AddCallRequest.call_type:type_name -> elevator.v1.CallType
+	0,  // 1: elevator.v1.RemoveCallRequest.call_type:type_name -> elevator.v1.CallType
+	21, // 2: elevator.v1.AddPassengerRequest.passenger:type_name -> elevator.v1.Passenger
+	4,  // 3: elevator.v1.SetModeRequest.mode:type_name -> elevator.v1.OperationMode
+	1,  // 4: elevator.v1.PressJogRequest.direction:type_name -> elevator.v1.Direction
+	22, // 5: elevator.v1.InjectFaultRequest.fault:type_name -> elevator.v1.Fault
+	6,  // 6: elevator.v1.ClearFaultRequest.kind:type_name -> elevator.v1.FaultKind
+	7,  // 7: elevator.v1.WatchEventsRequest.backpressure:type_name -> elevator.v1.Backpressure
+	37, // 8: elevator.v1.Passenger.arrived_at:type_name -> google.protobuf.Timestamp
+	37, // 9: elevator.v1.Passenger.boarded_at:type_name -> google.protobuf.Timestamp
+	37, // 10: elevator.v1.Passenger.alighted_at:type_name -> google.protobuf.Timestamp
+	6,  // 11: elevator.v1.Fault.kind:type_name -> elevator.v1.FaultKind
+	1,  // 12: elevator.v1.Fault.direction:type_name -> elevator.v1.Direction
+	3,  // 13: elevator.v1.Doors.front:type_name -> elevator.v1.DoorState
+	3,  // 14: elevator.v1.Doors.rear:type_name -> elevator.v1.DoorState
+	1,  // 15: elevator.v1.CarState.direction:type_name -> elevator.v1.Direction
+	23, // 16: elevator.v1.CarState.doors:type_name -> elevator.v1.Doors
+	4,  // 17: elevator.v1.CarState.mode:type_name -> elevator.v1.OperationMode
+	5,  // 18: elevator.v1.CarState.emergency:type_name -> elevator.v1.EmergencyPhase
+	22, // 19: elevator.v1.CarState.faults:type_name -> elevator.v1.Fault
+	24, // 20: elevator.v1.GroupState.cars:type_name -> elevator.v1.CarState
+	37, // 21: elevator.v1.Event.time:type_name -> google.protobuf.Timestamp
+	1,  // 22: elevator.v1.Event.direction:type_name -> elevator.v1.Direction
+	4,  // 23: elevator.v1.Event.mode:type_name -> elevator.v1.OperationMode
+	27, // 24: elevator.v1.Event.door:type_name -> elevator.v1.DoorChange
+	28, // 25: elevator.v1.Event.arrival:type_name -> elevator.v1.Arrival
+	29, // 26: elevator.v1.Event.position:type_name -> elevator.v1.Position
+	30, // 27: elevator.v1.Event.error:type_name -> elevator.v1.ErrorDetail
+	31, // 28: elevator.v1.Event.passenger:type_name -> elevator.v1.PassengerMove
+	32, // 29: elevator.v1.Event.emergency:type_name -> elevator.v1.Emergency
+	33, // 30: elevator.v1.Event.smoke_detector:type_name -> elevator.v1.SmokeDetector
+	34, // 31: elevator.v1.Event.recall:type_name -> elevator.v1.Recall
+	35, // 32: elevator.v1.Event.obstruction:type_name -> elevator.v1.Obstruction
+	36, // 33: elevator.v1.Event.nudging:type_name -> elevator.v1.Nudging
+	2,  // 34: elevator.v1.DoorChange.side:type_name -> elevator.v1.DoorSide
+	3,  // 35: elevator.v1.DoorChange.state:type_name -> elevator.v1.DoorState
+	2,  // 36: elevator.v1.Arrival.open_door_side:type_name -> elevator.v1.DoorSide
+	22, // 37: elevator.v1.ErrorDetail.fault:type_name -> elevator.v1.Fault
+	21, // 38: elevator.v1.PassengerMove.passenger:type_name -> elevator.v1.Passenger
+	8,  // 39: elevator.v1.ElevatorService.GetState:input_type -> elevator.v1.GetStateRequest
+	10, // 40: elevator.v1.ElevatorService.AddCall:input_type -> elevator.v1.AddCallRequest
+	11, // 41: elevator.v1.ElevatorService.RemoveCall:input_type -> elevator.v1.RemoveCallRequest
+	9,  // 42: elevator.v1.ElevatorService.ClearCalls:input_type -> elevator.v1.CarRequest
+	12, // 43: elevator.v1.ElevatorService.AddPassenger:input_type -> elevator.v1.AddPassengerRequest
+	13, // 44: elevator.v1.ElevatorService.AddWeight:input_type -> elevator.v1.AddWeightRequest
+	9,  // 45: elevator.v1.ElevatorService.PressOpenButton:input_type -> elevator.v1.CarRequest
+	9,  // 46: elevator.v1.ElevatorService.ReleaseOpenButton:input_type -> elevator.v1.CarRequest
+	9,  // 47: elevator.v1.ElevatorService.PressCloseButton:input_type -> elevator.v1.CarRequest
+	9,  // 48: elevator.v1.ElevatorService.ReleaseCloseButton:input_type -> elevator.v1.CarRequest
+	14, // 49: elevator.v1.ElevatorService.SetObstruction:input_type -> elevator.v1.SetObstructionRequest
+	15, // 50: elevator.v1.ElevatorService.SetMode:input_type -> elevator.v1.SetModeRequest
+	9,  // 51: elevator.v1.ElevatorService.Reset:input_type -> elevator.v1.CarRequest
+	9,  // 52: elevator.v1.ElevatorService.ResetEmergency:input_type -> elevator.v1.CarRequest
+	9,  // 53: elevator.v1.ElevatorService.Rescue:input_type -> elevator.v1.CarRequest
+	16, // 54: elevator.v1.ElevatorService.PressJog:input_type -> elevator.v1.PressJogRequest
+	9,  // 55: elevator.v1.ElevatorService.ReleaseJog:input_type -> elevator.v1.CarRequest
+	17, // 56: elevator.v1.ElevatorService.InjectFault:input_type -> elevator.v1.InjectFaultRequest
+	18, // 57: elevator.v1.ElevatorService.ClearFault:input_type -> elevator.v1.ClearFaultRequest
+	19, // 58: elevator.v1.ElevatorService.SetSmokeDetector:input_type -> elevator.v1.SetSmokeDetectorRequest
+	20, // 59: elevator.v1.ElevatorService.WatchEvents:input_type -> elevator.v1.WatchEventsRequest
+	25, // 60: elevator.v1.ElevatorService.GetState:output_type -> elevator.v1.GroupState
+	24, // 61: elevator.v1.ElevatorService.AddCall:output_type -> elevator.v1.CarState
+	24, // 62: elevator.v1.ElevatorService.RemoveCall:output_type -> elevator.v1.CarState
+	24, // 63: elevator.v1.ElevatorService.ClearCalls:output_type -> elevator.v1.CarState
+	24, // 64: elevator.v1.ElevatorService.AddPassenger:output_type -> elevator.v1.CarState
+	24, // 65: elevator.v1.ElevatorService.AddWeight:output_type -> elevator.v1.CarState
+	24, // 66: elevator.v1.ElevatorService.PressOpenButton:output_type -> elevator.v1.CarState
+	24, // 67: elevator.v1.ElevatorService.ReleaseOpenButton:output_type -> elevator.v1.CarState
+	24, // 68: elevator.v1.ElevatorService.PressCloseButton:output_type -> elevator.v1.CarState
+	24, // 69: elevator.v1.ElevatorService.ReleaseCloseButton:output_type -> elevator.v1.CarState
+	24, // 70: elevator.v1.ElevatorService.SetObstruction:output_type -> elevator.v1.CarState
+	24, // 71: elevator.v1.ElevatorService.SetMode:output_type -> elevator.v1.CarState
+	24, // 72: elevator.v1.ElevatorService.Reset:output_type -> elevator.v1.CarState
+	24, // 73: elevator.v1.ElevatorService.ResetEmergency:output_type -> elevator.v1.CarState
+	24, // 74: elevator.v1.ElevatorService.Rescue:output_type -> elevator.v1.CarState
+	24, // 75: elevator.v1.ElevatorService.PressJog:output_type -> elevator.v1.CarState
+	24, // 76: elevator.v1.ElevatorService.ReleaseJog:output_type -> elevator.v1.CarState
+	24, // 77: elevator.v1.ElevatorService.InjectFault:output_type -> elevator.v1.CarState
+	24, // 78: elevator.v1.ElevatorService.ClearFault:output_type -> elevator.v1.CarState
+	25, // 79: elevator.v1.ElevatorService.SetSmokeDetector:output_type -> elevator.v1.GroupState
+	26, // 80: elevator.v1.ElevatorService.WatchEvents:output_type -> elevator.v1.Event
+	60, // [60:81] is the sub-list for method output_type
+	39, // [39:60] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
+}
+
+func init() { file_api_elevator_v1_elevator_proto_init() }
+func file_api_elevator_v1_elevator_proto_init() {
+	if File_api_elevator_v1_elevator_proto != nil {
+		return
+	}
+	file_api_elevator_v1_elevator_proto_msgTypes[18].OneofWrappers = []any{
+		(*Event_Floor)(nil),
+		(*Event_Direction)(nil),
+		(*Event_Mode)(nil),
+		(*Event_Door)(nil),
+		(*Event_Arrival)(nil),
+		(*Event_Position)(nil),
+		(*Event_Error)(nil),
+		(*Event_Passenger)(nil),
+		(*Event_Emergency)(nil),
+		(*Event_SmokeDetector)(nil),
+		(*Event_Recall)(nil),
+		(*Event_Obstruction)(nil),
+		(*Event_Nudging)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_elevator_v1_elevator_proto_rawDesc), len(file_api_elevator_v1_elevator_proto_rawDesc)),
+			NumEnums:      8,
+			NumMessages:   29,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_elevator_v1_elevator_proto_goTypes,
+		DependencyIndexes: file_api_elevator_v1_elevator_proto_depIdxs,
+		EnumInfos:         file_api_elevator_v1_elevator_proto_enumTypes,
+		MessageInfos:      file_api_elevator_v1_elevator_proto_msgTypes,
+	}.Build()
+	File_api_elevator_v1_elevator_proto = out.File
+	file_api_elevator_v1_elevator_proto_goTypes = nil
+	file_api_elevator_v1_elevator_proto_depIdxs = nil
+}
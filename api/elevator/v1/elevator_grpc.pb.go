@@ -0,0 +1,955 @@
+// gRPC API of the elevator simulator.
+//
+// ElevatorService controls one elevator group and streams its events. It is
+// served by package go-elevator-simulator/pkg/grpcserver, which a program
+// embedding the simulator registers on its own grpc.Server, and by the
+// elevator-grpc command.
+//
+// Cars are addressed by their index in the group, from 0. A rejected command
+// fails with a gRPC status whose ErrorInfo detail carries the engine error
+// code as its reason (OutOfRange, Inaccessible, ModeForbids, Overload,
+// Invalid, State, Fault, Unavailable).
+//
+// Regenerate the Go code with protoc-gen-go and protoc-gen-go-grpc:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//	  --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+//	  api/elevator/v1/elevator.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api/elevator/v1/elevator.proto
+
+package elevatorv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ElevatorService_GetState_FullMethodName           = "/elevator.v1.ElevatorService/GetState"
+	ElevatorService_AddCall_FullMethodName            = "/elevator.v1.ElevatorService/AddCall"
+	ElevatorService_RemoveCall_FullMethodName         = "/elevator.v1.ElevatorService/RemoveCall"
+	ElevatorService_ClearCalls_FullMethodName         = "/elevator.v1.ElevatorService/ClearCalls"
+	ElevatorService_AddPassenger_FullMethodName       = "/elevator.v1.ElevatorService/AddPassenger"
+	ElevatorService_AddWeight_FullMethodName          = "/elevator.v1.ElevatorService/AddWeight"
+	ElevatorService_PressOpenButton_FullMethodName    = "/elevator.v1.ElevatorService/PressOpenButton"
+	ElevatorService_ReleaseOpenButton_FullMethodName  = "/elevator.v1.ElevatorService/ReleaseOpenButton"
+	ElevatorService_PressCloseButton_FullMethodName   = "/elevator.v1.ElevatorService/PressCloseButton"
+	ElevatorService_ReleaseCloseButton_FullMethodName = "/elevator.v1.ElevatorService/ReleaseCloseButton"
+	ElevatorService_SetObstruction_FullMethodName     = "/elevator.v1.ElevatorService/SetObstruction"
+	ElevatorService_SetMode_FullMethodName            = "/elevator.v1.ElevatorService/SetMode"
+	ElevatorService_Reset_FullMethodName              = "/elevator.v1.ElevatorService/Reset"
+	ElevatorService_ResetEmergency_FullMethodName     = "/elevator.v1.ElevatorService/ResetEmergency"
+	ElevatorService_Rescue_FullMethodName             = "/elevator.v1.ElevatorService/Rescue"
+	ElevatorService_PressJog_FullMethodName           = "/elevator.v1.ElevatorService/PressJog"
+	ElevatorService_ReleaseJog_FullMethodName         = "/elevator.v1.ElevatorService/ReleaseJog"
+	ElevatorService_InjectFault_FullMethodName        = "/elevator.v1.ElevatorService/InjectFault"
+	ElevatorService_ClearFault_FullMethodName         = "/elevator.v1.ElevatorService/ClearFault"
+	ElevatorService_SetSmokeDetector_FullMethodName   = "/elevator.v1.ElevatorService/SetSmokeDetector"
+	ElevatorService_WatchEvents_FullMethodName        = "/elevator.v1.ElevatorService/WatchEvents"
+)
+
+// ElevatorServiceClient is the client API for ElevatorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ElevatorServiceClient interface {
+	// GetState returns the state of every car and of the group.
+	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GroupState, error)
+	// AddCall registers a call. A car call goes to the given car; a hall call
+	// is assigned to a car by the group, and the state of that car is returned.
+	AddCall(ctx context.Context, in *AddCallRequest, opts ...grpc.CallOption) (*CarState, error)
+	// RemoveCall cancels a call. A hall call is cancelled on every car.
+	RemoveCall(ctx context.Context, in *RemoveCallRequest, opts ...grpc.CallOption) (*CarState, error)
+	// ClearCalls cancels every call of a car.
+	ClearCalls(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error)
+	// AddPassenger puts a passenger on its origin landing and assigns its hall
+	// call to a car, whose state is returned.
+	AddPassenger(ctx context.Context, in *AddPassengerRequest, opts ...grpc.CallOption) (*CarState, error)
+	// AddWeight adds load to a car; a negative weight removes load.
+	AddWeight(ctx context.Context, in *AddWeightRequest, opts ...grpc.CallOption) (*CarState, error)
+	// PressOpenButton opens the doors and holds them open until
+	// ReleaseOpenButton.
+	PressOpenButton(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error)
+	ReleaseOpenButton(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error)
+	// PressCloseButton closes the doors early. An overloaded car keeps its
+	// doors open and publishes an Error event.
+	PressCloseButton(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error)
+	ReleaseCloseButton(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error)
+	// SetObstruction reports the door light curtain as blocked or clear.
+	SetObstruction(ctx context.Context, in *SetObstructionRequest, opts ...grpc.CallOption) (*CarState, error)
+	// SetMode changes the operation mode of a car.
+	SetMode(ctx context.Context, in *SetModeRequest, opts ...grpc.CallOption) (*CarState, error)
+	// Reset returns a car to its initial floor and state.
+	Reset(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error)
+	// ResetEmergency acknowledges a latched emergency stop.
+	ResetEmergency(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error)
+	// Rescue moves a car stopped between floors to the nearest landing after
+	// ResetEmergency.
+	Rescue(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error)
+	// PressJog moves a car on inspection until ReleaseJog.
+	PressJog(ctx context.Context, in *PressJogRequest, opts ...grpc.CallOption) (*CarState, error)
+	ReleaseJog(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error)
+	// InjectFault injects an equipment failure into a car.
+	InjectFault(ctx context.Context, in *InjectFaultRequest, opts ...grpc.CallOption) (*CarState, error)
+	// ClearFault repairs a failure injected into a car.
+	ClearFault(ctx context.Context, in *ClearFaultRequest, opts ...grpc.CallOption) (*CarState, error)
+	// SetSmokeDetector reports a smoke detector on a floor as active or clear.
+	// An active detector recalls every car for fire service.
+	SetSmokeDetector(ctx context.Context, in *SetSmokeDetectorRequest, opts ...grpc.CallOption) (*GroupState, error)
+	// WatchEvents streams the events of the group as they happen, until the
+	// client cancels the call. The response header is sent once the stream is
+	// subscribed: every event published after it is received is streamed.
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type elevatorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewElevatorServiceClient(cc grpc.ClientConnInterface) ElevatorServiceClient {
+	return &elevatorServiceClient{cc}
+}
+
+func (c *elevatorServiceClient) GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GroupState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupState)
+	err := c.cc.Invoke(ctx, ElevatorService_GetState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) AddCall(ctx context.Context, in *AddCallRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_AddCall_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) RemoveCall(ctx context.Context, in *RemoveCallRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_RemoveCall_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) ClearCalls(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_ClearCalls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) AddPassenger(ctx context.Context, in *AddPassengerRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_AddPassenger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) AddWeight(ctx context.Context, in *AddWeightRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_AddWeight_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) PressOpenButton(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_PressOpenButton_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) ReleaseOpenButton(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_ReleaseOpenButton_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) PressCloseButton(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_PressCloseButton_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) ReleaseCloseButton(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_ReleaseCloseButton_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) SetObstruction(ctx context.Context, in *SetObstructionRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_SetObstruction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) SetMode(ctx context.Context, in *SetModeRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_SetMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) Reset(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_Reset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) ResetEmergency(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_ResetEmergency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) Rescue(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_Rescue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) PressJog(ctx context.Context, in *PressJogRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_PressJog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) ReleaseJog(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_ReleaseJog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) InjectFault(ctx context.Context, in *InjectFaultRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_InjectFault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) ClearFault(ctx context.Context, in *ClearFaultRequest, opts ...grpc.CallOption) (*CarState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarState)
+	err := c.cc.Invoke(ctx, ElevatorService_ClearFault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) SetSmokeDetector(ctx context.Context, in *SetSmokeDetectorRequest, opts ...grpc.CallOption) (*GroupState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupState)
+	err := c.cc.Invoke(ctx, ElevatorService_SetSmokeDetector_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorServiceClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ElevatorService_ServiceDesc.Streams[0], ElevatorService_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ElevatorService_WatchEventsClient = grpc.ServerStreamingClient[Event]
+
+// ElevatorServiceServer is the server API for ElevatorService service.
+// All implementations must embed UnimplementedElevatorServiceServer
+// for forward compatibility.
+type ElevatorServiceServer interface {
+	// GetState returns the state of every car and of the group.
+	GetState(context.Context, *GetStateRequest) (*GroupState, error)
+	// AddCall registers a call. A car call goes to the given car; a hall call
+	// is assigned to a car by the group, and the state of that car is returned.
+	AddCall(context.Context, *AddCallRequest) (*CarState, error)
+	// RemoveCall cancels a call. A hall call is cancelled on every car.
+	RemoveCall(context.Context, *RemoveCallRequest) (*CarState, error)
+	// ClearCalls cancels every call of a car.
+	ClearCalls(context.Context, *CarRequest) (*CarState, error)
+	// AddPassenger puts a passenger on its origin landing and assigns its hall
+	// call to a car, whose state is returned.
+	AddPassenger(context.Context, *AddPassengerRequest) (*CarState, error)
+	// AddWeight adds load to a car; a negative weight removes load.
+	AddWeight(context.Context, *AddWeightRequest) (*CarState, error)
+	// PressOpenButton opens the doors and holds them open until
+	// ReleaseOpenButton.
+	PressOpenButton(context.Context, *CarRequest) (*CarState, error)
+	ReleaseOpenButton(context.Context, *CarRequest) (*CarState, error)
+	// PressCloseButton closes the doors early. An overloaded car keeps its
+	// doors open and publishes an Error event.
+	PressCloseButton(context.Context, *CarRequest) (*CarState, error)
+	ReleaseCloseButton(context.Context, *CarRequest) (*CarState, error)
+	// SetObstruction reports the door light curtain as blocked or clear.
+	SetObstruction(context.Context, *SetObstructionRequest) (*CarState, error)
+	// SetMode changes the operation mode of a car.
+	SetMode(context.Context, *SetModeRequest) (*CarState, error)
+	// Reset returns a car to its initial floor and state.
+	Reset(context.Context, *CarRequest) (*CarState, error)
+	// ResetEmergency acknowledges a latched emergency stop.
+	ResetEmergency(context.Context, *CarRequest) (*CarState, error)
+	// Rescue moves a car stopped between floors to the nearest landing after
+	// ResetEmergency.
+	Rescue(context.Context, *CarRequest) (*CarState, error)
+	// PressJog moves a car on inspection until ReleaseJog.
+	PressJog(context.Context, *PressJogRequest) (*CarState, error)
+	ReleaseJog(context.Context, *CarRequest) (*CarState, error)
+	// InjectFault injects an equipment failure into a car.
+	InjectFault(context.Context, *InjectFaultRequest) (*CarState, error)
+	// ClearFault repairs a failure injected into a car.
+	ClearFault(context.Context, *ClearFaultRequest) (*CarState, error)
+	// SetSmokeDetector reports a smoke detector on a floor as active or clear.
+	// An active detector recalls every car for fire service.
+	SetSmokeDetector(context.Context, *SetSmokeDetectorRequest) (*GroupState, error)
+	// WatchEvents streams the events of the group as they happen, until the
+	// client cancels the call. The response header is sent once the stream is
+	// subscribed: every event published after it is received is streamed.
+	WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedElevatorServiceServer()
+}
+
+// UnimplementedElevatorServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedElevatorServiceServer struct{}
+
+func (UnimplementedElevatorServiceServer) GetState(context.Context, *GetStateRequest) (*GroupState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
+}
+func (UnimplementedElevatorServiceServer) AddCall(context.Context, *AddCallRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCall not implemented")
+}
+func (UnimplementedElevatorServiceServer) RemoveCall(context.Context, *RemoveCallRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCall not implemented")
+}
+func (UnimplementedElevatorServiceServer) ClearCalls(context.Context, *CarRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearCalls not implemented")
+}
+func (UnimplementedElevatorServiceServer) AddPassenger(context.Context, *AddPassengerRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPassenger not implemented")
+}
+func (UnimplementedElevatorServiceServer) AddWeight(context.Context, *AddWeightRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWeight not implemented")
+}
+func (UnimplementedElevatorServiceServer) PressOpenButton(context.Context, *CarRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PressOpenButton not implemented")
+}
+func (UnimplementedElevatorServiceServer) ReleaseOpenButton(context.Context, *CarRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseOpenButton not implemented")
+}
+func (UnimplementedElevatorServiceServer) PressCloseButton(context.Context, *CarRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PressCloseButton not implemented")
+}
+func (UnimplementedElevatorServiceServer) ReleaseCloseButton(context.Context, *CarRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseCloseButton not implemented")
+}
+func (UnimplementedElevatorServiceServer) SetObstruction(context.Context, *SetObstructionRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetObstruction not implemented")
+}
+func (UnimplementedElevatorServiceServer) SetMode(context.Context, *SetModeRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMode not implemented")
+}
+func (UnimplementedElevatorServiceServer) Reset(context.Context, *CarRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
+}
+func (UnimplementedElevatorServiceServer) ResetEmergency(context.Context, *CarRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetEmergency not implemented")
+}
+func (UnimplementedElevatorServiceServer) Rescue(context.Context, *CarRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rescue not implemented")
+}
+func (UnimplementedElevatorServiceServer) PressJog(context.Context, *PressJogRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PressJog not implemented")
+}
+func (UnimplementedElevatorServiceServer) ReleaseJog(context.Context, *CarRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseJog not implemented")
+}
+func (UnimplementedElevatorServiceServer) InjectFault(context.Context, *InjectFaultRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectFault not implemented")
+}
+func (UnimplementedElevatorServiceServer) ClearFault(context.Context, *ClearFaultRequest) (*CarState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearFault not implemented")
+}
+func (UnimplementedElevatorServiceServer) SetSmokeDetector(context.Context, *SetSmokeDetectorRequest) (*GroupState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSmokeDetector not implemented")
+}
+func (UnimplementedElevatorServiceServer) WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedElevatorServiceServer) mustEmbedUnimplementedElevatorServiceServer() {}
+func (UnimplementedElevatorServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeElevatorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ElevatorServiceServer will
+// result in compilation errors.
+type UnsafeElevatorServiceServer interface {
+	mustEmbedUnimplementedElevatorServiceServer()
+}
+
+func RegisterElevatorServiceServer(s grpc.ServiceRegistrar, srv ElevatorServiceServer) {
+	// If the following call pancis, it indicates UnimplementedElevatorServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ElevatorService_ServiceDesc, srv)
+}
+
+func _ElevatorService_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).GetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_GetState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).GetState(ctx, req.(*GetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_AddCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).AddCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_AddCall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).AddCall(ctx, req.(*AddCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_RemoveCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).RemoveCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_RemoveCall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).RemoveCall(ctx, req.(*RemoveCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_ClearCalls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).ClearCalls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_ClearCalls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).ClearCalls(ctx, req.(*CarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_AddPassenger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPassengerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).AddPassenger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_AddPassenger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).AddPassenger(ctx, req.(*AddPassengerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_AddWeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).AddWeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_AddWeight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).AddWeight(ctx, req.(*AddWeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_PressOpenButton_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).PressOpenButton(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_PressOpenButton_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).PressOpenButton(ctx, req.(*CarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_ReleaseOpenButton_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).ReleaseOpenButton(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_ReleaseOpenButton_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).ReleaseOpenButton(ctx, req.(*CarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_PressCloseButton_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).PressCloseButton(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_PressCloseButton_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).PressCloseButton(ctx, req.(*CarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_ReleaseCloseButton_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).ReleaseCloseButton(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_ReleaseCloseButton_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).ReleaseCloseButton(ctx, req.(*CarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_SetObstruction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetObstructionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).SetObstruction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_SetObstruction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).SetObstruction(ctx, req.(*SetObstructionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_SetMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).SetMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_SetMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).SetMode(ctx, req.(*SetModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_Reset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).Reset(ctx, req.(*CarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_ResetEmergency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).ResetEmergency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_ResetEmergency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).ResetEmergency(ctx, req.(*CarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_Rescue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).Rescue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_Rescue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).Rescue(ctx, req.(*CarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_PressJog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PressJogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).PressJog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_PressJog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).PressJog(ctx, req.(*PressJogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_ReleaseJog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).ReleaseJog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_ReleaseJog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).ReleaseJog(ctx, req.(*CarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_InjectFault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InjectFaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).InjectFault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_InjectFault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).InjectFault(ctx, req.(*InjectFaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_ClearFault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearFaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).ClearFault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_ClearFault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).ClearFault(ctx, req.(*ClearFaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_SetSmokeDetector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSmokeDetectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorServiceServer).SetSmokeDetector(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorService_SetSmokeDetector_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorServiceServer).SetSmokeDetector(ctx, req.(*SetSmokeDetectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorService_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ElevatorServiceServer).WatchEvents(m, &grpc.GenericServerStream[WatchEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ElevatorService_WatchEventsServer = grpc.ServerStreamingServer[Event]
+
+// ElevatorService_ServiceDesc is the grpc.ServiceDesc for ElevatorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ElevatorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "elevator.v1.ElevatorService",
+	HandlerType: (*ElevatorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetState",
+			Handler:    _ElevatorService_GetState_Handler,
+		},
+		{
+			MethodName: "AddCall",
+			Handler:    _ElevatorService_AddCall_Handler,
+		},
+		{
+			MethodName: "RemoveCall",
+			Handler:    _ElevatorService_RemoveCall_Handler,
+		},
+		{
+			MethodName: "ClearCalls",
+			Handler:    _ElevatorService_ClearCalls_Handler,
+		},
+		{
+			MethodName: "AddPassenger",
+			Handler:    _ElevatorService_AddPassenger_Handler,
+		},
+		{
+			MethodName: "AddWeight",
+			Handler:    _ElevatorService_AddWeight_Handler,
+		},
+		{
+			MethodName: "PressOpenButton",
+			Handler:    _ElevatorService_PressOpenButton_Handler,
+		},
+		{
+			MethodName: "ReleaseOpenButton",
+			Handler:    _ElevatorService_ReleaseOpenButton_Handler,
+		},
+		{
+			MethodName: "PressCloseButton",
+			Handler:    _ElevatorService_PressCloseButton_Handler,
+		},
+		{
+			MethodName: "ReleaseCloseButton",
+			Handler:    _ElevatorService_ReleaseCloseButton_Handler,
+		},
+		{
+			MethodName: "SetObstruction",
+			Handler:    _ElevatorService_SetObstruction_Handler,
+		},
+		{
+			MethodName: "SetMode",
+			Handler:    _ElevatorService_SetMode_Handler,
+		},
+		{
+			MethodName: "Reset",
+			Handler:    _ElevatorService_Reset_Handler,
+		},
+		{
+			MethodName: "ResetEmergency",
+			Handler:    _ElevatorService_ResetEmergency_Handler,
+		},
+		{
+			MethodName: "Rescue",
+			Handler:    _ElevatorService_Rescue_Handler,
+		},
+		{
+			MethodName: "PressJog",
+			Handler:    _ElevatorService_PressJog_Handler,
+		},
+		{
+			MethodName: "ReleaseJog",
+			Handler:    _ElevatorService_ReleaseJog_Handler,
+		},
+		{
+			MethodName: "InjectFault",
+			Handler:    _ElevatorService_InjectFault_Handler,
+		},
+		{
+			MethodName: "ClearFault",
+			Handler:    _ElevatorService_ClearFault_Handler,
+		},
+		{
+			MethodName: "SetSmokeDetector",
+			Handler:    _ElevatorService_SetSmokeDetector_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _ElevatorService_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/elevator/v1/elevator.proto",
+}
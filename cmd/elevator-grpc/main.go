@@ -0,0 +1,77 @@
+// Command elevator-grpc runs an elevator group in real time and serves it
+// over gRPC (api/elevator/v1).
+//
+//	elevator-grpc [-addr :9090] [-cars 2] [-min 1] [-max 10]
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"go-elevator-simulator/pkg/elevator"
+	"go-elevator-simulator/pkg/grpcserver"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "listen address")
+	id := flag.String("id", "G", "group ID")
+	cars := flag.Int("cars", 2, "number of cars")
+	minFloor := flag.Int("min", 1, "lowest floor")
+	maxFloor := flag.Int("max", 10, "highest floor")
+	maxWeight := flag.Int("max-weight", 1000, "rated load in kg")
+	travel := flag.Duration("travel", 2*time.Second, "travel time per floor")
+	doorSpeed := flag.Duration("door-speed", time.Second, "time to open or close the doors")
+	doorOpen := flag.Duration("door-open", 3*time.Second, "time the doors stay open")
+	flag.Parse()
+
+	g, err := elevator.NewGroup(elevator.GroupConfig{
+		ID:   *id,
+		Cars: *cars,
+		Car: elevator.Config{
+			MinFloor:     *minFloor,
+			MaxFloor:     *maxFloor,
+			InitialFloor: *minFloor,
+			MaxWeight:    *maxWeight,
+			TravelTime:   *travel,
+			DoorSpeed:    *doorSpeed,
+			DoorOpenTime: *doorOpen,
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := grpc.NewServer()
+	grpcserver.New(g).Register(s)
+	reflection.Register(s)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		if err := g.Run(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("Group stopped", "error", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		s.Stop()
+	}()
+
+	slog.Info("Serving elevator group over gRPC", "addr", lis.Addr().String(), "cars", *cars)
+	if err := s.Serve(lis); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,106 @@
+// Command elevator-scenario runs scenario files headless and reports pass/fail.
+//
+//	elevator-scenario [-v] [-log] scenario.json...
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"go-elevator-simulator/pkg/elevator"
+	"go-elevator-simulator/pkg/scenario"
+)
+
+func main() {
+	verbose := flag.Bool("v", false, "print the event trace of every scenario")
+	engineLog := flag.Bool("log", false, "print the engine log")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] scenario.json|dir...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if *engineLog {
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+	slog.SetDefault(logger)
+
+	paths, err := expand(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	failed := 0
+	for _, path := range paths {
+		if !run(path, logger, *verbose) {
+			failed++
+		}
+	}
+	fmt.Printf("\n%d passed, %d failed\n", len(paths)-failed, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// expand replaces directories with the JSON files they contain.
+func expand(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(arg, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+func run(path string, logger *slog.Logger, verbose bool) bool {
+	s, err := scenario.Load(path)
+	if err != nil {
+		fmt.Printf("ERROR %s: %v\n", path, err)
+		return false
+	}
+	res, err := scenario.Run(context.Background(), s, elevator.WithLogger(logger))
+	if err != nil {
+		fmt.Printf("ERROR %s: %v\n", path, err)
+		return false
+	}
+
+	status := "PASS"
+	if !res.Passed() {
+		status = "FAIL"
+	}
+	fmt.Printf("%s  %s (%s, %d events)\n", status, s.Name, path, len(res.Events))
+	for _, f := range res.Failures {
+		fmt.Printf("      %s\n", f)
+	}
+	if verbose {
+		for _, rec := range res.Events {
+			if rec.Type == elevator.EventPosition {
+				continue
+			}
+			fmt.Printf("      %s\n", rec)
+		}
+	}
+	return res.Passed()
+}
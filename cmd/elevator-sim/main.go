@@ -0,0 +1,110 @@
+// Command elevator-sim runs a building traffic simulation headless, faster
+// than real time, and writes the results as JSON or CSV.
+//
+//	elevator-sim [-seed n] [-duration d] [-o path] [-format json|csv] config.json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-elevator-simulator/pkg/batch"
+	"go-elevator-simulator/pkg/scenario"
+)
+
+func main() {
+	seed := flag.Int64("seed", 0, "random seed of the traffic (overrides the config)")
+	duration := flag.Duration("duration", 0, "simulated time to run (overrides the config)")
+	out := flag.String("o", "", "output path: a JSON file, or a directory for CSV (default: JSON on stdout)")
+	format := flag.String("format", "", "output format: json or csv (default: csv if -o has no extension, else json)")
+	engineLog := flag.Bool("log", false, "print the engine log")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] config.json\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if *engineLog {
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+	slog.SetDefault(logger)
+
+	cfg, err := batch.Load(flag.Arg(0))
+	if err != nil {
+		fail(err)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "seed":
+			cfg.Seed = *seed
+		case "duration":
+			cfg.Duration = scenario.Duration(*duration)
+		}
+	})
+	if err := cfg.Validate(); err != nil {
+		fail(err)
+	}
+	if *format == "" {
+		*format = "json"
+		if *out != "" && filepath.Ext(*out) == "" {
+			*format = "csv"
+		}
+	}
+
+	began := time.Now()
+	res, err := batch.Run(context.Background(), cfg)
+	if err != nil {
+		fail(err)
+	}
+	s := res.Summary
+	fmt.Fprintf(os.Stderr, "%s: %v simulated in %v, %d passengers, %d delivered, avg wait %.1fs, %.2f kWh\n",
+		flag.Arg(0), time.Duration(cfg.Duration), time.Since(began).Round(time.Millisecond),
+		s.Generated, s.Delivered, s.AvgWait, s.Energy.Total)
+
+	switch strings.ToLower(*format) {
+	case "json":
+		err = writeJSON(res, *out)
+	case "csv":
+		if *out == "" {
+			fail(fmt.Errorf("-o is required for CSV output"))
+		}
+		err = res.WriteCSV(*out)
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+func writeJSON(res *batch.Result, path string) error {
+	if path == "" {
+		return res.WriteJSON(os.Stdout)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := res.WriteJSON(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
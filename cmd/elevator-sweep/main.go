@@ -0,0 +1,111 @@
+// Command elevator-sweep runs a parameter sweep of batch simulations on all
+// CPU cores and writes a comparison report as Markdown and CSV.
+//
+//	elevator-sweep [-seeds n] [-workers n] [-o dir] [-json] sweep.json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"go-elevator-simulator/pkg/sweep"
+)
+
+func main() {
+	seeds := flag.Int("seeds", 0, "seeds per cell (overrides the config)")
+	workers := flag.Int("workers", 0, "simulations run at once (default: the config, else the number of CPUs)")
+	out := flag.String("o", "", "output directory for report.md, cells.csv and runs.csv (default: Markdown on stdout)")
+	asJSON := flag.Bool("json", false, "print the report as JSON instead of Markdown")
+	engineLog := flag.Bool("log", false, "print the engine log")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] sweep.json\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if *engineLog {
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+	slog.SetDefault(logger)
+
+	cfg, err := sweep.Load(flag.Arg(0))
+	if err != nil {
+		fail(err)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "seeds":
+			cfg.Seeds = *seeds
+		case "workers":
+			cfg.Workers = *workers
+		}
+	})
+	if err := cfg.Validate(); err != nil {
+		fail(err)
+	}
+	n := cfg.Workers
+	if n == 0 {
+		n = runtime.NumCPU()
+	}
+	runs := cfg.Seeds
+	if runs == 0 {
+		runs = sweep.DefaultSeeds
+	}
+	runs *= len(cfg.Cells())
+	fmt.Fprintf(os.Stderr, "%s: %d runs on %d workers\n", flag.Arg(0), runs, n)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	began := time.Now()
+	report, err := sweep.Run(ctx, cfg)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Fprintf(os.Stderr, "%s: done in %v\n", flag.Arg(0), time.Since(began).Round(time.Millisecond))
+
+	switch {
+	case *out != "":
+		err = writeDir(report, *out)
+	case *asJSON:
+		err = report.WriteJSON(os.Stdout)
+	default:
+		err = report.WriteMarkdown(os.Stdout)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+// writeDir writes the CSV files and the Markdown report to dir.
+func writeDir(report *sweep.Report, dir string) error {
+	if err := report.WriteCSV(dir); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(dir, sweep.ReportFile))
+	if err != nil {
+		return err
+	}
+	if err := report.WriteMarkdown(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+// apiClient stands in for the WebSocket client sending an API command. It
+// has no send queue: the HTTP response carries the reply.
+var apiClient = &client{role: RoleController}
+
+// newAPI returns the handler of the versioned HTTP API. Simulations are the
+// rooms of hub, so a simulation created over HTTP can be watched by joining
+// its room over WebSocket, and the other way round. See api/openapi.yaml.
+func newAPI(hub *Hub) http.Handler {
+	a := &api{hub: hub}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/simulations", a.list)
+	mux.HandleFunc("POST /api/v1/simulations", a.create)
+	mux.HandleFunc("GET /api/v1/simulations/{name}", a.get)
+	mux.HandleFunc("DELETE /api/v1/simulations/{name}", a.delete)
+	mux.HandleFunc("POST /api/v1/simulations/{name}/calls", a.addCall)
+	mux.HandleFunc("PUT /api/v1/simulations/{name}/cars/{car}/mode", a.setMode)
+	mux.HandleFunc("PUT /api/v1/simulations/{name}/cars/{car}/weight", a.setWeight)
+	mux.HandleFunc("POST /api/v1/simulations/{name}/cars/{car}/buttons", a.pressButton)
+	return mux
+}
+
+type api struct {
+	hub *Hub
+}
+
+func (a *api) list(w http.ResponseWriter, r *http.Request) {
+	infos := []SimulationInfo{}
+	for _, s := range a.hub.Rooms() {
+		infos = append(infos, s.apiInfo())
+	}
+	writeAPI(w, http.StatusOK, infos)
+}
+
+func (a *api) create(w http.ResponseWriter, r *http.Request) {
+	var req CreateSimulationRequest
+	if !decodeAPI(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeAPIError(w, &protocolError{code: "BadMessage", msg: "simulation name is required"})
+		return
+	}
+	s, err := a.hub.Create(req.Name, req.Config)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	w.Header().Set("Location", "/api/v1/simulations/"+req.Name)
+	writeAPI(w, http.StatusCreated, s.apiState())
+}
+
+func (a *api) get(w http.ResponseWriter, r *http.Request) {
+	if s := a.room(w, r); s != nil {
+		writeAPI(w, http.StatusOK, s.apiState())
+	}
+}
+
+func (a *api) delete(w http.ResponseWriter, r *http.Request) {
+	if !a.hub.Delete(r.PathValue("name")) {
+		writeAPIError(w, notFound(r.PathValue("name")))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *api) addCall(w http.ResponseWriter, r *http.Request) {
+	var req CallRequest
+	if !decodeAPI(w, r, &req) {
+		return
+	}
+	a.command(w, r, ClientMessage{Action: "addCall", Floor: req.Floor, CallType: req.CallType, Car: req.Car})
+}
+
+func (a *api) setMode(w http.ResponseWriter, r *http.Request) {
+	var req ModeRequest
+	if !decodeAPI(w, r, &req) {
+		return
+	}
+	mode, err := elevator.ParseMode(req.Mode)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	a.carCommand(w, r, ClientMessage{Action: "setMode", Mode: int(mode)})
+}
+
+func (a *api) setWeight(w http.ResponseWriter, r *http.Request) {
+	var req WeightRequest
+	if !decodeAPI(w, r, &req) {
+		return
+	}
+	a.carCommand(w, r, ClientMessage{Action: "setWeight", Weight: req.Weight})
+}
+
+// buttonActions maps a button and press or release to a WebSocket action.
+var buttonActions = map[[2]string]string{
+	{"open", "press"}:    "pressOpen",
+	{"open", "release"}:  "releaseOpen",
+	{"close", "press"}:   "pressClose",
+	{"close", "release"}: "releaseClose",
+}
+
+func (a *api) pressButton(w http.ResponseWriter, r *http.Request) {
+	var req ButtonRequest
+	if !decodeAPI(w, r, &req) {
+		return
+	}
+	if req.Action == "" {
+		req.Action = "press"
+	}
+	action, ok := buttonActions[[2]string{req.Button, req.Action}]
+	if !ok {
+		writeAPIError(w, &protocolError{code: "BadMessage", msg: fmt.Sprintf("unknown button %q or action %q", req.Button, req.Action)})
+		return
+	}
+	a.carCommand(w, r, ClientMessage{Action: action})
+}
+
+// carCommand sends msg to the car named by the path of r.
+func (a *api) carCommand(w http.ResponseWriter, r *http.Request, msg ClientMessage) {
+	car, err := strconv.Atoi(r.PathValue("car"))
+	if err != nil {
+		writeAPIError(w, &protocolError{code: "BadMessage", msg: fmt.Sprintf("invalid car %q", r.PathValue("car"))})
+		return
+	}
+	msg.Car = car
+	a.command(w, r, msg)
+}
+
+// command performs msg on the simulation named by the path of r and answers
+// with the resulting state.
+func (a *api) command(w http.ResponseWriter, r *http.Request, msg ClientMessage) {
+	s := a.room(w, r)
+	if s == nil {
+		return
+	}
+	if err := s.command(msg); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPI(w, http.StatusOK, s.apiState())
+}
+
+// room returns the simulation named by the path of r, or answers 404.
+func (a *api) room(w http.ResponseWriter, r *http.Request) *ElevatorSession {
+	name := r.PathValue("name")
+	s, ok := a.hub.Room(name)
+	if !ok {
+		writeAPIError(w, notFound(name))
+		return nil
+	}
+	return s
+}
+
+func notFound(name string) error {
+	return &protocolError{code: "NotFound", msg: fmt.Sprintf("simulation %q not found", name)}
+}
+
+// decodeAPI reads the JSON body of r into v, or answers 400.
+func decodeAPI(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeAPIError(w, &protocolError{code: "BadMessage", msg: err.Error()})
+		return false
+	}
+	return true
+}
+
+func writeAPI(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("Failed to write API response", "error", err)
+	}
+}
+
+// writeAPIError answers with the ErrorReply of err and a status for its code.
+func writeAPIError(w http.ResponseWriter, err error) {
+	reply := errorReply(err)
+	status := http.StatusBadRequest
+	switch code := reply.Code; {
+	case code == "Forbidden":
+		status = http.StatusForbidden
+	case code == "NotFound":
+		status = http.StatusNotFound
+	case code == "Exists", code == "NoSession":
+		status = http.StatusConflict
+	case errors.Is(err, elevator.ErrModeForbids), errors.Is(err, elevator.ErrState),
+		errors.Is(err, elevator.ErrFault), errors.Is(err, elevator.ErrOverload),
+		errors.Is(err, elevator.ErrUnavailable):
+		status = http.StatusConflict
+	}
+	writeAPI(w, status, reply)
+}
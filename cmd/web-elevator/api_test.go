@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPI(t *testing.T) {
+	hub := NewHub("")
+	t.Cleanup(func() { hub.Delete("lobby") })
+	handler := newAPI(hub)
+
+	const create = `{"name": "lobby", "config": {"id": "T", "minFloor": 1, "maxFloor": 10, "initialFloor": 1, "travelTime": 1, "doorSpeed": 1, "doorOpenTime": 3, "cars": 2}}`
+	const sim = "/api/v1/simulations/lobby"
+
+	// The steps run in order against the same hub.
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantCode   string // error code of a failed request
+	}{
+		{"create", "POST", "/api/v1/simulations", create, http.StatusCreated, ""},
+		{"duplicate create", "POST", "/api/v1/simulations", create, http.StatusConflict, "Exists"},
+		{"create without name", "POST", "/api/v1/simulations", `{"config": {}}`, http.StatusBadRequest, "BadMessage"},
+		{"unknown field", "POST", sim + "/calls", `{"floor": 5, "speed": 1}`, http.StatusBadRequest, "BadMessage"},
+		{"get", "GET", sim, "", http.StatusOK, ""},
+		{"get unknown", "GET", "/api/v1/simulations/attic", "", http.StatusNotFound, "NotFound"},
+		{"delete unknown", "DELETE", "/api/v1/simulations/attic", "", http.StatusNotFound, "NotFound"},
+		{"call unknown simulation", "POST", "/api/v1/simulations/attic/calls", `{"floor": 5}`, http.StatusNotFound, "NotFound"},
+		{"hall call", "POST", sim + "/calls", `{"floor": 5, "callType": "HallUp"}`, http.StatusOK, ""},
+		{"floor out of range", "POST", sim + "/calls", `{"floor": 11, "car": 1}`, http.StatusBadRequest, "OutOfRange"},
+		{"bad car", "PUT", sim + "/cars/first/weight", `{"weight": 80}`, http.StatusBadRequest, "BadMessage"},
+		{"unknown car", "PUT", sim + "/cars/7/weight", `{"weight": 80}`, http.StatusBadRequest, "OutOfRange"},
+		{"weight", "PUT", sim + "/cars/1/weight", `{"weight": 80}`, http.StatusOK, ""},
+		{"bad button", "POST", sim + "/cars/1/buttons", `{"button": "alarm"}`, http.StatusBadRequest, "BadMessage"},
+		{"bad button action", "POST", sim + "/cars/1/buttons", `{"button": "open", "action": "hold"}`, http.StatusBadRequest, "BadMessage"},
+		{"button", "POST", sim + "/cars/1/buttons", `{"button": "open", "action": "release"}`, http.StatusOK, ""},
+		{"bad mode", "PUT", sim + "/cars/0/mode", `{"mode": "Turbo"}`, http.StatusBadRequest, "Invalid"},
+		{"inspection", "PUT", sim + "/cars/0/mode", `{"mode": "Manual"}`, http.StatusOK, ""},
+		{"call on inspection", "POST", sim + "/calls", `{"floor": 5, "car": 0}`, http.StatusConflict, "ModeForbids"},
+		{"emergency stop", "PUT", sim + "/cars/1/mode", `{"mode": "Emergency"}`, http.StatusOK, ""},
+		{"leave latched emergency", "PUT", sim + "/cars/1/mode", `{"mode": "Auto"}`, http.StatusConflict, "State"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(handler, tt.method, tt.path, tt.body)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantCode != "" {
+				var reply ErrorReply
+				if err := json.Unmarshal(rec.Body.Bytes(), &reply); err != nil || reply.Code != tt.wantCode {
+					t.Errorf("error %s, want code %s", rec.Body, tt.wantCode)
+				}
+			}
+			if tt.name == "create" {
+				if got := rec.Header().Get("Location"); got != sim {
+					t.Errorf("Location %q, want %q", got, sim)
+				}
+			}
+		})
+	}
+}
+
+func TestAPI_DeleteKeepsAttachedRoom(t *testing.T) {
+	hub := newTestRoom(t, "lobby")
+	handler := newAPI(hub)
+	rec := &recorder{}
+	s, c := hub.Join("lobby", rec, RoleObserver)
+
+	if got := serve(handler, "DELETE", "/api/v1/simulations/lobby", ""); got.Code != http.StatusNoContent {
+		t.Fatalf("DELETE status %d, want %d", got.Code, http.StatusNoContent)
+	}
+	if _, ok := rec.wait("stopped", nil); !ok {
+		t.Error("attached client not told the simulation stopped")
+	}
+
+	// The room stays with its client, without a simulation.
+	got := serve(handler, "GET", "/api/v1/simulations/lobby", "")
+	var st SimulationState
+	if err := json.Unmarshal(got.Body.Bytes(), &st); err != nil || got.Code != http.StatusOK {
+		t.Fatalf("GET after DELETE: %d %s", got.Code, got.Body)
+	}
+	if st.Running || st.Clients != 1 {
+		t.Errorf("state after DELETE %+v, want stopped with 1 client", st.SimulationInfo)
+	}
+	if got := serve(handler, "POST", "/api/v1/simulations/lobby/calls", `{"floor": 5}`); got.Code != http.StatusConflict {
+		t.Errorf("call on stopped simulation: status %d, want %d", got.Code, http.StatusConflict)
+	}
+
+	// Once the client leaves, the room is gone.
+	hub.Leave(s, c)
+	if got := serve(handler, "GET", "/api/v1/simulations/lobby", ""); got.Code != http.StatusNotFound {
+		t.Errorf("GET after the client left: status %d, want %d", got.Code, http.StatusNotFound)
+	}
+}
+
+func serve(h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
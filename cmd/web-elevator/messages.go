@@ -0,0 +1,227 @@
+package main
+
+import "go-elevator-simulator/pkg/elevator"
+
+// Message types
+// 메시지 타입 정의
+//
+// Every ClientMessage is answered by an "ack" or "error" ServerMessage that
+// carries its ID and Action, after any messages the action itself sends.
+type ClientMessage struct {
+	ID        string           `json:"id,omitempty"` // 상관 ID: 응답(ack/error)에 그대로 돌려줌
+	Action    string           `json:"action"`
+	Config    *ElevatorConfig  `json:"config,omitempty"`
+	Car       int              `json:"car,omitempty"` // 대상 카 번호 (0부터)
+	Floor     int              `json:"floor,omitempty"`
+	CallType  string           `json:"callType,omitempty"` // "Car"(기본), "HallUp", "HallDown"
+	Mode      int              `json:"mode,omitempty"`
+	Weight    int              `json:"weight,omitempty"`
+	Traffic   *TrafficConfig   `json:"traffic,omitempty"`
+	Name      string           `json:"name,omitempty"`      // openJournal: 저널 파일 이름
+	Offset    float64          `json:"offset,omitempty"`    // scrub: 재생 위치 (초)
+	Snapshot  *SessionSnapshot `json:"snapshot,omitempty"`  // restoreSnapshot
+	Active    bool             `json:"active,omitempty"`    // smokeDetector, obstruction: 감지기 작동(true) / 해제(false)
+	Direction string           `json:"direction,omitempty"` // pressJog, injectFault(StuckHallButton): "Up", "Down"
+	Fault     string           `json:"fault,omitempty"`     // injectFault, clearFault: 고장 종류
+}
+
+// callType maps the wire value to a domain call type, defaulting to a car call.
+func (m ClientMessage) callType() elevator.CallType {
+	if m.CallType == "" {
+		return elevator.CallCar
+	}
+	return elevator.CallType(m.CallType)
+}
+
+type ElevatorConfig struct {
+	ID              string  `json:"id"`
+	MinFloor        int     `json:"minFloor"`
+	MaxFloor        int     `json:"maxFloor"`
+	InitialFloor    int     `json:"initialFloor"`
+	TravelTime      float64 `json:"travelTime"`      // seconds
+	DoorSpeed       float64 `json:"doorSpeed"`       // seconds
+	DoorOpenTime    float64 `json:"doorOpenTime"`    // seconds
+	DoorReopenTime  float64 `json:"doorReopenTime"`  // seconds (Time to keep door open after button press / 버튼 조작 후 문 열림 시간)
+	Strategy        string  `json:"strategy"`        // dispatch strategy name (collective, scan, look, fcfs, sstf)
+	Cars            int     `json:"cars"`            // number of cars in the bank (default 1)
+	Policy          string  `json:"policy"`          // hall call assignment policy (eta, nearest)
+	RatedSpeed      float64 `json:"ratedSpeed"`      // m/s; 0 keeps the per-floor travel times
+	Acceleration    float64 `json:"acceleration"`    // m/s²
+	Jerk            float64 `json:"jerk"`            // m/s³
+	FloorHeight     float64 `json:"floorHeight"`     // m
+	RecallFloor     int     `json:"recallFloor"`     // fire service recall floor; 0 uses the initial floor
+	AltRecallFloor  int     `json:"altRecallFloor"`  // alternate recall floor; 0 uses the recall floor
+	InspectionSpeed float64 `json:"inspectionSpeed"` // m/s; 0 uses the default jog speed
+	NudgingTime     float64 `json:"nudgingTime"`     // seconds a blocked door sensor holds the doors; 0 uses the default
+}
+
+// TrafficConfig selects a generated traffic pattern for "startTraffic".
+// TrafficConfig는 "startTraffic"에서 사용할 교통 패턴 설정입니다.
+type TrafficConfig struct {
+	Pattern string  `json:"pattern"`        // up-peak, down-peak, lunch, interfloor, office
+	Rate    float64 `json:"rate"`           // passengers per minute (office: up-peak rate)
+	Seed    int64   `json:"seed,omitempty"` // random seed; chosen by the server when 0 so journals replay exactly
+}
+
+type ServerMessage struct {
+	Type          string           `json:"type"`
+	ID            string           `json:"id,omitempty"`     // ack, error: 응답하는 ClientMessage의 ID
+	Action        string           `json:"action,omitempty"` // ack, error: 응답하는 액션
+	Error         *ErrorReply      `json:"error,omitempty"`
+	Room          string           `json:"room,omitempty"`    // room: 방 이름 (비공개 세션이면 빈 값)
+	Role          string           `json:"role,omitempty"`    // room: 받는 클라이언트의 역할
+	Clients       int              `json:"clients,omitempty"` // room: 연결된 클라이언트 수
+	Config        *ElevatorConfig  `json:"config,omitempty"`  // session: 새로 시작된 시뮬레이션의 설정
+	EventType     string           `json:"eventType,omitempty"`
+	Car           int              `json:"car,omitempty"`
+	Payload       interface{}      `json:"payload,omitempty"`
+	Timestamp     string           `json:"timestamp,omitempty"`
+	Cars          []CarState       `json:"cars,omitempty"`
+	HallUpCalls   []int            `json:"hallUpCalls,omitempty"`
+	HallDownCalls []int            `json:"hallDownCalls,omitempty"`
+	MaxWeight     int              `json:"maxWeight,omitempty"`
+	Smoke         []int            `json:"smoke,omitempty"` // 작동 중인 연기 감지기 층
+	Stats         *StatsState      `json:"stats,omitempty"`
+	Journals      []string         `json:"journals,omitempty"`
+	Journal       *JournalInfo     `json:"journal,omitempty"`
+	Offset        float64          `json:"offset,omitempty"`
+	Snapshot      *SessionSnapshot `json:"snapshot,omitempty"`
+}
+
+// ErrorReply is the reason an action failed in an "error" message. Code is an
+// elevator.ErrorCode for commands the engine rejected, or one of BadMessage,
+// UnknownAction, NoSession, Forbidden, NotFound, Exists and Failed.
+// ErrorReply는 "error" 메시지에 담기는 액션 실패 사유입니다.
+type ErrorReply struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// protocolError is an action failure outside the engine.
+type protocolError struct {
+	code string
+	msg  string
+}
+
+func (e *protocolError) Error() string { return e.msg }
+
+// errorReply classifies err for an "error" message.
+func errorReply(err error) *ErrorReply {
+	code := "Failed"
+	if pe, ok := err.(*protocolError); ok {
+		code = pe.code
+	} else if c := elevator.ErrorCodeOf(err); c != "" {
+		code = string(c)
+	}
+	return &ErrorReply{Code: code, Message: err.Error()}
+}
+
+// CreateSimulationRequest is the body of POST /api/v1/simulations.
+// CreateSimulationRequest는 HTTP API의 시뮬레이션 생성 요청입니다.
+type CreateSimulationRequest struct {
+	Name   string          `json:"name"`
+	Config *ElevatorConfig `json:"config"`
+}
+
+// CallRequest is the body of POST /api/v1/simulations/{name}/calls.
+type CallRequest struct {
+	Floor    int    `json:"floor"`
+	CallType string `json:"callType,omitempty"` // "Car"(기본), "HallUp", "HallDown"
+	Car      int    `json:"car,omitempty"`      // 카 호출의 대상 카 번호 (0부터)
+}
+
+// ModeRequest is the body of PUT .../cars/{car}/mode.
+type ModeRequest struct {
+	Mode string `json:"mode"` // 운행 모드 이름 (Auto, Manual, Moving, Emergency, FireRecall, FireService)
+}
+
+// WeightRequest is the body of PUT .../cars/{car}/weight.
+type WeightRequest struct {
+	Weight int `json:"weight"` // kg
+}
+
+// ButtonRequest is the body of POST .../cars/{car}/buttons.
+type ButtonRequest struct {
+	Button string `json:"button"`           // "open", "close"
+	Action string `json:"action,omitempty"` // "press"(기본), "release"
+}
+
+// SimulationInfo describes a simulation in the HTTP API.
+// SimulationInfo는 HTTP API에서 시뮬레이션(공유 방)을 나타냅니다.
+type SimulationInfo struct {
+	Name    string          `json:"name"`
+	Running bool            `json:"running"` // 실시간 시뮬레이션 실행 중
+	Clients int             `json:"clients"` // 연결된 WebSocket 클라이언트 수
+	Config  *ElevatorConfig `json:"config,omitempty"`
+}
+
+// SimulationState is a simulation and the state of its cars in the HTTP API.
+type SimulationState struct {
+	SimulationInfo
+	Cars          []CarState `json:"cars,omitempty"`
+	HallUpCalls   []int      `json:"hallUpCalls,omitempty"`
+	HallDownCalls []int      `json:"hallDownCalls,omitempty"`
+	MaxWeight     int        `json:"maxWeight,omitempty"`
+	Smoke         []int      `json:"smoke,omitempty"`
+}
+
+// SessionSnapshot is a saved session: the building configuration and the
+// runtime state of every car. Traffic generation is not included.
+// SessionSnapshot은 저장된 세션(건물 설정과 모든 카의 런타임 상태)입니다.
+type SessionSnapshot struct {
+	Config *ElevatorConfig        `json:"config"`
+	Group  elevator.GroupSnapshot `json:"group"`
+}
+
+// JournalInfo describes a recorded session opened for replay.
+// JournalInfo는 재생을 위해 연 녹화 세션 정보입니다.
+type JournalInfo struct {
+	Name     string          `json:"name"`
+	Duration float64         `json:"duration"` // seconds
+	Config   *ElevatorConfig `json:"config"`
+}
+
+// StatsState is the payload of a "stats" message. Times are in seconds.
+// StatsState는 "stats" 메시지의 내용입니다. 시간 단위는 초입니다.
+type StatsState struct {
+	Waiting          int     `json:"waiting"`
+	Riding           int     `json:"riding"`
+	Delivered        int     `json:"delivered"`
+	AvgWait          float64 `json:"avgWait"`
+	MaxWait          float64 `json:"maxWait"`
+	AvgRide          float64 `json:"avgRide"`
+	AvgJourney       float64 `json:"avgJourney"`
+	JourneyP50       float64 `json:"journeyP50"`
+	JourneyP90       float64 `json:"journeyP90"`
+	JourneyP95       float64 `json:"journeyP95"`
+	LongWaits        int     `json:"longWaits"`
+	StopsPerTrip     float64 `json:"stopsPerTrip"`
+	HandlingCapacity int     `json:"handlingCapacity"` // 5분 최대 수송 인원
+	RecentHandling   int     `json:"recentHandling"`   // 최근 5분 수송 인원
+}
+
+// CarState is the state of a single car in a "state" message.
+// CarState는 "state" 메시지에 담기는 개별 카의 상태입니다.
+type CarState struct {
+	ID            string     `json:"id"`
+	Floor         int        `json:"floor"`
+	Direction     string     `json:"direction"`
+	Doors         DoorStates `json:"doors"`
+	Mode          int        `json:"mode"`
+	CarCalls      []int      `json:"carCalls"`
+	HallUpCalls   []int      `json:"hallUpCalls"`
+	HallDownCalls []int      `json:"hallDownCalls"`
+	Weight        int        `json:"weight"`
+	Position      float64    `json:"position"`            // m
+	Speed         float64    `json:"speed"`               // m/s
+	Level         float64    `json:"level"`               // 층 단위 위치
+	Emergency     string     `json:"emergency,omitempty"` // 비상 정지 복구 단계 (Latched, Reset, Rescue, Rescued)
+	Obstructed    bool       `json:"obstructed"`          // 문 감지기 차단 중
+	Nudging       bool       `json:"nudging"`             // 넛징 중 (저속 닫힘, 부저)
+	Faults        []string   `json:"faults"`              // 주입된 고장 종류
+}
+
+type DoorStates struct {
+	Front string `json:"front"`
+	Rear  string `json:"rear"`
+}
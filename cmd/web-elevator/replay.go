@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+	"go-elevator-simulator/pkg/journal"
+)
+
+// replayLogSize is how many recent events a scrub sends to the client log.
+const replayLogSize = 50
+
+// replayer feeds a recorded journal back through a fresh engine.
+// replayer는 기록된 저널의 명령을 새 엔진에 다시 입력하여 세션을 재현합니다.
+//
+// Replays are deterministic: every command is applied at its recorded virtual
+// offset, so the engine publishes exactly the recorded events. Seeking
+// backwards rebuilds the engine from the start.
+type replayer struct {
+	name     string
+	journal  *journal.Journal
+	config   *ElevatorConfig
+	commands []recordedCommand // commands[0] builds the session
+
+	session *ElevatorSession // headless session driving the engine
+	next    int              // index of the next command to apply
+	events  []elevator.GroupEvent
+}
+
+type recordedCommand struct {
+	at  time.Duration
+	msg ClientMessage
+}
+
+// newReplayer prepares j for replay. The first command must be the "init" or
+// "restoreSnapshot" that started the session.
+func newReplayer(name string, j *journal.Journal) (*replayer, error) {
+	r := &replayer{name: name, journal: j}
+	for _, e := range j.Filter(journal.KindCommand) {
+		var msg ClientMessage
+		if err := json.Unmarshal(e.Command, &msg); err != nil {
+			return nil, fmt.Errorf("invalid command at %v: %w", e.At, err)
+		}
+		r.commands = append(r.commands, recordedCommand{at: e.At, msg: msg})
+	}
+	if len(r.commands) == 0 {
+		return nil, fmt.Errorf("journal has no commands")
+	}
+	switch first := r.commands[0].msg; {
+	case first.Action == "init" && first.Config != nil:
+		r.config = first.Config
+	case first.Action == "restoreSnapshot" && first.Snapshot != nil && first.Snapshot.Config != nil:
+		r.config = first.Snapshot.Config
+	default:
+		return nil, fmt.Errorf("journal does not start with an init or restoreSnapshot command")
+	}
+	return r, r.reset()
+}
+
+// info describes the journal being replayed.
+func (r *replayer) info() *JournalInfo {
+	return &JournalInfo{
+		Name:     r.name,
+		Duration: r.journal.Duration().Seconds(),
+		Config:   r.config,
+	}
+}
+
+// reset rebuilds the engine at the start of the session.
+func (r *replayer) reset() error {
+	if r.session != nil {
+		r.session.stopLive()
+	}
+	s := NewElevatorSession("", "")
+	if err := s.build(r.commands[0].msg, r.journal.Start); err != nil {
+		s.stopLive()
+		return err
+	}
+	r.session = s
+	r.next = 1
+	r.events = nil
+	return nil
+}
+
+// seek advances the replay to offset, rebuilding it first if offset is behind.
+func (r *replayer) seek(ctx context.Context, offset time.Duration) error {
+	s := r.session
+	if offset < s.sim.Elapsed() {
+		if err := r.reset(); err != nil {
+			return err
+		}
+		s = r.session
+	}
+
+	start := r.journal.Start
+	for ; r.next < len(r.commands) && r.commands[r.next].at <= offset; r.next++ {
+		cmd := r.commands[r.next]
+		if err := s.sim.RunUntil(ctx, start.Add(cmd.at)); err != nil {
+			return err
+		}
+		if err := s.apply(cmd.msg); err != nil {
+			slog.Debug("Replayed command rejected", "action", cmd.msg.Action, "error", err)
+		}
+	}
+	if err := s.sim.RunUntil(ctx, start.Add(offset)); err != nil {
+		return err
+	}
+	r.events = append(r.events, s.outbox...)
+	s.outbox = nil
+	return nil
+}
+
+// close stops the replay engine.
+func (r *replayer) close() {
+	r.session.stopLive()
+}
+
+// verifyJournal replays the journal at path to its end and checks that the
+// engine publishes the recorded events.
+func verifyJournal(ctx context.Context, path string) error {
+	j, err := journal.Open(path)
+	if err != nil {
+		return err
+	}
+	r, err := newReplayer(filepath.Base(path), j)
+	if err != nil {
+		return err
+	}
+	defer r.close()
+	if err := r.seek(ctx, j.Duration()); err != nil {
+		return err
+	}
+
+	replayed := make([]journal.Entry, 0, len(r.events))
+	for _, ev := range r.events {
+		entry, err := journal.EventEntry(j.Start, ev.Car, ev.Event)
+		if err != nil {
+			return err
+		}
+		replayed = append(replayed, entry)
+	}
+	return journal.Compare(j.Filter(journal.KindEvent), replayed)
+}
+
+// closeReplay stops the open replay, if any.
+func (s *ElevatorSession) closeReplay() {
+	if s.replay != nil {
+		s.replay.close()
+		s.replay = nil
+	}
+}
+
+// journalList returns a "journals" message listing the recorded journals,
+// newest first.
+func (s *ElevatorSession) journalList() ServerMessage {
+	var names []string
+	if s.journalDir != "" {
+		entries, err := os.ReadDir(s.journalDir)
+		if err != nil {
+			slog.Warn("Failed to list journals", "dir", s.journalDir, "error", err)
+		}
+		for _, e := range entries {
+			if !e.IsDir() && strings.HasSuffix(e.Name(), ".jsonl") {
+				names = append(names, e.Name())
+			}
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	return ServerMessage{Type: "journals", Journals: names}
+}
+
+// openJournal stops the live simulation and loads a journal for scrubbing.
+func (s *ElevatorSession) openJournal(name string) error {
+	if s.journalDir == "" || name == "" || name != filepath.Base(name) {
+		return fmt.Errorf("invalid journal name %q", name)
+	}
+	j, err := journal.Open(filepath.Join(s.journalDir, name))
+	if err != nil {
+		return err
+	}
+	r, err := newReplayer(name, j)
+	if err != nil {
+		return err
+	}
+
+	s.stopLive()
+	s.closeReplay()
+	s.replay = r
+	slog.Info("Journal opened", "name", name, "duration", j.Duration())
+
+	s.writeJSON(ServerMessage{Type: "journal", Journal: r.info()})
+	s.writeJSON(stateMessage(r.session.group))
+	return nil
+}
+
+// scrub moves the open replay to offset seconds and sends its state there.
+func (s *ElevatorSession) scrub(offset float64) error {
+	r := s.replay
+	if r == nil {
+		return fmt.Errorf("no journal open")
+	}
+	at := time.Duration(offset * float64(time.Second))
+	at = max(0, min(at, r.journal.Duration()))
+	if err := r.seek(context.Background(), at); err != nil {
+		return err
+	}
+
+	s.writeJSON(ServerMessage{Type: "scrub", Offset: at.Seconds()})
+	var recent []elevator.GroupEvent
+	for i := len(r.events) - 1; i >= 0 && len(recent) < replayLogSize; i-- {
+		if r.events[i].Type != elevator.EventPosition {
+			recent = append(recent, r.events[i])
+		}
+	}
+	for i := len(recent) - 1; i >= 0; i-- {
+		s.sendEvent(recent[i])
+	}
+	s.writeJSON(stateMessage(r.session.group))
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"go-elevator-simulator/pkg/journal"
+)
+
+// recordSession runs a private session recording to dir and returns the path
+// of its journal. The session is advanced by hand instead of by wall time.
+func recordSession(t *testing.T, dir string) string {
+	t.Helper()
+	s := NewElevatorSession("", dir)
+	rec := &recorder{}
+	c := s.attach(rec, RoleController)
+
+	n := 0
+	act := func(msg ClientMessage) {
+		t.Helper()
+		n++
+		msg.ID = fmt.Sprint(n)
+		s.handleAction(c, msg)
+		m, ok := rec.wait("", func(m ServerMessage) bool { return m.ID == msg.ID })
+		if !ok || m.Type != "ack" {
+			t.Fatalf("%s: reply %+v", msg.Action, m)
+		}
+	}
+	advance := func(d time.Duration) {
+		t.Helper()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.sim.RunFor(context.Background(), d); err != nil {
+			t.Fatalf("RunFor: %v", err)
+		}
+		s.flush()
+	}
+
+	act(ClientMessage{Action: "init", Config: testElevatorConfig()})
+	s.mu.Lock()
+	s.cancel() // stop pacing to wall time
+	s.mu.Unlock()
+
+	act(ClientMessage{Action: "startTraffic", Traffic: &TrafficConfig{Pattern: "lunch", Rate: 6, Seed: 7}})
+	advance(20 * time.Second)
+	act(ClientMessage{Action: "addCall", Floor: 7, CallType: "HallDown"})
+	act(ClientMessage{Action: "addCall", Car: 1, Floor: 9})
+	advance(15 * time.Second)
+	act(ClientMessage{Action: "pressOpen", Car: 1})
+	advance(4 * time.Second)
+	act(ClientMessage{Action: "releaseOpen", Car: 1})
+	act(ClientMessage{Action: "pressClose"})
+	advance(time.Second)
+	act(ClientMessage{Action: "releaseClose"})
+	advance(2 * time.Minute)
+	act(ClientMessage{Action: "stopTraffic"})
+	advance(30 * time.Second)
+	s.detach(c) // a private session closes its journal with its client
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil || len(paths) != 1 {
+		t.Fatalf("journals %v (%v), want one", paths, err)
+	}
+	return paths[0]
+}
+
+func TestVerifyJournal(t *testing.T) {
+	path := recordSession(t, t.TempDir())
+	if err := verifyJournal(context.Background(), path); err != nil {
+		t.Fatalf("verifyJournal: %v", err)
+	}
+
+	// A journal missing a recorded command no longer reproduces its events.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := filepath.Join(t.TempDir(), "tampered.jsonl")
+	if err := os.WriteFile(tampered, dropLine(data, `"action":"addCall"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyJournal(context.Background(), tampered); err == nil {
+		t.Error("verifyJournal accepted a journal missing a command")
+	}
+}
+
+func TestReplayer_Seek(t *testing.T) {
+	path := recordSession(t, t.TempDir())
+	r := openReplay(t, path)
+	end := r.journal.Duration()
+	ctx := context.Background()
+
+	seek := func(r *replayer, at time.Duration) ServerMessage {
+		t.Helper()
+		if err := r.seek(ctx, at); err != nil {
+			t.Fatalf("seek(%v): %v", at, err)
+		}
+		if got := r.session.sim.Elapsed(); got != at {
+			t.Fatalf("seek(%v) landed at %v", at, got)
+		}
+		return stateMessage(r.session.group)
+	}
+	atEnd := seek(r, end)
+	events := slices.Clone(r.events)
+
+	// Backwards rebuilds the engine; forwards again ends in the same place.
+	mid := seek(r, end/2)
+	if len(r.events) == 0 || len(r.events) >= len(events) {
+		t.Errorf("%d events at the middle, %d at the end", len(r.events), len(events))
+	}
+	if got := seek(r, end); !reflect.DeepEqual(got, atEnd) || !reflect.DeepEqual(r.events, events) {
+		t.Errorf("scrubbing back and forth changed the replay: state %+v, want %+v", got, atEnd)
+	}
+
+	// A fresh replay stopped in the middle matches the scrubbed one.
+	if got := seek(openReplay(t, path), end/2); !reflect.DeepEqual(got, mid) {
+		t.Errorf("state at the middle %+v, want %+v", got, mid)
+	}
+}
+
+func openReplay(t *testing.T, path string) *replayer {
+	t.Helper()
+	j, err := journal.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	r, err := newReplayer(filepath.Base(path), j)
+	if err != nil {
+		t.Fatalf("newReplayer: %v", err)
+	}
+	t.Cleanup(r.close)
+	return r
+}
+
+// dropLine removes the first line of data containing substr.
+func dropLine(data []byte, substr string) []byte {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	for i, l := range lines {
+		if bytes.Contains(l, []byte(substr)) {
+			return bytes.Join(slices.Delete(lines, i, i+1), nil)
+		}
+	}
+	return data
+}
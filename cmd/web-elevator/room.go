@@ -0,0 +1,305 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Client roles. Observers watch a room; only controllers send commands.
+const (
+	RoleController = "controller"
+	RoleObserver   = "observer"
+)
+
+// observerActions are the actions an observer may send.
+var observerActions = map[string]bool{
+	"getState":     true,
+	"saveSnapshot": true,
+	"listJournals": true,
+}
+
+// Client send queue limits. A client whose queue fills up, or whose
+// connection takes longer than writeWait to accept a message, is disconnected
+// so it cannot hold up the session or the other clients.
+const (
+	sendBuffer = 1024
+	writeWait  = 10 * time.Second
+)
+
+// client is a WebSocket connection attached to a session.
+//
+// Messages are queued under the session lock and written to the connection
+// by a goroutine of the client's own.
+type client struct {
+	conn messageWriter
+	role string
+
+	send chan ServerMessage // nil: messages are discarded
+	done chan struct{}
+	stop sync.Once
+}
+
+// newClient creates a client and starts writing its queue to conn.
+func newClient(conn messageWriter, role string) *client {
+	c := &client{
+		conn: conn,
+		role: role,
+		send: make(chan ServerMessage, sendBuffer),
+		done: make(chan struct{}),
+	}
+	go c.writeLoop()
+	return c
+}
+
+// allowed returns an error if the client's role forbids action.
+func (c *client) allowed(action string) error {
+	if c.role == RoleObserver && !observerActions[action] {
+		return &protocolError{code: "Forbidden", msg: fmt.Sprintf("observers cannot send %q", action)}
+	}
+	return nil
+}
+
+// Hub holds the named rooms of the server.
+// Hub는 서버의 이름 있는 시뮬레이션 방을 관리합니다.
+//
+// A room is an ElevatorSession shared by every connection that joins it. It
+// outlives its connections: the simulation keeps running with nobody
+// attached, and is dropped only once it is both empty and stopped.
+type Hub struct {
+	mu         sync.Mutex
+	rooms      map[string]*ElevatorSession
+	journalDir string
+}
+
+func NewHub(journalDir string) *Hub {
+	return &Hub{rooms: make(map[string]*ElevatorSession), journalDir: journalDir}
+}
+
+// Join attaches a connection to the room name, creating the room if needed.
+func (h *Hub) Join(name string, conn messageWriter, role string) (*ElevatorSession, *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.rooms[name]
+	if !ok {
+		s = NewElevatorSession(name, h.journalDir)
+		h.rooms[name] = s
+		slog.Info("Room created", "room", name)
+	}
+	return s, s.attach(conn, role)
+}
+
+// Leave detaches c from its room and drops the room if it is idle.
+func (h *Hub) Leave(s *ElevatorSession, c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if s.detach(c) && h.rooms[s.room] == s {
+		delete(h.rooms, s.room)
+		slog.Info("Room closed", "room", s.room)
+	}
+}
+
+// Room returns the room called name.
+func (h *Hub) Room(name string) (*ElevatorSession, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.rooms[name]
+	return s, ok
+}
+
+// Rooms returns every room by name.
+func (h *Hub) Rooms() []*ElevatorSession {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]*ElevatorSession, 0, len(h.rooms))
+	for _, s := range h.rooms {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].room < out[j].room })
+	return out
+}
+
+// Create starts a simulation with cfg in the room name. It fails if the room
+// already runs one.
+func (h *Hub) Create(name string, cfg *ElevatorConfig) (*ElevatorSession, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.rooms[name]
+	if !ok {
+		s = NewElevatorSession(name, h.journalDir)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.group != nil || s.replay != nil {
+		return nil, &protocolError{code: "Exists", msg: fmt.Sprintf("simulation %q already exists", name)}
+	}
+	if err := s.initElevator(cfg); err != nil {
+		return nil, err
+	}
+	if !ok {
+		h.rooms[name] = s
+		slog.Info("Room created", "room", name)
+	}
+	return s, nil
+}
+
+// Delete stops the simulation in the room name and reports whether the room
+// existed. Clients attached stay in the room.
+func (h *Hub) Delete(name string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.rooms[name]
+	if !ok {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopLive()
+	s.closeReplay()
+	s.writeJSON(ServerMessage{Type: "stopped"})
+	if len(s.clients) == 0 {
+		delete(h.rooms, name)
+		slog.Info("Room closed", "room", name)
+	}
+	return true
+}
+
+// attach adds a client to the session and brings it up to date.
+func (s *ElevatorSession) attach(conn messageWriter, role string) *client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := newClient(conn, role)
+	s.clients = append(s.clients, c)
+	slog.Info("Client joined", "room", s.room, "role", role, "clients", len(s.clients))
+	s.sendRoom()
+
+	switch {
+	case s.replay != nil:
+		r := s.replay
+		c.write(ServerMessage{Type: "journal", Journal: r.info()})
+		c.write(ServerMessage{Type: "scrub", Offset: r.session.sim.Elapsed().Seconds()})
+		c.write(stateMessage(r.session.group))
+	case s.group != nil:
+		c.write(ServerMessage{Type: "session", Config: s.config})
+		c.write(stateMessage(s.group))
+	}
+	return c
+}
+
+// detach removes a client from the session and reports whether the session
+// is left empty and idle. A private session is stopped with its client.
+func (s *ElevatorSession) detach(c *client) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, other := range s.clients {
+		if other == c {
+			s.clients = append(s.clients[:i], s.clients[i+1:]...)
+			break
+		}
+	}
+	c.close()
+	slog.Info("Client left", "room", s.room, "clients", len(s.clients))
+	if len(s.clients) > 0 {
+		s.sendRoom()
+		return false
+	}
+	if s.room == "" {
+		s.stopLive()
+		s.closeReplay()
+	}
+	return s.group == nil && s.replay == nil
+}
+
+// sendRoom tells every client the room it is in, its role and the number of
+// clients attached.
+func (s *ElevatorSession) sendRoom() {
+	for _, c := range s.clients {
+		c.write(ServerMessage{Type: "room", Room: s.room, Role: c.role, Clients: len(s.clients)})
+	}
+}
+
+// command performs an HTTP API command on the session.
+func (s *ElevatorSession) command(msg ClientMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dispatch(apiClient, msg)
+}
+
+// apiInfo describes the session for the HTTP API.
+func (s *ElevatorSession) apiInfo() SimulationInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.info()
+}
+
+func (s *ElevatorSession) info() SimulationInfo {
+	return SimulationInfo{Name: s.room, Running: s.group != nil, Clients: len(s.clients), Config: s.config}
+}
+
+// apiState returns the session and the state of its cars for the HTTP API.
+func (s *ElevatorSession) apiState() SimulationState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := SimulationState{SimulationInfo: s.info()}
+	if s.group != nil {
+		m := stateMessage(s.group)
+		st.Cars, st.HallUpCalls, st.HallDownCalls = m.Cars, m.HallUpCalls, m.HallDownCalls
+		st.MaxWeight, st.Smoke = m.MaxWeight, m.Smoke
+	}
+	return st
+}
+
+// write queues msg for the client without blocking. A client that cannot
+// keep up is disconnected.
+func (c *client) write(msg ServerMessage) {
+	if c.send == nil {
+		return
+	}
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+	select {
+	case c.send <- msg:
+	default:
+		slog.Warn("Client send queue full, disconnecting", "role", c.role, "queued", len(c.send))
+		c.close()
+	}
+}
+
+// close stops the writer and closes the connection, which ends the read
+// loop of the client; the messages still queued are dropped.
+func (c *client) close() {
+	c.stop.Do(func() { close(c.done) })
+}
+
+// writeLoop writes the queued messages to the connection until the client is
+// closed or a write fails.
+func (c *client) writeLoop() {
+	defer c.conn.Close()
+	for {
+		select {
+		case <-c.done: // checked first: a closed client writes nothing more
+			return
+		default:
+		}
+		select {
+		case <-c.done:
+			return
+		case msg := <-c.send:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				slog.Warn("Failed to set write deadline", "error", err)
+			}
+			if err := c.conn.WriteJSON(msg); err != nil {
+				slog.Warn("Failed to write to client, disconnecting", "role", c.role, "error", err)
+				c.close()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder is a messageWriter keeping every message sent to a client.
+type recorder struct {
+	mu     sync.Mutex
+	msgs   []ServerMessage
+	closed bool
+	stall  chan struct{} // if set, writes wait until it is closed
+}
+
+func (r *recorder) WriteJSON(v interface{}) error {
+	if r.stall != nil {
+		<-r.stall
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.msgs = append(r.msgs, v.(ServerMessage))
+	return nil
+}
+
+func (r *recorder) SetWriteDeadline(time.Time) error { return nil }
+
+func (r *recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	return nil
+}
+
+// wait returns the last message of type typ ("" for any) that satisfies ok,
+// waiting for the client's writer to deliver it.
+func (r *recorder) wait(typ string, ok func(ServerMessage) bool) (ServerMessage, bool) {
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		r.mu.Lock()
+		for i := len(r.msgs) - 1; i >= 0; i-- {
+			if m := r.msgs[i]; (typ == "" || m.Type == typ) && (ok == nil || ok(m)) {
+				r.mu.Unlock()
+				return m, true
+			}
+		}
+		r.mu.Unlock()
+		if time.Now().After(deadline) {
+			return ServerMessage{}, false
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (r *recorder) isClosed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closed
+}
+
+func (r *recorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.msgs = nil
+}
+
+func testElevatorConfig() *ElevatorConfig {
+	return &ElevatorConfig{ID: "T", MinFloor: 1, MaxFloor: 10, InitialFloor: 1, TravelTime: 1, DoorSpeed: 1, DoorOpenTime: 3, Cars: 2}
+}
+
+// newTestRoom creates a hub with a running simulation in the room name.
+func newTestRoom(t *testing.T, name string) *Hub {
+	t.Helper()
+	hub := NewHub("")
+	if _, err := hub.Create(name, testElevatorConfig()); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	t.Cleanup(func() { hub.Delete(name) })
+	return hub
+}
+
+func TestClient_Allowed(t *testing.T) {
+	hub := newTestRoom(t, "lobby")
+	tests := []struct {
+		name     string
+		role     string
+		msg      ClientMessage
+		wantCode string // "" for an ack
+	}{
+		{"observer cannot add call", RoleObserver, ClientMessage{Action: "addCall", Floor: 5}, "Forbidden"},
+		{"observer cannot stop", RoleObserver, ClientMessage{Action: "stop"}, "Forbidden"},
+		{"observer cannot set mode", RoleObserver, ClientMessage{Action: "setMode", Mode: 1}, "Forbidden"},
+		{"observer gets state", RoleObserver, ClientMessage{Action: "getState"}, ""},
+		{"observer saves snapshot", RoleObserver, ClientMessage{Action: "saveSnapshot"}, ""},
+		{"observer lists journals", RoleObserver, ClientMessage{Action: "listJournals"}, ""},
+		{"controller adds call", RoleController, ClientMessage{Action: "addCall", Floor: 5}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			s, c := hub.Join("lobby", rec, tt.role)
+			defer hub.Leave(s, c)
+
+			tt.msg.ID = "1"
+			s.handleAction(c, tt.msg)
+			m, ok := rec.wait("", func(m ServerMessage) bool { return m.ID == "1" })
+			switch {
+			case !ok:
+				t.Errorf("no reply to %q", tt.msg.Action)
+			case tt.wantCode == "":
+				if m.Type != "ack" {
+					t.Errorf("reply to %q %+v, want ack", tt.msg.Action, m.Error)
+				}
+			default:
+				if m.Type != "error" || m.Error.Code != tt.wantCode {
+					t.Errorf("reply %+v, want error %s", m, tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func TestHub_SharedRoom(t *testing.T) {
+	hub := newTestRoom(t, "lobby")
+	ctrl, obs := &recorder{}, &recorder{}
+	s, cc := hub.Join("lobby", ctrl, RoleController)
+	s2, oc := hub.Join("lobby", obs, RoleObserver)
+	if s2 != s {
+		t.Fatal("second client joined a different session")
+	}
+
+	// The first client sees the second join.
+	if m, ok := ctrl.wait("room", func(m ServerMessage) bool { return m.Clients == 2 }); !ok || m.Role != RoleController {
+		t.Errorf("controller room message %+v, want 2 clients", m)
+	}
+	if _, ok := obs.wait("session", nil); !ok {
+		t.Error("observer was not sent the running session")
+	}
+
+	// State broadcasts reach every client.
+	obs.wait("state", nil) // the state sent on joining
+	obs.reset()
+	s.handleAction(cc, ClientMessage{Action: "getState"})
+	if m, ok := obs.wait("state", nil); !ok || len(m.Cars) != 2 {
+		t.Errorf("observer state %+v, want 2 cars", m)
+	}
+
+	// The room and its group outlive the first client.
+	hub.Leave(s, cc)
+	got, ok := hub.Room("lobby")
+	if !ok || got != s {
+		t.Fatal("room dropped when its first client left")
+	}
+	s.mu.Lock()
+	running := s.group != nil
+	s.mu.Unlock()
+	if !running {
+		t.Fatal("simulation stopped when its first client left")
+	}
+	if _, ok := obs.wait("room", func(m ServerMessage) bool { return m.Clients == 1 }); !ok {
+		t.Error("observer not told that one client is left")
+	}
+
+	// A running room is kept with nobody attached, and dropped once deleted.
+	hub.Leave(s, oc)
+	if _, ok := hub.Room("lobby"); !ok {
+		t.Error("running room dropped with nobody attached")
+	}
+	if !hub.Delete("lobby") {
+		t.Fatal("Delete: room not found")
+	}
+	if _, ok := hub.Room("lobby"); ok {
+		t.Error("empty stopped room kept")
+	}
+}
+
+func TestClient_SlowClient(t *testing.T) {
+	hub := newTestRoom(t, "lobby")
+	stalled := &recorder{stall: make(chan struct{})}
+	defer close(stalled.stall)
+	fast := &recorder{}
+	s, sc := hub.Join("lobby", stalled, RoleObserver)
+	_, fc := hub.Join("lobby", fast, RoleController)
+	defer hub.Leave(s, fc)
+
+	// Broadcasts go on while one client stops reading, until its queue
+	// overflows and it is disconnected.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i <= sendBuffer; i++ {
+			s.handleAction(fc, ClientMessage{Action: "getState", ID: fmt.Sprint(i)})
+			if i%100 == 0 { // let the reading client keep up
+				fast.wait("ack", func(m ServerMessage) bool { return m.ID == fmt.Sprint(i) })
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a stalled client blocked the session")
+	}
+	if _, ok := fast.wait("ack", func(m ServerMessage) bool { return m.ID == fmt.Sprint(sendBuffer) }); !ok {
+		t.Error("other client missed the broadcasts")
+	}
+	select {
+	case <-sc.done:
+	default:
+		t.Error("stalled client not disconnected")
+	}
+
+	// The connection is closed once the blocked write returns, which ends
+	// the client's read loop and so its membership.
+	stalled.stall <- struct{}{}
+	deadline := time.Now().Add(2 * time.Second)
+	for !stalled.isClosed() {
+		if time.Now().After(deadline) {
+			t.Fatal("stalled connection not closed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	hub.Leave(s, sc)
+}
@@ -0,0 +1,652 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+	"go-elevator-simulator/pkg/journal"
+	"go-elevator-simulator/pkg/stats"
+	"go-elevator-simulator/pkg/traffic"
+
+	"github.com/gorilla/websocket"
+)
+
+// paceInterval is how often a live session advances its simulation to wall time.
+const paceInterval = 50 * time.Millisecond
+
+// messageWriter sends JSON messages to the client. *websocket.Conn
+// implements it.
+type messageWriter interface {
+	WriteJSON(v interface{}) error
+	SetWriteDeadline(t time.Time) error
+	Close() error
+}
+
+// ElevatorSession manages an elevator group and the WebSocket clients
+// attached to it
+// ElevatorSession은 엘리베이터 그룹과 여기에 연결된 WebSocket 클라이언트들을 관리합니다.
+//
+// The group runs on a discrete-event Simulation that a live session advances
+// in step with the wall clock. Every engine callback and every command runs
+// under mu, so commands land at exact virtual times and a journal of the
+// session replays to identical events.
+//
+// State and events are broadcast to every client; replies go to the client
+// that sent the action. A session without a room name is private to one
+// connection and stops with it; a headless session has no clients at all.
+type ElevatorSession struct {
+	room    string
+	clients []*client
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+
+	sim    *elevator.Simulation
+	config *ElevatorConfig
+	group  *elevator.Group
+	feed   *traffic.Feed
+	stats  *stats.Collector
+	outbox []elevator.GroupEvent // 다음 flush에서 보낼 이벤트
+
+	journalDir string
+	journal    *journal.Writer
+	replay     *replayer
+}
+
+func NewElevatorSession(room, journalDir string) *ElevatorSession {
+	return &ElevatorSession{
+		room:       room,
+		journalDir: journalDir,
+	}
+}
+
+// HandleMessages reads the actions of client c from conn until it closes.
+func (s *ElevatorSession) HandleMessages(conn *websocket.Conn, c *client) {
+	slog.Info("Session started", "remote_addr", conn.RemoteAddr(), "room", s.room, "role", c.role)
+	defer func() {
+		_ = conn.Close()
+		slog.Info("Session ended", "remote_addr", conn.RemoteAddr(), "room", s.room)
+	}()
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				slog.Error("WebSocket read error", "error", err)
+			}
+			return
+		}
+
+		var msg ClientMessage
+		if err := json.Unmarshal(message, &msg); err != nil {
+			slog.Warn("Failed to parse message", "error", err)
+			s.mu.Lock()
+			reply(c, msg, &protocolError{code: "BadMessage", msg: err.Error()})
+			s.mu.Unlock()
+			continue
+		}
+
+		s.handleAction(c, msg)
+	}
+}
+
+// handleAction performs an action of client c and answers it with an "ack"
+// or "error" message carrying the id of the action.
+func (s *ElevatorSession) handleAction(c *client, msg ClientMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	slog.Debug("Action received", "action", msg.Action, "payload", msg)
+	err := c.allowed(msg.Action)
+	if err == nil {
+		err = s.dispatch(c, msg)
+	}
+	if err != nil {
+		slog.Warn("Action failed", "action", msg.Action, "id", msg.ID, "error", err)
+	}
+	reply(c, msg, err)
+}
+
+func (s *ElevatorSession) dispatch(c *client, msg ClientMessage) error {
+	switch msg.Action {
+	case "init":
+		return s.initElevator(msg.Config)
+	case "stop":
+		s.stopLive()
+		s.closeReplay()
+		s.writeJSON(ServerMessage{Type: "stopped"})
+		return nil
+	case "listJournals":
+		c.write(s.journalList())
+		return nil
+	case "openJournal":
+		return s.openJournal(msg.Name)
+	case "scrub":
+		return s.scrub(msg.Offset)
+	case "restoreSnapshot":
+		return s.restoreSnapshot(msg.Snapshot)
+	}
+
+	if s.group == nil {
+		return &protocolError{code: "NoSession", msg: "no simulation running"}
+	}
+	switch msg.Action {
+	case "getState":
+		s.sendState()
+		return nil
+	case "saveSnapshot":
+		c.write(ServerMessage{Type: "snapshot", Snapshot: &SessionSnapshot{
+			Config: s.config,
+			Group:  s.group.Snapshot(),
+		}})
+		return nil
+	}
+
+	if msg.Action == "startTraffic" && msg.Traffic != nil && msg.Traffic.Seed == 0 {
+		msg.Traffic.Seed = time.Now().UnixNano()
+	}
+	s.record(msg)
+	err := s.apply(msg)
+	s.flush()
+	return err
+}
+
+// reply answers the action msg of c with an "ack", or an "error" if err is set.
+func reply(c *client, msg ClientMessage, err error) {
+	if err == nil {
+		c.write(ServerMessage{Type: "ack", ID: msg.ID, Action: msg.Action})
+		return
+	}
+	c.write(ServerMessage{Type: "error", ID: msg.ID, Action: msg.Action, Error: errorReply(err)})
+}
+
+// apply performs an engine command. It is shared by live sessions and replays.
+func (s *ElevatorSession) apply(msg ClientMessage) error {
+	switch msg.Action {
+	case "addCall":
+		return s.addCall(msg)
+	case "removeCall":
+		s.removeCall(msg)
+	case "smokeDetector":
+		return s.group.SetSmokeDetector(msg.Floor, msg.Active)
+	case "reset":
+		s.group.Reset()
+		s.stats.Reset()
+		s.sendStats()
+	case "startTraffic":
+		return s.startTraffic(msg.Traffic)
+	case "stopTraffic":
+		s.stopTraffic()
+	default:
+		return s.handleCarAction(msg)
+	}
+	return nil
+}
+
+// record appends a command to the session journal.
+func (s *ElevatorSession) record(msg ClientMessage) {
+	if s.journal == nil {
+		return
+	}
+	msg.ID = "" // 상관 ID는 연결에만 의미가 있음
+	data, err := json.Marshal(msg)
+	if err == nil {
+		err = s.journal.Command(s.sim.Now(), data)
+	}
+	if err != nil {
+		slog.Error("Failed to record command", "action", msg.Action, "error", err)
+	}
+}
+
+// handleCarAction handles actions addressed to a single car.
+func (s *ElevatorSession) handleCarAction(msg ClientMessage) error {
+	car, err := s.group.Car(msg.Car)
+	if err != nil {
+		return err
+	}
+
+	switch msg.Action {
+	case "pressOpen":
+		car.PressOpenButton()
+	case "releaseOpen":
+		car.ReleaseOpenButton()
+	case "pressClose":
+		car.PressCloseButton()
+	case "releaseClose":
+		car.ReleaseCloseButton()
+	case "pressJog":
+		return car.PressJog(elevator.Direction(msg.Direction))
+	case "releaseJog":
+		car.ReleaseJog()
+	case "obstruction":
+		car.SetObstruction(msg.Active)
+	case "injectFault":
+		return car.InjectFault(elevator.Fault{Kind: elevator.FaultKind(msg.Fault), Floor: msg.Floor, Dir: elevator.Direction(msg.Direction)})
+	case "clearFault":
+		return car.ClearFault(elevator.FaultKind(msg.Fault))
+	case "resetEmergency":
+		return car.ResetEmergency()
+	case "rescue":
+		return car.Rescue()
+	case "setMode":
+		return car.SetMode(elevator.OperationMode(msg.Mode))
+	case "addWeight":
+		car.AddWeight(msg.Weight)
+	case "setWeight":
+		car.AddWeight(msg.Weight - car.Weight())
+	default:
+		return &protocolError{code: "UnknownAction", msg: fmt.Sprintf("unknown action %q", msg.Action)}
+	}
+	return nil
+}
+
+func (s *ElevatorSession) addCall(msg ClientMessage) error {
+	switch callType := msg.callType(); callType {
+	case elevator.CallHallUp:
+		_, err := s.group.AddHallCall(msg.Floor, elevator.DirUp)
+		return err
+	case elevator.CallHallDown:
+		_, err := s.group.AddHallCall(msg.Floor, elevator.DirDown)
+		return err
+	default:
+		return s.group.AddCarCall(msg.Car, msg.Floor)
+	}
+}
+
+func (s *ElevatorSession) removeCall(msg ClientMessage) {
+	switch callType := msg.callType(); callType {
+	case elevator.CallHallUp:
+		s.group.RemoveHallCall(msg.Floor, elevator.DirUp)
+	case elevator.CallHallDown:
+		s.group.RemoveHallCall(msg.Floor, elevator.DirDown)
+	default:
+		if car, err := s.group.Car(msg.Car); err == nil {
+			car.RemoveCall(msg.Floor, callType)
+		}
+	}
+}
+
+// startTraffic replaces any running generator with one feeding the group
+// passengers on the simulation clock.
+func (s *ElevatorSession) startTraffic(cfg *TrafficConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("no traffic config provided")
+	}
+	s.stopTraffic()
+
+	profile := traffic.Constant(cfg.Rate, traffic.Pattern(cfg.Pattern))
+	if cfg.Pattern == "office" {
+		profile = traffic.OfficeDay(cfg.Rate)
+	}
+	carCfg := s.group.Cars()[0].Config
+	gen, err := traffic.New(traffic.Config{
+		MinFloor: carCfg.MinFloor,
+		MaxFloor: carCfg.MaxFloor,
+		Lobby:    carCfg.InitialFloor,
+		Profile:  profile,
+		Seed:     cfg.Seed,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.feed = gen.Attach(s.sim.Clock(), traffic.GroupSink(s.group), func(p elevator.Passenger, err error) {
+		slog.Warn("Generated passenger rejected", "id", p.ID, "error", err)
+	})
+	slog.Info("Traffic started", "pattern", cfg.Pattern, "rate", cfg.Rate, "seed", cfg.Seed)
+	return nil
+}
+
+func (s *ElevatorSession) stopTraffic() {
+	if s.feed != nil {
+		s.feed.Stop()
+		s.feed = nil
+		slog.Info("Traffic stopped")
+	}
+}
+
+// groupConfig converts the client configuration into a group configuration.
+func groupConfig(cfg *ElevatorConfig) (elevator.GroupConfig, error) {
+	scheduler, err := elevator.NewScheduler(cfg.Strategy)
+	if err != nil {
+		return elevator.GroupConfig{}, err
+	}
+	policy, err := elevator.NewAssignmentPolicy(cfg.Policy)
+	if err != nil {
+		return elevator.GroupConfig{}, err
+	}
+	cars := cfg.Cars
+	if cars < 1 {
+		cars = 1
+	}
+	var motion *elevator.MotionProfile
+	if cfg.RatedSpeed > 0 {
+		motion = &elevator.MotionProfile{
+			RatedSpeed:   cfg.RatedSpeed,
+			Acceleration: cfg.Acceleration,
+			Jerk:         cfg.Jerk,
+			FloorHeight:  cfg.FloorHeight,
+		}
+	}
+	var recall *elevator.RecallConfig
+	if cfg.RecallFloor != 0 {
+		recall = &elevator.RecallConfig{Floor: cfg.RecallFloor, AlternateFloor: cfg.AltRecallFloor}
+		if recall.AlternateFloor == 0 {
+			recall.AlternateFloor = recall.Floor
+		}
+	}
+
+	return elevator.GroupConfig{
+		ID:     cfg.ID,
+		Cars:   cars,
+		Policy: policy,
+		Car: elevator.Config{
+			MinFloor:        cfg.MinFloor,
+			MaxFloor:        cfg.MaxFloor,
+			InitialFloor:    cfg.InitialFloor,
+			TravelTime:      time.Duration(cfg.TravelTime * float64(time.Second)),
+			TravelTimeEdge:  time.Duration(cfg.TravelTime * 1.5 * float64(time.Second)),
+			DoorSpeed:       time.Duration(cfg.DoorSpeed * float64(time.Second)),
+			DoorOpenTime:    time.Duration(cfg.DoorOpenTime * float64(time.Second)),
+			DoorReopenTime:  time.Duration(cfg.DoorReopenTime * float64(time.Second)),
+			MaxWeight:       1000,
+			Scheduler:       scheduler,
+			Motion:          motion,
+			Recall:          recall,
+			InspectionSpeed: cfg.InspectionSpeed,
+			NudgingTime:     time.Duration(cfg.NudgingTime * float64(time.Second)),
+		},
+	}, nil
+}
+
+// setup builds the simulation and elevator group, starting at start.
+func (s *ElevatorSession) setup(cfg *ElevatorConfig, start time.Time) error {
+	config, err := groupConfig(cfg)
+	if err != nil {
+		return err
+	}
+	slog.Info("Elevator config", "config", config)
+
+	sim := elevator.NewSimulation(start)
+	g, err := sim.NewGroup(config)
+	if err != nil {
+		sim.Close()
+		return fmt.Errorf("failed to initialize elevator group: %w", err)
+	}
+	s.sim = sim
+	s.config = cfg
+	s.group = g
+	s.stats = stats.NewCollector(stats.Config{})
+	s.stats.AttachGroup(g)
+
+	// Observers run with s.mu held (inside a command or a pacing step).
+	g.OnEvent(func(ev elevator.GroupEvent) {
+		s.outbox = append(s.outbox, ev)
+		if s.journal != nil {
+			if err := s.journal.Event(ev.Car, ev.Event); err != nil {
+				slog.Error("Failed to record event", "error", err)
+			}
+		}
+	})
+	return nil
+}
+
+func (s *ElevatorSession) initElevator(cfg *ElevatorConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("no config provided for init")
+	}
+
+	if err := s.startLive(ClientMessage{Action: "init", Config: cfg}, time.Now()); err != nil {
+		return err
+	}
+	slog.Info("Elevator initialized", "id", cfg.ID, "cars", len(s.group.Cars()), "floors", cfg.MinFloor, "to", cfg.MaxFloor)
+	return nil
+}
+
+// restoreSnapshot resumes a saved session. Its simulation continues from the
+// virtual time the snapshot was taken at.
+func (s *ElevatorSession) restoreSnapshot(snap *SessionSnapshot) error {
+	if snap == nil || snap.Config == nil {
+		return fmt.Errorf("no snapshot provided")
+	}
+	if err := s.startLive(ClientMessage{Action: "restoreSnapshot", Snapshot: snap}, snap.Group.TakenAt); err != nil {
+		return err
+	}
+	slog.Info("Snapshot restored", "id", snap.Config.ID, "taken_at", snap.Group.TakenAt)
+	return nil
+}
+
+// startLive replaces the running simulation with one built by the "init" or
+// "restoreSnapshot" message msg, starting at virtual time start.
+func (s *ElevatorSession) startLive(msg ClientMessage, start time.Time) error {
+	// Stop existing elevator if any
+	s.stopLive()
+	s.closeReplay()
+
+	if err := s.build(msg, start); err != nil {
+		s.stopLive()
+		return err
+	}
+	s.startJournal(s.config.ID, start)
+	s.record(msg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go s.pace(ctx, s.sim)
+	go s.statsLoop(ctx)
+
+	// Send the new session and its initial state to every client
+	s.writeJSON(ServerMessage{Type: "session", Config: s.config})
+	s.sendState()
+	return nil
+}
+
+// build sets up the simulation for an "init" or "restoreSnapshot" message.
+func (s *ElevatorSession) build(msg ClientMessage, start time.Time) error {
+	if msg.Action == "init" {
+		return s.setup(msg.Config, start)
+	}
+	if err := s.setup(msg.Snapshot.Config, start); err != nil {
+		return err
+	}
+	return s.group.Restore(msg.Snapshot.Group)
+}
+
+// startJournal opens a new journal file for the session, if recording is enabled.
+func (s *ElevatorSession) startJournal(id string, start time.Time) {
+	if s.journalDir == "" {
+		return
+	}
+	name := fmt.Sprintf("%s-%s.jsonl", time.Now().Format("20060102-150405.000"), id)
+	w, err := journal.Create(filepath.Join(s.journalDir, name), start)
+	if err != nil {
+		slog.Error("Failed to start journal", "error", err)
+		return
+	}
+	s.journal = w
+	slog.Info("Recording session", "journal", name)
+}
+
+// stopLive stops the running simulation and closes its journal.
+func (s *ElevatorSession) stopLive() {
+	s.stopTraffic()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	if s.sim != nil {
+		s.sim.Close()
+	}
+	if s.journal != nil {
+		if err := s.journal.Close(); err != nil {
+			slog.Error("Failed to close journal", "error", err)
+		}
+		s.journal = nil
+	}
+	s.sim = nil
+	s.config = nil
+	s.group = nil
+	s.stats = nil
+	s.outbox = nil
+}
+
+// pace advances sim in step with the wall clock.
+func (s *ElevatorSession) pace(ctx context.Context, sim *elevator.Simulation) {
+	base, wallStart := sim.Now(), time.Now()
+	ticker := time.NewTicker(paceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			if s.sim == sim {
+				if err := sim.RunUntil(ctx, base.Add(time.Since(wallStart))); err != nil {
+					s.mu.Unlock()
+					return
+				}
+				s.flush()
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// flush delivers the queued events followed by the resulting state.
+func (s *ElevatorSession) flush() {
+	if s.journal != nil {
+		if err := s.journal.Flush(); err != nil {
+			slog.Error("Failed to flush journal", "error", err)
+		}
+	}
+	if len(s.outbox) == 0 {
+		return
+	}
+	for _, ev := range s.outbox {
+		s.sendEvent(ev)
+	}
+	s.outbox = nil
+	s.sendState()
+}
+
+// statsLoop pushes a "stats" message every second.
+func (s *ElevatorSession) statsLoop(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			s.sendStats()
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *ElevatorSession) sendStats() {
+	if s.stats == nil {
+		return
+	}
+
+	r := s.stats.Report(s.sim.Now())
+	s.writeJSON(ServerMessage{
+		Type: "stats",
+		Stats: &StatsState{
+			Waiting:          r.Waiting,
+			Riding:           r.Riding,
+			Delivered:        r.Delivered,
+			AvgWait:          r.AvgWait.Seconds(),
+			MaxWait:          r.MaxWait.Seconds(),
+			AvgRide:          r.AvgRide.Seconds(),
+			AvgJourney:       r.AvgJourney.Seconds(),
+			JourneyP50:       r.JourneyP50.Seconds(),
+			JourneyP90:       r.JourneyP90.Seconds(),
+			JourneyP95:       r.JourneyP95.Seconds(),
+			LongWaits:        r.LongWaits,
+			StopsPerTrip:     r.StopsPerTrip,
+			HandlingCapacity: r.HandlingCapacity,
+			RecentHandling:   r.RecentHandling,
+		},
+	})
+}
+
+func (s *ElevatorSession) sendState() {
+	if s.group == nil {
+		return
+	}
+	s.writeJSON(stateMessage(s.group))
+}
+
+// stateMessage builds a "state" message for every car of g.
+func stateMessage(g *elevator.Group) ServerMessage {
+	cars := g.Cars()
+	states := make([]CarState, 0, len(cars))
+	for _, car := range cars {
+		floor, direction, doors, weight := car.CurrentState()
+		calls := car.CallFloors()
+		pos := car.Position()
+		faults := []string{}
+		for _, f := range car.ActiveFaults() {
+			faults = append(faults, string(f.Kind))
+		}
+		states = append(states, CarState{
+			ID:        car.Config.ID,
+			Floor:     floor,
+			Direction: string(direction),
+			Doors: DoorStates{
+				Front: string(doors[elevator.Front]),
+				Rear:  string(doors[elevator.Rear]),
+			},
+			Mode:          int(car.CurrentMode()),
+			Emergency:     string(car.EmergencyPhase()),
+			Obstructed:    car.Obstructed(),
+			Nudging:       car.Nudging(),
+			Faults:        faults,
+			CarCalls:      calls.Car,
+			HallUpCalls:   calls.HallUp,
+			HallDownCalls: calls.HallDown,
+			Weight:        weight,
+			Position:      pos.Position,
+			Speed:         pos.Speed,
+			Level:         pos.Level,
+		})
+	}
+	hall := g.HallCalls()
+
+	return ServerMessage{
+		Type:          "state",
+		Cars:          states,
+		HallUpCalls:   hall.HallUp,
+		HallDownCalls: hall.HallDown,
+		MaxWeight:     cars[0].Config.MaxWeight,
+		Smoke:         cars[0].SmokeDetectors(),
+	}
+}
+
+func (s *ElevatorSession) sendEvent(event elevator.GroupEvent) {
+	msg := ServerMessage{
+		Type:      "event",
+		EventType: string(event.Type),
+		Car:       event.Car,
+		Payload:   event.Payload,
+		Timestamp: event.Timestamp.Format("15:04:05"),
+	}
+
+	s.writeJSON(msg)
+}
+
+// writeJSON broadcasts msg to every client of the session.
+func (s *ElevatorSession) writeJSON(msg ServerMessage) {
+	// slog.Debug("Sending message", "type", msg.Type, "event", msg.EventType) // Optional trace
+	for _, c := range s.clients {
+		c.write(msg)
+	}
+}
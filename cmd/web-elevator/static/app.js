@@ -23,34 +23,54 @@ const OperationMode = {
     AUTO: 0,
     MANUAL: 1,
     MOVING: 2,
-    EMERGENCY: 3
+    EMERGENCY: 3,
+    FIRE_RECALL: 4,
+    FIRE_SERVICE: 5
 };
 
-const ModeNames = ['Auto', 'Manual', 'Moving', 'Emergency'];
+const ModeNames = ['Auto', 'Manual', 'Moving', 'Emergency', 'FireRecall', 'FireService'];
+
+const CallType = {
+    CAR: 'Car',
+    HALL_UP: 'HallUp',
+    HALL_DOWN: 'HallDown'
+};
 
 // ========================================
 // WebSocket Client
 // ========================================
+// Number of "stats" samples (one per second) kept for the chart
+const STATS_HISTORY = 120;
+
 class ElevatorClient {
     constructor() {
         this.ws = null;
         this.eventListeners = [];
         this.stateListeners = [];
+        this.statsListeners = [];
+        this.replayListeners = [];
+        this.snapshotListeners = [];
+        this.errorListeners = [];
+        this.roomListeners = [];
+        // Actions sent and not yet answered by "ack" or "error", by id
+        this.pending = new Map();
+        this.nextId = 0;
         this.state = {
-            floor: 1,
-            direction: Direction.NONE,
-            doors: { front: DoorState.CLOSE, rear: DoorState.CLOSE },
-            mode: OperationMode.AUTO,
-            callFloors: [],
-            weight: 0,
+            cars: [],
+            hallUpCalls: [],
+            hallDownCalls: [],
             maxWeight: 1000
         };
     }
 
-    connect() {
+    // connect joins the shared room, or a private session when room is empty.
+    connect(room = '', role = 'controller') {
+        this.room = room;
+        this.role = role;
         return new Promise((resolve, reject) => {
             const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-            const wsUrl = `${protocol}//${window.location.host}/ws`;
+            const params = room ? `?${new URLSearchParams({ room, role })}` : '';
+            const wsUrl = `${protocol}//${window.location.host}/ws${params}`;
 
             this.ws = new WebSocket(wsUrl);
 
@@ -78,21 +98,43 @@ class ElevatorClient {
     handleMessage(msg) {
         if (msg.type === 'state') {
             this.state = {
-                floor: msg.floor,
-                direction: msg.direction,
-                doors: msg.doors,
-                mode: msg.mode,
-                callFloors: msg.callFloors || [],
-                weight: msg.weight || 0,
+                cars: (msg.cars || []).map(car => ({
+                    ...car,
+                    carCalls: car.carCalls || [],
+                    hallUpCalls: car.hallUpCalls || [],
+                    hallDownCalls: car.hallDownCalls || [],
+                    weight: car.weight || 0
+                })),
+                hallUpCalls: msg.hallUpCalls || [],
+                hallDownCalls: msg.hallDownCalls || [],
                 maxWeight: msg.maxWeight || 0
             };
             this.stateListeners.forEach(cb => cb(this.state));
         } else if (msg.type === 'event') {
             this.eventListeners.forEach(cb => cb({
+                car: msg.car || 0,
                 type: msg.eventType,
                 payload: msg.payload,
                 timestamp: msg.timestamp
             }));
+        } else if (msg.type === 'stats') {
+            this.statsListeners.forEach(cb => cb(msg.stats));
+        } else if (msg.type === 'journals' || msg.type === 'journal' || msg.type === 'scrub') {
+            this.replayListeners.forEach(cb => cb(msg));
+        } else if (msg.type === 'snapshot') {
+            this.snapshotListeners.forEach(cb => cb(msg.snapshot));
+        } else if (msg.type === 'room' || msg.type === 'session' || msg.type === 'stopped') {
+            this.roomListeners.forEach(cb => cb(msg));
+        } else if (msg.type === 'ack') {
+            this.pending.delete(msg.id);
+        } else if (msg.type === 'error') {
+            this.pending.delete(msg.id);
+            this.errorListeners.forEach(cb => cb({
+                id: msg.id,
+                action: msg.action,
+                code: msg.error?.code,
+                message: msg.error?.message
+            }));
         }
     }
 
@@ -104,9 +146,34 @@ class ElevatorClient {
         this.eventListeners.push(callback);
     }
 
+    onStats(callback) {
+        this.statsListeners.push(callback);
+    }
+
+    onSnapshot(callback) {
+        this.snapshotListeners.push(callback);
+    }
+
+    // Receives "journals", "journal" and "scrub" messages
+    onReplay(callback) {
+        this.replayListeners.push(callback);
+    }
+
+    // Receives "room", "session" and "stopped" messages
+    onRoom(callback) {
+        this.roomListeners.push(callback);
+    }
+
+    // Receives actions the server rejected
+    onError(callback) {
+        this.errorListeners.push(callback);
+    }
+
     send(action, data = {}) {
         if (this.ws && this.ws.readyState === WebSocket.OPEN) {
-            this.ws.send(JSON.stringify({ action, ...data }));
+            const id = String(++this.nextId);
+            this.pending.set(id, action);
+            this.ws.send(JSON.stringify({ id, action, ...data }));
         }
     }
 
@@ -114,32 +181,68 @@ class ElevatorClient {
         this.send('init', { config });
     }
 
-    addCall(floor) {
-        this.send('addCall', { floor });
+    addCall(floor, callType = CallType.CAR, car = 0) {
+        this.send('addCall', { floor, callType, car });
+    }
+
+    removeCall(floor, callType = CallType.CAR, car = 0) {
+        this.send('removeCall', { floor, callType, car });
+    }
+
+    pressOpen(car = 0) {
+        this.send('pressOpen', { car });
+    }
+
+    releaseOpen(car = 0) {
+        this.send('releaseOpen', { car });
+    }
+
+    pressClose(car = 0) {
+        this.send('pressClose', { car });
+    }
+
+    releaseClose(car = 0) {
+        this.send('releaseClose', { car });
+    }
+
+    pressJog(direction, car = 0) {
+        this.send('pressJog', { direction, car });
+    }
+
+    releaseJog(car = 0) {
+        this.send('releaseJog', { car });
+    }
+
+    resetEmergency(car = 0) {
+        this.send('resetEmergency', { car });
+    }
+
+    rescue(car = 0) {
+        this.send('rescue', { car });
     }
 
-    removeCall(floor) {
-        this.send('removeCall', { floor });
+    setObstruction(active, car = 0) {
+        this.send('obstruction', { car, active });
     }
 
-    pressOpen() {
-        this.send('pressOpen');
+    injectFault(fault, floor, direction, car = 0) {
+        this.send('injectFault', { car, fault, floor, direction });
     }
 
-    releaseOpen() {
-        this.send('releaseOpen');
+    clearFault(fault, car = 0) {
+        this.send('clearFault', { car, fault });
     }
 
-    pressClose() {
-        this.send('pressClose');
+    setSmokeDetector(floor, active) {
+        this.send('smokeDetector', { floor, active });
     }
 
-    setWeight(weight) {
-        this.send('setWeight', { weight });
+    setWeight(weight, car = 0) {
+        this.send('setWeight', { weight, car });
     }
 
-    setMode(mode) {
-        this.send('setMode', { mode });
+    setMode(mode, car = 0) {
+        this.send('setMode', { mode, car });
     }
 
     reset() {
@@ -150,15 +253,46 @@ class ElevatorClient {
         this.send('stop');
     }
 
+    startTraffic(pattern, rate) {
+        this.send('startTraffic', { traffic: { pattern, rate } });
+    }
+
+    stopTraffic() {
+        this.send('stopTraffic');
+    }
+
+    listJournals() {
+        this.send('listJournals');
+    }
+
+    openJournal(name) {
+        this.send('openJournal', { name });
+    }
+
+    scrub(offset) {
+        this.send('scrub', { offset });
+    }
+
+    saveSnapshot() {
+        this.send('saveSnapshot');
+    }
+
+    restoreSnapshot(snapshot) {
+        this.send('restoreSnapshot', { snapshot });
+    }
+
     getState() {
         return this.state;
     }
 
-    getFloor() { return this.state.floor; }
-    getDirection() { return this.state.direction; }
-    getDoors() { return this.state.doors; }
-    getMode() { return this.state.mode; }
-    getCallFloors() { return this.state.callFloors; }
+    getCar(car = 0) { return this.state.cars[car]; }
+    getCarCalls(car = 0) {
+        const state = this.getCar(car);
+        return state ? state.carCalls : [];
+    }
+    getHallCalls(callType) {
+        return callType === CallType.HALL_UP ? this.state.hallUpCalls : this.state.hallDownCalls;
+    }
 }
 
 // ========================================
@@ -168,6 +302,7 @@ class UIController {
     constructor() {
         this.client = null;
         this.config = null;
+        this.selectedCar = 0;
         this.bindElements();
         this.bindEvents();
     }
@@ -186,10 +321,22 @@ class UIController {
         this.doorSpeedInput = document.getElementById('doorSpeed');
         this.doorOpenTimeInput = document.getElementById('doorOpenTime');
         this.doorReopenTimeInput = document.getElementById('doorReopenTime');
+        this.strategyInput = document.getElementById('strategy');
+        this.carsInput = document.getElementById('cars');
+        this.policyInput = document.getElementById('policy');
+        this.ratedSpeedInput = document.getElementById('ratedSpeed');
+        this.accelerationInput = document.getElementById('acceleration');
+        this.jerkInput = document.getElementById('jerk');
+        this.floorHeightInput = document.getElementById('floorHeight');
+        this.inspectionSpeedInput = document.getElementById('inspectionSpeed');
+        this.nudgingTimeInput = document.getElementById('nudgingTime');
+        this.recallFloorInput = document.getElementById('recallFloor');
+        this.altRecallFloorInput = document.getElementById('altRecallFloor');
 
         // Building
         this.building = document.getElementById('building');
-        this.elevatorCar = document.getElementById('elevator-car');
+        this.shafts = document.getElementById('shafts');
+        this.carTabs = document.getElementById('car-tabs');
 
         // Status
         this.statusMode = document.getElementById('status-mode');
@@ -201,16 +348,75 @@ class UIController {
         this.floorButtons = document.getElementById('floor-buttons');
         this.btnOpen = document.getElementById('btn-open');
         this.btnClose = document.getElementById('btn-close');
+        this.btnObstruction = document.getElementById('btn-obstruction');
+        this.obstructed = false;
         this.modeSelect = document.getElementById('mode-select');
+        this.inspectionPanel = document.getElementById('inspection-panel');
+        this.btnJogUp = document.getElementById('btn-jog-up');
+        this.btnJogDown = document.getElementById('btn-jog-down');
+        this.emergencyPanel = document.getElementById('emergency-panel');
+        this.emergencyPhase = document.getElementById('emergency-phase');
+        this.btnEmergencyReset = document.getElementById('btn-emergency-reset');
+        this.btnRescue = document.getElementById('btn-rescue');
         this.btnReset = document.getElementById('btn-reset');
         this.btnStop = document.getElementById('btn-stop');
 
+        // Traffic
+        this.trafficPattern = document.getElementById('traffic-pattern');
+        this.trafficRate = document.getElementById('traffic-rate');
+        this.btnTraffic = document.getElementById('btn-traffic');
+        this.trafficRunning = false;
+
+        // Fire service
+        this.faultKind = document.getElementById('fault-kind');
+        this.faultFloor = document.getElementById('fault-floor');
+        this.faultDir = document.getElementById('fault-dir');
+        this.btnFaultInject = document.getElementById('btn-fault-inject');
+        this.btnFaultClear = document.getElementById('btn-fault-clear');
+        this.faultActive = document.getElementById('fault-active');
+        this.smokeFloor = document.getElementById('smoke-floor');
+        this.btnSmoke = document.getElementById('btn-smoke');
+        this.smokeActive = [];
+
+        // Stats
+        this.statsAvgWait = document.getElementById('stats-avg-wait');
+        this.statsAvgRide = document.getElementById('stats-avg-ride');
+        this.statsJourney = document.getElementById('stats-journey');
+        this.statsLongWaits = document.getElementById('stats-long-waits');
+        this.statsStops = document.getElementById('stats-stops');
+        this.statsHandling = document.getElementById('stats-handling');
+        this.statsChart = document.getElementById('stats-chart');
+        this.statsHistory = [];
+
         // Weight
         this.weightSlider = document.getElementById('weight-slider');
         this.weightValue = document.getElementById('weight-value');
         this.weightMax = document.getElementById('weight-max');
         this.overloadIndicator = document.getElementById('overload-indicator');
 
+        // Replay
+        this.journalSelect = document.getElementById('journal-select');
+        this.btnJournalRefresh = document.getElementById('btn-journal-refresh');
+        this.btnJournalOpen = document.getElementById('btn-journal-open');
+        this.replayPanel = document.getElementById('replay-panel');
+        this.replayName = document.getElementById('replay-name');
+        this.replaySlider = document.getElementById('replay-slider');
+        this.replayTime = document.getElementById('replay-time');
+        this.btnReplayPlay = document.getElementById('btn-replay-play');
+        this.replayTimer = null;
+
+        // Room
+        this.roomNameInput = document.getElementById('room-name');
+        this.roomRoleInput = document.getElementById('room-role');
+        this.btnRoomJoin = document.getElementById('btn-room-join');
+        this.roomInfo = document.getElementById('room-info');
+
+        // Snapshot
+        this.btnSave = document.getElementById('btn-save');
+        this.btnLoad = document.getElementById('btn-load');
+        this.btnLoadConfig = document.getElementById('btn-load-config');
+        this.snapshotFile = document.getElementById('snapshot-file');
+
         // Log
         this.eventLog = document.getElementById('event-log');
         this.btnClearLog = document.getElementById('btn-clear-log');
@@ -228,22 +434,56 @@ class UIController {
 
         // Door buttons
         this.btnOpen.addEventListener('mousedown', () => {
-            if (this.client) this.client.pressOpen();
+            if (this.client) this.client.pressOpen(this.selectedCar);
         });
         this.btnOpen.addEventListener('mouseup', () => {
-            if (this.client) this.client.releaseOpen();
+            if (this.client) this.client.releaseOpen(this.selectedCar);
         });
         this.btnOpen.addEventListener('mouseleave', () => {
-            if (this.client) this.client.releaseOpen();
+            if (this.client) this.client.releaseOpen(this.selectedCar);
+        });
+        // The close button is held like the open button: fire service
+        // Phase II closes the doors only while it is pressed.
+        this.btnClose.addEventListener('mousedown', () => {
+            if (this.client) this.client.pressClose(this.selectedCar);
+        });
+        this.btnClose.addEventListener('mouseup', () => {
+            if (this.client) this.client.releaseClose(this.selectedCar);
         });
-        this.btnClose.addEventListener('click', () => {
-            if (this.client) this.client.pressClose();
+        this.btnClose.addEventListener('mouseleave', (e) => {
+            if (this.client && e.buttons) this.client.releaseClose(this.selectedCar);
+        });
+
+        // Door sensor: toggles the light curtain / safety edge of the selected car.
+        this.btnObstruction.addEventListener('click', () => {
+            if (this.client) this.client.setObstruction(!this.obstructed, this.selectedCar);
+        });
+
+        // Inspection jog: the car moves only while a button is held.
+        [[this.btnJogUp, Direction.UP], [this.btnJogDown, Direction.DOWN]].forEach(([btn, dir]) => {
+            btn.addEventListener('mousedown', () => {
+                if (this.client) this.client.pressJog(dir, this.selectedCar);
+            });
+            btn.addEventListener('mouseup', () => {
+                if (this.client) this.client.releaseJog(this.selectedCar);
+            });
+            btn.addEventListener('mouseleave', (e) => {
+                if (this.client && e.buttons) this.client.releaseJog(this.selectedCar);
+            });
+        });
+
+        // Emergency stop recovery
+        this.btnEmergencyReset.addEventListener('click', () => {
+            if (this.client) this.client.resetEmergency(this.selectedCar);
+        });
+        this.btnRescue.addEventListener('click', () => {
+            if (this.client) this.client.rescue(this.selectedCar);
         });
 
         // Mode select
         this.modeSelect.addEventListener('change', () => {
             if (this.client) {
-                this.client.setMode(parseInt(this.modeSelect.value));
+                this.client.setMode(parseInt(this.modeSelect.value), this.selectedCar);
             }
         });
 
@@ -258,18 +498,95 @@ class UIController {
         // Stop
         this.btnStop.addEventListener('click', () => {
             if (this.client) {
-                this.client.setMode(OperationMode.EMERGENCY);
+                this.client.setMode(OperationMode.EMERGENCY, this.selectedCar);
                 this.modeSelect.value = OperationMode.EMERGENCY;
             }
         });
 
+        // Traffic generator
+        this.btnTraffic.addEventListener('click', () => {
+            if (!this.client) return;
+            if (this.trafficRunning) {
+                this.client.stopTraffic();
+                this.addLog('🚶 교통 생성 중지', 'info');
+            } else {
+                const rate = parseFloat(this.trafficRate.value);
+                this.client.startTraffic(this.trafficPattern.value, rate);
+                this.addLog(`🚶 교통 생성 시작: ${this.trafficPattern.selectedOptions[0].text}, 분당 ${rate}명`, 'info');
+            }
+            this.setTrafficRunning(!this.trafficRunning);
+        });
+
+        // Fault injection: the stuck hall button needs a floor and direction.
+        this.faultKind.addEventListener('change', () => {
+            const button = this.faultKind.value === 'StuckHallButton';
+            this.faultFloor.classList.toggle('hidden', !button);
+            this.faultDir.classList.toggle('hidden', !button);
+        });
+        this.btnFaultInject.addEventListener('click', () => {
+            if (!this.client) return;
+            this.client.injectFault(this.faultKind.value, parseInt(this.faultFloor.value), this.faultDir.value, this.selectedCar);
+        });
+        this.btnFaultClear.addEventListener('click', () => {
+            if (this.client) this.client.clearFault(this.faultKind.value, this.selectedCar);
+        });
+
+        // Smoke detector
+        this.btnSmoke.addEventListener('click', () => {
+            if (!this.client) return;
+            const floor = parseInt(this.smokeFloor.value);
+            this.client.setSmokeDetector(floor, !this.smokeActive.includes(floor));
+        });
+        this.smokeFloor.addEventListener('input', () => this.updateSmoke(this.smokeActive));
+
         // Weight slider
         this.weightSlider.addEventListener('input', () => {
             this.weightValue.textContent = this.weightSlider.value;
         });
         this.weightSlider.addEventListener('change', () => {
             if (this.client) {
-                this.client.setWeight(parseInt(this.weightSlider.value));
+                this.client.setWeight(parseInt(this.weightSlider.value), this.selectedCar);
+            }
+        });
+
+        // Replay
+        this.btnJournalRefresh.addEventListener('click', () => this.refreshJournals());
+        this.btnJournalOpen.addEventListener('click', async () => {
+            if (!this.journalSelect.value || !await this.connect()) return;
+            this.client.openJournal(this.journalSelect.value);
+        });
+        this.replaySlider.addEventListener('input', () => {
+            this.setReplayPlaying(false);
+            this.client.scrub(parseFloat(this.replaySlider.value));
+        });
+        this.btnReplayPlay.addEventListener('click', () => {
+            this.setReplayPlaying(this.replayTimer === null);
+        });
+
+        // Room
+        this.btnRoomJoin.addEventListener('click', async () => {
+            if (!this.roomNameInput.value.trim()) {
+                alert('방 이름을 입력하세요.');
+                return;
+            }
+            await this.connect();
+        });
+
+        // Snapshot save/load
+        this.btnSave.addEventListener('click', () => {
+            if (this.client) this.client.saveSnapshot();
+        });
+        [this.btnLoad, this.btnLoadConfig].forEach(btn => btn.addEventListener('click', () => {
+            this.snapshotFile.value = '';
+            this.snapshotFile.click();
+        }));
+        this.snapshotFile.addEventListener('change', async () => {
+            const file = this.snapshotFile.files[0];
+            if (!file) return;
+            try {
+                await this.restoreSnapshot(JSON.parse(await file.text()));
+            } catch (error) {
+                alert('세션 파일을 읽을 수 없습니다.');
             }
         });
 
@@ -285,6 +602,7 @@ class UIController {
     }
 
     async startSimulation() {
+        this.setTrafficRunning(false);
         this.config = {
             id: 'WEB-ELV',
             minFloor: parseInt(this.minFloorInput.value),
@@ -294,6 +612,17 @@ class UIController {
             doorSpeed: parseFloat(this.doorSpeedInput.value),
             doorOpenTime: parseFloat(this.doorOpenTimeInput.value),
             doorReopenTime: parseFloat(this.doorReopenTimeInput.value),
+            strategy: this.strategyInput.value,
+            cars: parseInt(this.carsInput.value),
+            policy: this.policyInput.value,
+            ratedSpeed: parseFloat(this.ratedSpeedInput.value),
+            acceleration: parseFloat(this.accelerationInput.value),
+            jerk: parseFloat(this.jerkInput.value),
+            floorHeight: parseFloat(this.floorHeightInput.value),
+            inspectionSpeed: parseFloat(this.inspectionSpeedInput.value) || 0,
+            nudgingTime: parseFloat(this.nudgingTimeInput.value) || 0,
+            recallFloor: parseInt(this.recallFloorInput.value) || 0,
+            altRecallFloor: parseInt(this.altRecallFloorInput.value) || 0,
         };
 
         // Validate
@@ -302,15 +631,30 @@ class UIController {
             return;
         }
 
-        // Create WebSocket client
-        this.client = new ElevatorClient();
+        if (!await this.connect()) return;
+
+        // Initialize elevator on server; the "session" reply shows it
+        this.client.init(this.config);
+    }
+
+    // connect opens the WebSocket connection to the room chosen on the config
+    // screen, reconnecting if the room or role changed, and subscribes the UI.
+    async connect() {
+        const room = this.roomNameInput.value.trim();
+        const role = this.roomRoleInput.value;
+        if (this.client && this.client.ws.readyState === WebSocket.OPEN) {
+            if (this.client.room === room && this.client.role === role) return true;
+            this.leave();
+        }
+        const client = new ElevatorClient();
 
         try {
-            await this.client.connect();
+            await client.connect(room, role);
         } catch (error) {
             alert('서버 연결에 실패했습니다. Go 서버가 실행 중인지 확인하세요.');
-            return;
+            return false;
         }
+        this.client = client;
 
         // Subscribe to state updates
         this.client.onState((state) => this.updateUI(state));
@@ -318,35 +662,177 @@ class UIController {
         // Subscribe to events
         this.client.onEvent((event) => this.handleEvent(event));
 
-        // Build UI
+        // Subscribe to statistics
+        this.client.onStats((stats) => this.updateStats(stats));
+
+        // Subscribe to saved sessions
+        this.client.onSnapshot((snapshot) => this.downloadSnapshot(snapshot));
+
+        // Subscribe to journal replay
+        this.client.onReplay((msg) => this.handleReplay(msg));
+
+        // Subscribe to room membership and sessions started by any controller
+        this.client.onRoom((msg) => this.handleRoom(msg));
+
+        // Subscribe to rejected actions
+        this.client.onError((err) => this.addLog(`⛔ ${err.action || '요청'} 거부: ${err.message} [${err.code}]`, 'error'));
+        return true;
+    }
+
+    // showSimulation builds the building for this.config and switches screens.
+    showSimulation(replay) {
+        this.statsHistory = [];
+        this.selectedCar = 0;
         this.buildFloorUI(this.config);
+        this.buildCars(this.config);
         this.buildFloorButtons(this.config);
 
-        // Initialize elevator on server
-        this.client.init(this.config);
+        this.simulationScreen.classList.toggle('replay-mode', replay);
+        this.replayPanel.classList.toggle('hidden', !replay);
 
         // Switch screens
         this.configScreen.classList.add('hidden');
         this.simulationScreen.classList.remove('hidden');
 
-        // Clear log and add start message
+        // Clear log
         this.eventLog.innerHTML = '';
-        this.addLog('🚀 시뮬레이터가 시작되었습니다. (Go 서버 연결됨)', 'info');
 
         // Wait for DOM to render, then update position
         requestAnimationFrame(() => {
             requestAnimationFrame(() => {
-                this.updateElevatorPosition(this.config.initialFloor);
+                this.carElements.forEach(el => this.updateElevatorPosition(el, this.config.initialFloor));
             });
         });
     }
 
+    setTrafficRunning(running) {
+        this.trafficRunning = running;
+        this.btnTraffic.textContent = running ? '⏸ 중지' : '▶ 시작';
+        this.btnTraffic.classList.toggle('active', running);
+    }
+
+    // downloadSnapshot saves a session snapshot as a JSON file.
+    downloadSnapshot(snapshot) {
+        const blob = new Blob([JSON.stringify(snapshot, null, 2)], { type: 'application/json' });
+        const a = document.createElement('a');
+        a.href = URL.createObjectURL(blob);
+        a.download = `elevator-${new Date().toISOString().replace(/[:.]/g, '-')}.json`;
+        a.click();
+        URL.revokeObjectURL(a.href);
+        this.addLog('💾 세션이 저장되었습니다.', 'info');
+    }
+
+    // restoreSnapshot resumes a saved session from where it was saved.
+    async restoreSnapshot(snapshot) {
+        if (!snapshot || !snapshot.config || !snapshot.group) throw new Error('invalid snapshot');
+        if (!await this.connect()) return;
+
+        this.setTrafficRunning(false);
+        this.setReplayPlaying(false);
+        this.client.restoreSnapshot(snapshot);
+    }
+
+    handleRoom(msg) {
+        switch (msg.type) {
+            case 'room': {
+                const observer = msg.role === 'observer';
+                this.simulationScreen.classList.toggle('observer-mode', observer);
+                this.roomInfo.classList.toggle('hidden', !msg.room);
+                this.roomInfo.textContent = `👥 ${msg.room} · ${observer ? '관찰자' : '제어자'} · ${msg.clients}명 접속`;
+                break;
+            }
+            case 'session':
+                this.setTrafficRunning(false);
+                this.setReplayPlaying(false);
+                this.config = msg.config;
+                this.showSimulation(false);
+                this.addLog('🚀 시뮬레이터가 시작되었습니다. (Go 서버 연결됨)', 'info');
+                break;
+            case 'stopped':
+                this.showConfig();
+                break;
+        }
+    }
+
+    // leave closes the connection; a shared room keeps running without it.
+    leave() {
+        this.client.ws.close();
+        this.client = null;
+    }
+
+    async refreshJournals() {
+        if (await this.connect()) this.client.listJournals();
+    }
+
+    handleReplay(msg) {
+        switch (msg.type) {
+            case 'journals': {
+                this.journalSelect.innerHTML = '';
+                (msg.journals || []).forEach(name => {
+                    const option = document.createElement('option');
+                    option.value = name;
+                    option.textContent = name;
+                    this.journalSelect.appendChild(option);
+                });
+                this.btnJournalOpen.disabled = this.journalSelect.options.length === 0;
+                break;
+            }
+            case 'journal':
+                this.setReplayPlaying(false);
+                this.config = msg.journal.config;
+                this.showSimulation(true);
+                this.replayName.textContent = msg.journal.name;
+                this.replaySlider.max = msg.journal.duration;
+                this.replaySlider.value = 0;
+                this.replayTime.textContent = this.formatReplayTime(0);
+                this.addLog(`🎞️ 기록 재생: ${msg.journal.name}`, 'info');
+                break;
+            case 'scrub': {
+                const offset = msg.offset || 0;
+                this.replaySlider.value = offset;
+                this.replayTime.textContent = this.formatReplayTime(offset);
+                // The server follows with the most recent events before offset
+                this.eventLog.innerHTML = '';
+                break;
+            }
+        }
+    }
+
+    // setReplayPlaying advances the replay in real time while playing.
+    setReplayPlaying(playing) {
+        clearInterval(this.replayTimer);
+        this.replayTimer = null;
+        if (playing) {
+            this.replayTimer = setInterval(() => {
+                const next = parseFloat(this.replaySlider.value) + 0.2;
+                if (next >= parseFloat(this.replaySlider.max)) this.setReplayPlaying(false);
+                this.client.scrub(Math.min(next, parseFloat(this.replaySlider.max)));
+            }, 200);
+        }
+        this.btnReplayPlay.textContent = playing ? '⏸' : '▶';
+    }
+
+    formatReplayTime(seconds) {
+        const total = Math.floor(seconds);
+        const m = Math.floor(total / 60);
+        const s = String(total % 60).padStart(2, '0');
+        const max = Math.floor(parseFloat(this.replaySlider.max) || 0);
+        return `${m}:${s} / ${Math.floor(max / 60)}:${String(max % 60).padStart(2, '0')}`;
+    }
+
+    // stopSimulation stops a private session, or leaves a shared room.
     stopSimulation() {
-        if (this.client) {
+        if (this.client && this.client.room) {
+            this.leave();
+        } else if (this.client) {
             this.client.stop();
-            this.client = null;
         }
+        this.showConfig();
+    }
 
+    showConfig() {
+        this.setTrafficRunning(false);
+        this.setReplayPlaying(false);
         this.simulationScreen.classList.add('hidden');
         this.configScreen.classList.remove('hidden');
     }
@@ -364,17 +850,83 @@ class UIController {
             label.className = 'floor-label';
             label.textContent = this.formatFloorName(f);
 
-            const indicator = document.createElement('div');
-            indicator.className = 'floor-indicator';
+            const hallButtons = document.createElement('div');
+            hallButtons.className = 'hall-buttons';
+            if (f < config.maxFloor) {
+                hallButtons.appendChild(this.createHallButton(f, CallType.HALL_UP));
+            }
+            if (f > config.minFloor) {
+                hallButtons.appendChild(this.createHallButton(f, CallType.HALL_DOWN));
+            }
 
             floorDiv.appendChild(label);
-            floorDiv.appendChild(indicator);
+            floorDiv.appendChild(hallButtons);
 
             this.building.appendChild(floorDiv);
             this.floorElements[f] = floorDiv;
         }
     }
 
+    buildCars(config) {
+        this.shafts.innerHTML = '';
+        this.carTabs.innerHTML = '';
+        this.carElements = [];
+        this.carTabElements = [];
+
+        const count = Math.max(1, config.cars || 1);
+        for (let i = 0; i < count; i++) {
+            const car = document.createElement('div');
+            car.className = 'elevator-car';
+            car.style.left = `${((i + 0.5) / count) * 100}%`;
+            car.style.width = `${Math.min(60, 180 / count)}px`;
+            car.innerHTML = `
+                <div class="car-inner">
+                    <div class="car-door left"></div>
+                    <div class="car-door right"></div>
+                </div>
+            `;
+            car.addEventListener('click', () => this.selectCar(i));
+            this.shafts.appendChild(car);
+            this.carElements.push(car);
+
+            const tab = document.createElement('button');
+            tab.className = 'btn-car-tab';
+            tab.textContent = this.formatCarName(i);
+            tab.addEventListener('click', () => this.selectCar(i));
+            this.carTabs.appendChild(tab);
+            this.carTabElements.push(tab);
+        }
+        this.carTabs.classList.toggle('hidden', count === 1);
+    }
+
+    selectCar(index) {
+        this.selectedCar = index;
+        if (this.client) {
+            this.updateUI(this.client.getState());
+        }
+    }
+
+    formatCarName(index) {
+        return `${index + 1}호기`;
+    }
+
+    createHallButton(floor, callType) {
+        const btn = document.createElement('button');
+        btn.className = 'btn-hall';
+        btn.dataset.callType = callType;
+        btn.textContent = callType === CallType.HALL_UP ? '▲' : '▼';
+
+        btn.addEventListener('click', () => {
+            if (!this.client) return;
+            if (this.client.getHallCalls(callType).includes(floor)) {
+                this.client.removeCall(floor, callType);
+            } else {
+                this.client.addCall(floor, callType);
+            }
+        });
+        return btn;
+    }
+
     buildFloorButtons(config) {
         this.floorButtons.innerHTML = '';
         this.floorButtonElements = {};
@@ -389,11 +941,11 @@ class UIController {
             btn.addEventListener('click', () => {
                 if (this.client) {
                     // Toggle: if already called, remove; otherwise add
-                    const callFloors = this.client.getCallFloors();
-                    if (callFloors.includes(f)) {
-                        this.client.removeCall(f);
+                    const carCalls = this.client.getCarCalls(this.selectedCar);
+                    if (carCalls.includes(f)) {
+                        this.client.removeCall(f, CallType.CAR, this.selectedCar);
                     } else {
-                        this.client.addCall(f);
+                        this.client.addCall(f, CallType.CAR, this.selectedCar);
                     }
                 }
             });
@@ -412,12 +964,13 @@ class UIController {
     handleEvent(event) {
         const eventType = event.type;
         const payload = event.payload;
+        const prefix = this.carElements.length > 1 ? `[${this.formatCarName(event.car)}] ` : '';
 
         switch (eventType) {
             case 'FloorChange':
                 // Go sends just the floor number as payload for FloorChange
                 const floorValue = typeof payload === 'number' ? payload : (payload?.to || payload);
-                this.addLog(`📍 층 변경: ${this.formatFloorName(floorValue)}`, 'floor');
+                this.addLog(`${prefix}📍 층 변경: ${this.formatFloorName(floorValue)}`, 'floor', event.timestamp);
                 break;
             case 'DoorChange':
                 // Go sends { Side: number, State: string }
@@ -425,59 +978,180 @@ class UIController {
                 const doorIcon = doorState === 'Open' ? '🚪↔️' :
                     doorState === 'Close' ? '🚪' :
                         doorState === 'Opening' ? '🚪→' : '🚪←';
-                this.addLog(`${doorIcon} 문 상태: ${doorState}`, 'door');
+                this.addLog(`${prefix}${doorIcon} 문 상태: ${doorState}`, 'door', event.timestamp);
                 break;
             case 'DirectionChange':
                 // Go sends the direction string as payload
                 const direction = typeof payload === 'string' ? payload : (payload?.to || payload);
                 const dirIcon = direction === 'Up' ? '⬆️' :
                     direction === 'Down' ? '⬇️' : '⏹';
-                this.addLog(`${dirIcon} 방향 변경: ${direction}`, 'direction');
+                this.addLog(`${prefix}${dirIcon} 방향 변경: ${direction}`, 'direction', event.timestamp);
                 break;
             case 'ModeChange':
                 // Go sends OperationMode (int) as payload
                 const modeValue = typeof payload === 'number' ? payload : (payload?.to || 0);
-                this.addLog(`⚙️ 모드 변경: ${ModeNames[modeValue] || modeValue}`, 'mode');
+                this.addLog(`${prefix}⚙️ 모드 변경: ${ModeNames[modeValue] || modeValue}`, 'mode', event.timestamp);
+                break;
+            case 'SmokeDetector':
+                this.addLog(`${prefix}🔥 연기 감지기 ${this.formatFloorName(payload?.Floor)}: ${payload?.Active ? '작동' : '해제'}`, 'mode', event.timestamp);
+                break;
+            case 'RecallStarted':
+                this.addLog(`${prefix}🚒 소방 귀환 시작: ${this.formatFloorName(payload?.Floor)}${payload?.Alternate ? ' (대체 층)' : ''}`, 'mode', event.timestamp);
+                break;
+            case 'RecallComplete':
+                this.addLog(`${prefix}🚒 소방 귀환 완료: ${this.formatFloorName(payload?.Floor)}`, 'mode', event.timestamp);
+                break;
+            case 'Obstruction':
+                this.addLog(`${prefix}🚧 문 감지기 ${payload?.Blocked ? '차단' : '해제'}`, 'door', event.timestamp);
+                break;
+            case 'Nudging':
+                this.addLog(`${prefix}🔔 넛징 ${payload?.Active ? '시작: 부저, 저속 닫힘' : '종료'}`, 'door', event.timestamp);
+                break;
+            case 'Error':
+                // Rejected commands are logged from the error reply to the action
+                if (payload?.Fault) {
+                    this.addLog(`${prefix}⚠️ 고장: ${payload.Message} (${this.formatFloorName(payload.Floor)})`, 'error', event.timestamp);
+                }
+                break;
+            case 'FaultCleared':
+                this.addLog(`${prefix}✅ 고장 해제: ${payload?.Fault?.Kind}`, 'mode', event.timestamp);
+                break;
+            case 'EmergencyStop':
+                this.addLog(`${prefix}🚨 비상 정지: ${payload?.Level?.toFixed(2)}층 위치, 리셋 필요`, 'mode', event.timestamp);
+                break;
+            case 'EmergencyReset':
+                this.addLog(`${prefix}✅ 비상 정지 리셋`, 'mode', event.timestamp);
+                break;
+            case 'RescueStarted':
+                this.addLog(`${prefix}🛟 구출 운전 시작`, 'mode', event.timestamp);
+                break;
+            case 'RescueComplete':
+                this.addLog(`${prefix}🛟 구출 완료: ${this.formatFloorName(payload?.Floor)} 문 열림`, 'mode', event.timestamp);
+                break;
+            case 'Position':
+                // Sent every tick while travelling; the car is moved from the state message.
+                break;
+            case 'PassengerWaiting':
+            case 'PassengerBoarded':
+            case 'PassengerAlighted':
+            case 'PassengerEvacuated': {
+                // Go sends { Passenger: {...}, Floor: number }
+                const p = payload?.Passenger || {};
+                const verb = eventType === 'PassengerWaiting' ? '대기' :
+                    eventType === 'PassengerBoarded' ? '탑승' :
+                    eventType === 'PassengerEvacuated' ? `대피 (${this.formatFloorName(payload?.Floor)})` : '하차';
+                this.addLog(`${prefix}🚶 ${p.ID} ${verb}: ${this.formatFloorName(p.Origin)} → ${this.formatFloorName(p.Destination)}`, 'info', event.timestamp);
                 break;
+            }
             default:
-                this.addLog(`📌 ${eventType}: ${JSON.stringify(payload)}`, 'info');
+                this.addLog(`${prefix}📌 ${eventType}: ${JSON.stringify(payload)}`, 'info', event.timestamp);
         }
     }
 
     updateUI(state) {
-        if (!state) return;
-        console.log('Updating UI with state:', state); // Debug log
+        if (!state || state.cars.length === 0) return;
+
+        const car = state.cars[this.selectedCar] || state.cars[0];
 
         // Status
-        const mode = state.mode;
+        const mode = car.mode;
         this.statusMode.textContent = ModeNames[mode];
         this.statusMode.className = `status-value mode-${ModeNames[mode].toLowerCase()}`;
-
-        const dir = state.direction;
+        if (document.activeElement !== this.modeSelect) {
+            this.modeSelect.value = mode;
+        }
+        this.inspectionPanel.classList.toggle('hidden', mode !== OperationMode.MANUAL);
+        this.emergencyPanel.classList.toggle('hidden', mode !== OperationMode.EMERGENCY);
+        this.emergencyPhase.textContent = car.emergency || '-';
+        this.btnEmergencyReset.disabled = car.emergency !== 'Latched';
+        this.btnRescue.disabled = car.emergency !== 'Reset';
+        this.obstructed = car.obstructed;
+        const faults = car.faults || [];
+        this.faultActive.textContent = faults.length ? `작동 중: ${faults.join(', ')}` : '';
+        this.btnObstruction.classList.toggle('active', car.obstructed);
+        this.btnObstruction.textContent = car.nudging ? '🔔 넛징 중' : car.obstructed ? '✅ 차단 해제' : '🚧 문 감지기 차단';
+
+        const dir = car.direction;
         const dirIcon = dir === Direction.UP ? '⬆️' : dir === Direction.DOWN ? '⬇️' : '⏹';
         this.statusDirection.innerHTML = `<span class="direction-icon">${dirIcon}</span> ${dir}`;
 
-        const floor = state.floor;
-        this.statusFloor.textContent = this.formatFloorName(floor);
+        this.statusFloor.textContent = this.formatFloorName(car.floor);
 
-        const doorState = state.doors.front;
+        const doorState = car.doors.front;
         const doorIcon = doorState === DoorState.OPEN ? '🚪↔️' : '🚪';
         this.statusDoor.innerHTML = `<span class="door-icon">${doorIcon}</span> ${doorState}`;
 
-        // Elevator position
-        this.updateElevatorPosition(floor);
-
-        // Elevator door animation
-        this.updateElevatorDoors(doorState);
+        // Elevator cars: position and door animation
+        state.cars.forEach((c, i) => {
+            const el = this.carElements[i];
+            if (!el) return;
+            this.updateElevatorPosition(el, c.level ?? c.floor);
+            this.updateElevatorDoors(el, c.doors.front);
+            el.classList.toggle('selected', i === this.selectedCar && state.cars.length > 1);
+        });
+        this.carTabElements.forEach((tab, i) => tab.classList.toggle('active', i === this.selectedCar));
 
         // Floor indicators
-        this.updateFloorIndicators(state);
+        this.updateFloorIndicators(state, car);
 
         // Floor buttons
-        this.updateFloorButtons(state);
+        this.updateFloorButtons(car);
 
         // Weight
-        this.updateWeight(state);
+        this.updateWeight({ weight: car.weight, maxWeight: state.maxWeight });
+
+        // Smoke detectors
+        this.updateSmoke(state.smoke || []);
+    }
+
+    updateSmoke(active) {
+        this.smokeActive = active;
+        const on = active.includes(parseInt(this.smokeFloor.value));
+        this.btnSmoke.textContent = on ? '✅ 감지 해제' : '🔥 감지 작동';
+        this.btnSmoke.classList.toggle('active', on);
+        this.btnSmoke.title = active.length ? `작동 중: ${active.map(f => this.formatFloorName(f)).join(', ')}` : '';
+    }
+
+    updateStats(stats) {
+        if (!stats) return;
+        const sec = (v) => `${v.toFixed(1)}s`;
+
+        this.statsAvgWait.textContent = sec(stats.avgWait);
+        this.statsAvgRide.textContent = sec(stats.avgRide);
+        this.statsJourney.textContent = `${sec(stats.journeyP50)} / ${sec(stats.journeyP90)} / ${sec(stats.journeyP95)}`;
+        this.statsLongWaits.textContent = stats.longWaits;
+        this.statsStops.textContent = stats.stopsPerTrip.toFixed(2);
+        this.statsHandling.textContent = `${stats.recentHandling} (최대 ${stats.handlingCapacity})`;
+
+        this.statsHistory.push(stats);
+        if (this.statsHistory.length > STATS_HISTORY) this.statsHistory.shift();
+        this.drawStatsChart();
+    }
+
+    drawStatsChart() {
+        const canvas = this.statsChart;
+        const ctx = canvas.getContext('2d');
+        const { width, height } = canvas;
+        ctx.clearRect(0, 0, width, height);
+
+        const series = [
+            { key: 'avgWait', color: '#6366f1' },
+            { key: 'avgJourney', color: '#22c55e' }
+        ];
+        const max = Math.max(10, ...this.statsHistory.flatMap(s => series.map(({ key }) => s[key])));
+        const step = width / (STATS_HISTORY - 1);
+
+        series.forEach(({ key, color }) => {
+            ctx.strokeStyle = color;
+            ctx.lineWidth = 2;
+            ctx.beginPath();
+            this.statsHistory.forEach((s, i) => {
+                const x = i * step;
+                const y = height - (s[key] / max) * (height - 4) - 2;
+                if (i === 0) ctx.moveTo(x, y); else ctx.lineTo(x, y);
+            });
+            ctx.stroke();
+        });
     }
 
     updateWeight(state) {
@@ -506,48 +1180,60 @@ class UIController {
         }
     }
 
-    updateElevatorPosition(floor) {
-        if (!this.floorElements[floor]) return;
+    updateElevatorPosition(carEl, level) {
+        // level may be fractional while the car travels between floors
+        const lower = Math.floor(level);
+        if (!this.floorElements[lower]) return;
 
-        const floorEl = this.floorElements[floor];
         const buildingRect = this.building.getBoundingClientRect();
-        const floorRect = floorEl.getBoundingClientRect();
+        const floorTop = (floor) => this.floorElements[floor].getBoundingClientRect().top - buildingRect.top + 2;
 
-        const top = floorRect.top - buildingRect.top + 2;
-        this.elevatorCar.style.top = `${top}px`;
+        let top = floorTop(lower);
+        const fraction = level - lower;
+        if (fraction > 0 && this.floorElements[lower + 1]) {
+            top += (floorTop(lower + 1) - top) * fraction;
+        }
+        carEl.style.top = `${top}px`;
     }
 
-    updateElevatorDoors(state) {
-        this.elevatorCar.classList.remove('door-open', 'door-opening', 'door-closing');
+    updateElevatorDoors(carEl, state) {
+        carEl.classList.remove('door-open', 'door-opening', 'door-closing');
 
         if (state === DoorState.OPEN) {
-            this.elevatorCar.classList.add('door-open');
+            carEl.classList.add('door-open');
         } else if (state === DoorState.OPENING) {
-            this.elevatorCar.classList.add('door-opening');
+            carEl.classList.add('door-opening');
         } else if (state === DoorState.CLOSING) {
-            this.elevatorCar.classList.add('door-closing');
+            carEl.classList.add('door-closing');
         }
     }
 
-    updateFloorIndicators(state) {
-        const callFloors = new Set(state.callFloors || []);
-        const currentFloor = state.floor;
+    updateFloorIndicators(state, car) {
+        const hallUp = new Set(state.hallUpCalls || []);
+        const hallDown = new Set(state.hallDownCalls || []);
+        const currentFloor = car.floor;
 
         for (const [floor, el] of Object.entries(this.floorElements)) {
+            const f = parseInt(floor);
             el.classList.remove('active', 'called');
 
-            if (parseInt(floor) === currentFloor) {
+            if (f === currentFloor) {
                 el.classList.add('active');
             }
-            if (callFloors.has(parseInt(floor))) {
+            if (hallUp.has(f) || hallDown.has(f)) {
                 el.classList.add('called');
             }
+
+            el.querySelectorAll('.btn-hall').forEach(btn => {
+                const calls = btn.dataset.callType === CallType.HALL_UP ? hallUp : hallDown;
+                btn.classList.toggle('called', calls.has(f));
+            });
         }
     }
 
-    updateFloorButtons(state) {
-        const callFloors = new Set(state.callFloors || []);
-        const currentFloor = state.floor;
+    updateFloorButtons(car) {
+        const callFloors = new Set(car.carCalls || []);
+        const currentFloor = car.floor;
 
         for (const [floor, btn] of Object.entries(this.floorButtonElements)) {
             btn.classList.remove('called', 'current');
@@ -561,11 +1247,11 @@ class UIController {
         }
     }
 
-    addLog(message, type = 'info') {
+    addLog(message, type = 'info', timestamp = null) {
         const entry = document.createElement('div');
         entry.className = `log-entry log-${type}`;
 
-        const time = new Date().toLocaleTimeString('ko-KR', {
+        const time = timestamp || new Date().toLocaleTimeString('ko-KR', {
             hour: '2-digit',
             minute: '2-digit',
             second: '2-digit'
@@ -590,5 +1276,6 @@ class UIController {
 // Initialize
 // ========================================
 document.addEventListener('DOMContentLoaded', () => {
-    new UIController();
+    const ui = new UIController();
+    ui.refreshJournals();
 });
@@ -0,0 +1,142 @@
+// Package batch runs building traffic simulations headless, faster than real time.
+// 이 패키지는 건물·교통 설정으로 시뮬레이션을 화면 없이 고속 실행하고 결과(승객별 기록, 카별 통계, 에너지, 요약)를 만듭니다.
+//
+// A Config describes the building (as in a scenario file), the passenger
+// traffic and the energy model. Run drives the cars and the traffic
+// generator on a discrete-event simulation, so an hour of traffic takes a
+// fraction of a second, and the same seed always gives the same Result.
+package batch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"go-elevator-simulator/pkg/scenario"
+	"go-elevator-simulator/pkg/traffic"
+)
+
+// Traffic profiles.
+const (
+	ProfileConstant  = "constant"   // 하루 종일 같은 도착률과 패턴
+	ProfileOfficeDay = "office-day" // 출근·점심·퇴근이 있는 사무실 하루 (Rate는 출근 최대 도착률)
+)
+
+// Config is a batch simulation.
+// Config는 배치 시뮬레이션 설정입니다.
+type Config struct {
+	Name     string            `json:"name,omitempty"`
+	Start    time.Time         `json:"start,omitempty"` // 가상 시작 시각 (기본 scenario.DefaultStart)
+	Duration scenario.Duration `json:"duration"`        // 실행 시간
+	Seed     int64             `json:"seed,omitempty"`  // 교통 생성 난수 시드
+	Building scenario.Building `json:"building"`
+	Traffic  Traffic           `json:"traffic"`
+	Energy   Energy            `json:"energy,omitempty"`
+}
+
+// Traffic is the passenger traffic model; see traffic.Config.
+type Traffic struct {
+	Lobby        *int            `json:"lobby,omitempty"`   // 로비 층 (기본 최저 층)
+	Profile      string          `json:"profile,omitempty"` // constant(기본), office-day
+	Pattern      traffic.Pattern `json:"pattern,omitempty"` // constant 프로파일의 교통 패턴
+	Rate         float64         `json:"rate,omitempty"`    // 건물 전체 도착률 (명/분)
+	Periods      []Period        `json:"periods,omitempty"` // 시간대별 도착률 (지정하면 Profile 무시)
+	FloorWeights map[int]float64 `json:"floorWeights,omitempty"`
+	MassMean     float64         `json:"massMean,omitempty"`
+	MassStdDev   float64         `json:"massStdDev,omitempty"`
+}
+
+// Period is a time-of-day window with a constant arrival rate.
+type Period struct {
+	Start   scenario.Duration `json:"start"` // 자정 기준
+	End     scenario.Duration `json:"end"`
+	Rate    float64           `json:"rate"` // 명/분
+	Pattern traffic.Pattern   `json:"pattern"`
+}
+
+// Load reads a batch configuration file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse decodes and validates a JSON configuration. Unknown fields are
+// rejected.
+func Parse(data []byte) (*Config, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate checks the run length, the building and the traffic model.
+func (c *Config) Validate() error {
+	if c.Duration <= 0 {
+		return fmt.Errorf("invalid config: duration must be positive")
+	}
+	if _, err := c.Building.GroupConfig("SIM"); err != nil {
+		return fmt.Errorf("invalid building: %w", err)
+	}
+	if _, err := c.trafficConfig(); err != nil {
+		return err
+	}
+	return c.Energy.validate()
+}
+
+// trafficConfig converts the traffic model for the building.
+func (c *Config) trafficConfig() (traffic.Config, error) {
+	t := c.Traffic
+	lobby := c.Building.Car.MinFloor
+	if t.Lobby != nil {
+		lobby = *t.Lobby
+	}
+
+	var profile []traffic.Period
+	switch {
+	case len(t.Periods) > 0:
+		for _, p := range t.Periods {
+			profile = append(profile, traffic.Period{
+				Start: time.Duration(p.Start), End: time.Duration(p.End), Rate: p.Rate, Pattern: p.Pattern,
+			})
+		}
+	case t.Profile == "" || t.Profile == ProfileConstant:
+		if t.Pattern == "" {
+			return traffic.Config{}, fmt.Errorf("invalid traffic: pattern is required for a constant profile")
+		}
+		profile = traffic.Constant(t.Rate, t.Pattern)
+	case t.Profile == ProfileOfficeDay:
+		profile = traffic.OfficeDay(t.Rate)
+	default:
+		return traffic.Config{}, fmt.Errorf("invalid traffic: unknown profile %q", t.Profile)
+	}
+
+	cfg := traffic.Config{
+		MinFloor:     c.Building.Car.MinFloor,
+		MaxFloor:     c.Building.Car.MaxFloor,
+		Lobby:        lobby,
+		FloorWeights: t.FloorWeights,
+		Profile:      profile,
+		MassMean:     t.MassMean,
+		MassStdDev:   t.MassStdDev,
+		Seed:         c.Seed,
+	}
+	if _, err := traffic.New(cfg); err != nil {
+		return traffic.Config{}, err
+	}
+	return cfg, nil
+}
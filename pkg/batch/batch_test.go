@@ -0,0 +1,180 @@
+package batch
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+	"go-elevator-simulator/pkg/stats"
+)
+
+var quiet = elevator.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+const testConfig = `{
+  "duration": "30m",
+  "seed": 7,
+  "building": {
+    "cars": 2,
+    "car": {"minFloor": 1, "maxFloor": 8, "initialFloor": 1, "travelTime": "1.5s",
+            "doorSpeed": "1s", "doorOpenTime": "3s", "maxWeight": 800}
+  },
+  "traffic": {"pattern": "lunch", "rate": 4}
+}`
+
+func TestParse_Invalid(t *testing.T) {
+	building := `"building": {"car": {"minFloor": 1, "maxFloor": 8, "initialFloor": 1, "travelTime": 1, "doorSpeed": 1, "doorOpenTime": 3}}`
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"unknown field", `{"duration": "1h", "seeds": 1}`, "unknown field"},
+		{"no duration", `{` + building + `, "traffic": {"pattern": "lunch", "rate": 1}}`, "duration must be positive"},
+		{"unknown strategy", `{"duration": "1h", "building": {"car": {"minFloor": 1, "maxFloor": 8, "strategy": "random"}}}`, "unknown scheduler"},
+		{"no pattern", `{"duration": "1h", ` + building + `, "traffic": {"rate": 1}}`, "pattern is required"},
+		{"unknown profile", `{"duration": "1h", ` + building + `, "traffic": {"profile": "weekend"}}`, "unknown profile"},
+		{"lobby out of range", `{"duration": "1h", ` + building + `, "traffic": {"lobby": 20, "pattern": "up-peak"}}`, "lobby 20 out of range"},
+		{"bad efficiency", `{"duration": "1h", ` + building + `, "traffic": {"pattern": "up-peak"}, "energy": {"efficiency": 2}}`, "efficiency"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.json))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	cfg, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	res, err := Run(context.Background(), cfg, quiet)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	s := res.Summary
+
+	if s.Generated == 0 || s.Delivered == 0 {
+		t.Fatalf("Generated %d, delivered %d passengers; want traffic", s.Generated, s.Delivered)
+	}
+	if n := s.Rejected + s.Waiting + s.Riding + s.Delivered + s.Evacuated; n != s.Generated {
+		t.Errorf("passenger states add up to %d, want %d", n, s.Generated)
+	}
+	delivered := 0
+	var energy float64
+	for _, c := range res.Cars {
+		delivered += c.Delivered
+		energy += c.Energy.Total
+		if c.Distance <= 0 || c.Energy.Travel <= 0 || c.DoorCycles == 0 {
+			t.Errorf("car %d did no work: %+v", c.Car, c)
+		}
+	}
+	if delivered != s.Delivered {
+		t.Errorf("cars delivered %d passengers, summary %d", delivered, s.Delivered)
+	}
+	if diff := energy - s.Energy.Total; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("car energy %.6f kWh, summary %.6f kWh", energy, s.Energy.Total)
+	}
+	if want := 2 * DefaultStandbyPower * 1800 / joulesPerKWh; s.Energy.Standby != want {
+		t.Errorf("standby energy = %v kWh, want %v", s.Energy.Standby, want)
+	}
+	for _, p := range res.Passengers {
+		if p.Status == StatusDelivered && (p.Wait == nil || p.Journey == nil || *p.Journey < *p.Wait) {
+			t.Errorf("inconsistent record %+v", p)
+		}
+	}
+
+	again, err := Run(context.Background(), cfg, quiet)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !reflect.DeepEqual(again, res) {
+		t.Error("two runs with the same seed differ")
+	}
+}
+
+func TestPassengerBook_Evacuated(t *testing.T) {
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return start.Add(time.Duration(s) * time.Second) }
+	delivered := elevator.Passenger{ID: "p1", Origin: 1, Destination: 5, ArrivedAt: at(0), BoardedAt: at(10), AlightedAt: at(30)}
+	evacuated := elevator.Passenger{ID: "p2", Origin: 1, Destination: 8, ArrivedAt: at(0), BoardedAt: at(10), AlightedAt: at(20)}
+
+	book := newPassengerBook(start)
+	collector := stats.NewCollector(stats.Config{})
+	for _, ev := range []elevator.Event{
+		passengerEvent(elevator.EventPassengerWaiting, delivered),
+		passengerEvent(elevator.EventPassengerWaiting, evacuated),
+		passengerEvent(elevator.EventPassengerBoarded, delivered),
+		passengerEvent(elevator.EventPassengerBoarded, evacuated),
+		passengerEvent(elevator.EventPassengerEvacuated, evacuated),
+		passengerEvent(elevator.EventPassengerAlighted, delivered),
+	} {
+		book.observe(0, ev)
+		collector.Observe(0, ev)
+	}
+
+	records := book.records()
+	if p := records[1]; p.Status != StatusEvacuated || p.Alighted == nil || *p.Alighted != 20 || p.Journey != nil {
+		t.Errorf("evacuated passenger record %+v", p)
+	}
+	s := summarize(&Config{}, collector.Report(at(60)), records)
+	if s.Delivered != 1 || s.Evacuated != 1 || s.Riding != 0 || s.AvgJourney != 30 {
+		t.Errorf("summary: delivered %d, evacuated %d, riding %d, avg journey %v; want 1, 1, 0, 30",
+			s.Delivered, s.Evacuated, s.Riding, s.AvgJourney)
+	}
+}
+
+func passengerEvent(t elevator.EventType, p elevator.Passenger) elevator.Event {
+	return elevator.Event{Type: t, Payload: elevator.PassengerPayload{Passenger: p}}
+}
+
+func TestResult_WriteCSV(t *testing.T) {
+	cfg, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	res, err := Run(context.Background(), cfg, quiet)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	dir := t.TempDir()
+	if err := res.WriteCSV(dir); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+
+	tests := []struct {
+		file string
+		rows int
+	}{
+		{SummaryFile, 2},
+		{CarsFile, 1 + len(res.Cars)},
+		{PassengersFile, 1 + len(res.Passengers)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			f, err := os.Open(filepath.Join(dir, tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			records, err := csv.NewReader(f).ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll: %v", err)
+			}
+			if len(records) != tt.rows {
+				t.Errorf("%d rows, want %d", len(records), tt.rows)
+			}
+		})
+	}
+}
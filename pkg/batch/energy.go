@@ -0,0 +1,161 @@
+package batch
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+// Energy model defaults.
+const (
+	DefaultRatedLoad    = 1000  // kg, 카의 MaxWeight가 없을 때
+	DefaultBalance      = 0.5   // 균형추가 상쇄하는 정격 하중 비율
+	DefaultEfficiency   = 0.75  // 구동 효율
+	DefaultFriction     = 1500  // J/m, 마찰·손실
+	DefaultStandbyPower = 200   // W, 대기 전력 (조명·제어반)
+	DefaultDoorCycle    = 300   // J, 문 한 번 열고 닫기
+	gravity             = 9.81  // m/s²
+	joulesPerKWh        = 3.6e6 // J/kWh
+)
+
+// Energy is a simple traction lift energy model. Zero values use the
+// defaults.
+// Energy는 견인식 엘리베이터의 단순 에너지 모델입니다.
+//
+// The counterweight balances the car and Balance of the rated load, so a run
+// costs the potential energy of the load imbalance over the height travelled,
+// divided by the drive efficiency, plus a friction loss per metre. The drive
+// does not regenerate. Each car also draws StandbyPower all the time and
+// DoorCycle for every door opening.
+type Energy struct {
+	Balance      float64 `json:"balance,omitempty"`      // 균형추 비율 (기본 0.5)
+	Efficiency   float64 `json:"efficiency,omitempty"`   // 구동 효율 (기본 0.75)
+	Friction     float64 `json:"friction,omitempty"`     // 마찰·손실 J/m (기본 1500)
+	StandbyPower float64 `json:"standbyPower,omitempty"` // 대기 전력 W (기본 200)
+	DoorCycle    float64 `json:"doorCycle,omitempty"`    // 문 1회 개폐 에너지 J (기본 300)
+}
+
+func (m Energy) validate() error {
+	if m.Balance < 0 || m.Balance > 1 {
+		return fmt.Errorf("invalid energy model: balance %.2f not in [0, 1]", m.Balance)
+	}
+	if m.Efficiency < 0 || m.Efficiency > 1 {
+		return fmt.Errorf("invalid energy model: efficiency %.2f not in (0, 1]", m.Efficiency)
+	}
+	if m.Friction < 0 || m.StandbyPower < 0 || m.DoorCycle < 0 {
+		return fmt.Errorf("invalid energy model: negative friction, standby power or door cycle")
+	}
+	return nil
+}
+
+func (m Energy) withDefaults() Energy {
+	if m.Balance == 0 {
+		m.Balance = DefaultBalance
+	}
+	if m.Efficiency == 0 {
+		m.Efficiency = DefaultEfficiency
+	}
+	if m.Friction == 0 {
+		m.Friction = DefaultFriction
+	}
+	if m.StandbyPower == 0 {
+		m.StandbyPower = DefaultStandbyPower
+	}
+	if m.DoorCycle == 0 {
+		m.DoorCycle = DefaultDoorCycle
+	}
+	return m
+}
+
+// EnergyUse is the energy used by a car or the building, in kWh.
+type EnergyUse struct {
+	Travel  float64 `json:"travel"`
+	Doors   float64 `json:"doors"`
+	Standby float64 `json:"standby"`
+	Total   float64 `json:"total"`
+}
+
+func (u *EnergyUse) add(o EnergyUse) {
+	u.Travel += o.Travel
+	u.Doors += o.Doors
+	u.Standby += o.Standby
+	u.Total += o.Total
+}
+
+// meter follows the events of one car and accumulates its travel and energy.
+type meter struct {
+	model     Energy
+	ratedLoad float64
+	height    float64 // m per floor
+
+	floor      int
+	load       float64 // kg on board
+	floors     int
+	stops      int
+	doorCycles int
+	travel     float64 // J
+}
+
+func newMeter(model Energy, cfg elevator.Config) *meter {
+	m := &meter{model: model.withDefaults(), ratedLoad: float64(cfg.MaxWeight), height: elevator.DefaultFloorHeight, floor: cfg.InitialFloor}
+	if m.ratedLoad <= 0 {
+		m.ratedLoad = DefaultRatedLoad
+	}
+	if cfg.Motion != nil && cfg.Motion.FloorHeight > 0 {
+		m.height = cfg.Motion.FloorHeight
+	}
+	return m
+}
+
+func (m *meter) observe(ev elevator.Event) {
+	switch p := ev.Payload.(type) {
+	case int:
+		if ev.Type != elevator.EventFloorChange {
+			return
+		}
+		n := p - m.floor
+		m.floor = p
+		if n == 0 {
+			return
+		}
+		h := math.Abs(float64(n)) * m.height
+		m.floors += int(math.Abs(float64(n)))
+		// Positive imbalance: the car side is heavier and costs energy going up.
+		imbalance := m.load - m.model.Balance*m.ratedLoad
+		if n < 0 {
+			imbalance = -imbalance
+		}
+		m.travel += math.Max(imbalance, 0)*gravity*h/m.model.Efficiency + m.model.Friction*h
+	case elevator.ArrivedPayload:
+		m.stops++
+	case elevator.DoorChangePayload:
+		if p.State == elevator.DoorOpening {
+			m.doorCycles++
+		}
+	case elevator.PassengerPayload:
+		switch ev.Type {
+		case elevator.EventPassengerBoarded:
+			m.load += float64(p.Passenger.Mass)
+		case elevator.EventPassengerAlighted, elevator.EventPassengerEvacuated:
+			m.load = math.Max(m.load-float64(p.Passenger.Mass), 0)
+		}
+	}
+}
+
+// use returns the energy used over a run of length d.
+func (m *meter) use(d time.Duration) EnergyUse {
+	u := EnergyUse{
+		Travel:  m.travel / joulesPerKWh,
+		Doors:   float64(m.doorCycles) * m.model.DoorCycle / joulesPerKWh,
+		Standby: m.model.StandbyPower * d.Seconds() / joulesPerKWh,
+	}
+	u.Total = u.Travel + u.Doors + u.Standby
+	return u
+}
+
+// distance returns the height travelled in m.
+func (m *meter) distance() float64 {
+	return float64(m.floors) * m.height
+}
@@ -0,0 +1,141 @@
+package batch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// CSV files written by WriteCSV.
+const (
+	SummaryFile    = "summary.csv"
+	CarsFile       = "cars.csv"
+	PassengersFile = "passengers.csv"
+)
+
+// WriteJSON writes the result as indented JSON.
+func (r *Result) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes the summary, the car statistics and the passenger records
+// as three CSV files in dir, creating it if needed.
+func (r *Result) WriteCSV(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	files := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{SummaryFile, r.WriteSummaryCSV},
+		{CarsFile, r.WriteCarsCSV},
+		{PassengersFile, r.WritePassengersCSV},
+	}
+	for _, f := range files {
+		if err := writeFile(filepath.Join(dir, f.name), f.write); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// SummaryHeader is the header of the summary CSV.
+var SummaryHeader = []string{
+	"name", "seed", "duration", "cars", "strategy",
+	"generated", "rejected", "waiting", "riding", "delivered", "evacuated",
+	"avg_wait", "max_wait", "wait_p50", "wait_p90", "wait_p95",
+	"avg_ride", "avg_journey", "journey_p50", "journey_p90", "journey_p95",
+	"long_waits", "stops_per_trip", "handling_capacity",
+	"energy_travel_kwh", "energy_doors_kwh", "energy_standby_kwh", "energy_kwh", "wh_per_passenger",
+}
+
+// Row returns the summary as a CSV row matching SummaryHeader.
+func (s Summary) Row() []string {
+	return []string{
+		s.Name, strconv.FormatInt(s.Seed, 10), ftoa(s.Duration), itoa(s.Cars), s.Strategy,
+		itoa(s.Generated), itoa(s.Rejected), itoa(s.Waiting), itoa(s.Riding), itoa(s.Delivered), itoa(s.Evacuated),
+		ftoa(s.AvgWait), ftoa(s.MaxWait), ftoa(s.WaitP50), ftoa(s.WaitP90), ftoa(s.WaitP95),
+		ftoa(s.AvgRide), ftoa(s.AvgJourney), ftoa(s.JourneyP50), ftoa(s.JourneyP90), ftoa(s.JourneyP95),
+		itoa(s.LongWaits), ftoa(s.StopsPerTrip), itoa(s.HandlingCapacity),
+		ftoa(s.Energy.Travel), ftoa(s.Energy.Doors), ftoa(s.Energy.Standby), ftoa(s.Energy.Total), ftoa(s.WhPerPassenger),
+	}
+}
+
+// WriteSummaryCSV writes the summary as a header and one row.
+func (r *Result) WriteSummaryCSV(w io.Writer) error {
+	return writeCSV(w, SummaryHeader, [][]string{r.Summary.Row()})
+}
+
+// WriteCarsCSV writes one row per car.
+func (r *Result) WriteCarsCSV(w io.Writer) error {
+	header := []string{"car", "id", "boarded", "delivered", "stops", "floors", "distance_m", "door_cycles",
+		"energy_travel_kwh", "energy_doors_kwh", "energy_standby_kwh", "energy_kwh"}
+	rows := make([][]string, 0, len(r.Cars))
+	for _, c := range r.Cars {
+		rows = append(rows, []string{
+			itoa(c.Car), c.ID, itoa(c.Boarded), itoa(c.Delivered), itoa(c.Stops), itoa(c.Floors), ftoa(c.Distance), itoa(c.DoorCycles),
+			ftoa(c.Energy.Travel), ftoa(c.Energy.Doors), ftoa(c.Energy.Standby), ftoa(c.Energy.Total),
+		})
+	}
+	return writeCSV(w, header, rows)
+}
+
+// WritePassengersCSV writes one row per passenger. Times not reached are
+// left empty.
+func (r *Result) WritePassengersCSV(w io.Writer) error {
+	header := []string{"id", "origin", "destination", "mass", "car", "status",
+		"arrived", "boarded", "alighted", "wait", "ride", "journey"}
+	rows := make([][]string, 0, len(r.Passengers))
+	for _, p := range r.Passengers {
+		rows = append(rows, []string{
+			p.ID, itoa(p.Origin), itoa(p.Destination), itoa(p.Mass), itoa(p.Car), p.Status,
+			ftoa(p.Arrived), optional(p.Boarded), optional(p.Alighted), optional(p.Wait), optional(p.Ride), optional(p.Journey),
+		})
+	}
+	return writeCSV(w, header, rows)
+}
+
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
+
+func ftoa(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func optional(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return ftoa(*f)
+}
@@ -0,0 +1,286 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+	"go-elevator-simulator/pkg/scenario"
+	"go-elevator-simulator/pkg/stats"
+	"go-elevator-simulator/pkg/traffic"
+)
+
+// Passenger states at the end of a run.
+const (
+	StatusDelivered = "delivered"
+	StatusEvacuated = "evacuated" // 소방 귀환·구출 층에서 목적 층 전에 내림
+	StatusRiding    = "riding"
+	StatusWaiting   = "waiting"
+	StatusRejected  = "rejected" // 군관리가 받지 않은 승객 (응답할 카 없음 등)
+)
+
+// Result is the outcome of a batch run.
+// Result는 배치 실행 결과입니다.
+type Result struct {
+	Summary    Summary     `json:"summary"`
+	Cars       []CarStats  `json:"cars"`
+	Passengers []Passenger `json:"passengers"`
+}
+
+// Summary sums up a run. Times are in seconds.
+type Summary struct {
+	Name     string  `json:"name,omitempty"`
+	Seed     int64   `json:"seed"`
+	Duration float64 `json:"duration"`
+	Cars     int     `json:"cars"`
+	Strategy string  `json:"strategy"`
+
+	Generated int `json:"generated"` // 생성된 승객 수
+	Rejected  int `json:"rejected"`
+	Waiting   int `json:"waiting"` // 종료 시 대기 중
+	Riding    int `json:"riding"`  // 종료 시 탑승 중
+	Delivered int `json:"delivered"`
+	Evacuated int `json:"evacuated"` // 목적 층 전에 대피 (Delivered와 시간 통계에서 제외)
+
+	AvgWait    float64 `json:"avgWait"`
+	MaxWait    float64 `json:"maxWait"`
+	WaitP50    float64 `json:"waitP50"`
+	WaitP90    float64 `json:"waitP90"`
+	WaitP95    float64 `json:"waitP95"`
+	AvgRide    float64 `json:"avgRide"`
+	AvgJourney float64 `json:"avgJourney"`
+	JourneyP50 float64 `json:"journeyP50"`
+	JourneyP90 float64 `json:"journeyP90"`
+	JourneyP95 float64 `json:"journeyP95"`
+
+	LongWaits        int     `json:"longWaits"` // 60초 넘게 기다린 승객 (대기 중 포함)
+	StopsPerTrip     float64 `json:"stopsPerTrip"`
+	HandlingCapacity int     `json:"handlingCapacity"` // 가장 붐빈 5분 동안 수송한 승객 수
+
+	Energy         EnergyUse `json:"energy"`
+	WhPerPassenger float64   `json:"whPerPassenger"` // 수송 승객 1명당 에너지 Wh
+}
+
+// CarStats is the work of one car over a run.
+type CarStats struct {
+	Car        int       `json:"car"`
+	ID         string    `json:"id"`
+	Boarded    int       `json:"boarded"`
+	Delivered  int       `json:"delivered"`
+	Stops      int       `json:"stops"`
+	Floors     int       `json:"floors"`   // 이동한 층 수
+	Distance   float64   `json:"distance"` // 이동 거리 m
+	DoorCycles int       `json:"doorCycles"`
+	Energy     EnergyUse `json:"energy"`
+}
+
+// Passenger is the record of one generated passenger. Times are seconds
+// after the start of the run; those not reached are nil.
+type Passenger struct {
+	ID          string   `json:"id"`
+	Origin      int      `json:"origin"`
+	Destination int      `json:"destination"`
+	Mass        int      `json:"mass"`
+	Car         int      `json:"car"` // 탑승한 카 (-1: 탑승 전)
+	Status      string   `json:"status"`
+	Arrived     float64  `json:"arrived"`
+	Boarded     *float64 `json:"boarded,omitempty"`
+	Alighted    *float64 `json:"alighted,omitempty"`
+	Wait        *float64 `json:"wait,omitempty"`
+	Ride        *float64 `json:"ride,omitempty"`
+	Journey     *float64 `json:"journey,omitempty"`
+}
+
+// Run simulates cfg and returns its result. opts are applied to every car,
+// e.g. elevator.WithLogger.
+func Run(ctx context.Context, cfg *Config, opts ...elevator.Option) (*Result, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	groupCfg, err := cfg.Building.GroupConfig("SIM")
+	if err != nil {
+		return nil, err
+	}
+	trafficCfg, err := cfg.trafficConfig()
+	if err != nil {
+		return nil, err
+	}
+	gen, err := traffic.New(trafficCfg)
+	if err != nil {
+		return nil, err
+	}
+	start := cfg.Start
+	if start.IsZero() {
+		start = scenario.DefaultStart
+	}
+	duration := time.Duration(cfg.Duration)
+
+	sim := elevator.NewSimulation(start)
+	defer sim.Close()
+	g, err := sim.NewGroup(groupCfg, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create elevator group: %w", err)
+	}
+
+	collector := stats.NewCollector(stats.Config{})
+	collector.AttachGroup(g)
+	meters := make([]*meter, len(g.Cars()))
+	for i, car := range g.Cars() {
+		meters[i] = newMeter(cfg.Energy, car.Config)
+	}
+	book := newPassengerBook(start)
+	g.OnEvent(func(ev elevator.GroupEvent) {
+		meters[ev.Car].observe(ev.Event)
+		book.observe(ev.Car, ev.Event)
+	})
+	feed := gen.Attach(sim.Clock(), traffic.GroupSink(g), func(p elevator.Passenger, err error) {
+		book.reject(p)
+	})
+	defer feed.Stop()
+
+	if err := sim.RunFor(ctx, duration); err != nil {
+		return nil, err
+	}
+
+	res := &Result{Passengers: book.records()}
+	res.Summary = summarize(cfg, collector.Report(sim.Now()), res.Passengers)
+	res.Summary.Strategy = groupCfg.Car.Scheduler.Name()
+	res.Summary.Duration = duration.Seconds()
+	res.Summary.Cars = len(g.Cars())
+	for i, car := range g.Cars() {
+		m := meters[i]
+		cs := CarStats{
+			Car:        i,
+			ID:         car.Config.ID,
+			Stops:      m.stops,
+			Floors:     m.floors,
+			Distance:   m.distance(),
+			DoorCycles: m.doorCycles,
+			Energy:     m.use(duration),
+		}
+		for _, p := range res.Passengers {
+			if p.Car != i {
+				continue
+			}
+			cs.Boarded++
+			if p.Status == StatusDelivered {
+				cs.Delivered++
+			}
+		}
+		res.Summary.Energy.add(cs.Energy)
+		res.Cars = append(res.Cars, cs)
+	}
+	if res.Summary.Delivered > 0 {
+		res.Summary.WhPerPassenger = res.Summary.Energy.Total * 1000 / float64(res.Summary.Delivered)
+	}
+	return res, nil
+}
+
+// summarize fills the passenger figures of the summary.
+func summarize(cfg *Config, r stats.Report, passengers []Passenger) Summary {
+	s := Summary{
+		Name:             cfg.Name,
+		Seed:             cfg.Seed,
+		Generated:        len(passengers),
+		AvgWait:          r.AvgWait.Seconds(),
+		MaxWait:          r.MaxWait.Seconds(),
+		AvgRide:          r.AvgRide.Seconds(),
+		AvgJourney:       r.AvgJourney.Seconds(),
+		JourneyP50:       r.JourneyP50.Seconds(),
+		JourneyP90:       r.JourneyP90.Seconds(),
+		JourneyP95:       r.JourneyP95.Seconds(),
+		LongWaits:        r.LongWaits,
+		StopsPerTrip:     r.StopsPerTrip,
+		HandlingCapacity: r.HandlingCapacity,
+	}
+	var waits []time.Duration
+	for _, p := range passengers {
+		switch p.Status {
+		case StatusRejected:
+			s.Rejected++
+		case StatusWaiting:
+			s.Waiting++
+		case StatusRiding:
+			s.Riding++
+		case StatusDelivered:
+			s.Delivered++
+		case StatusEvacuated:
+			s.Evacuated++
+		}
+		if p.Wait != nil {
+			waits = append(waits, seconds(*p.Wait))
+		}
+	}
+	sort.Slice(waits, func(i, j int) bool { return waits[i] < waits[j] })
+	s.WaitP50 = stats.Percentile(waits, 50).Seconds()
+	s.WaitP90 = stats.Percentile(waits, 90).Seconds()
+	s.WaitP95 = stats.Percentile(waits, 95).Seconds()
+	return s
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// passengerBook keeps the record of every passenger from its events.
+type passengerBook struct {
+	start time.Time
+	order []string
+	byID  map[string]*Passenger
+}
+
+func newPassengerBook(start time.Time) *passengerBook {
+	return &passengerBook{start: start, byID: make(map[string]*Passenger)}
+}
+
+func (b *passengerBook) since(t time.Time) float64 {
+	return t.Sub(b.start).Seconds()
+}
+
+func (b *passengerBook) record(p elevator.Passenger) *Passenger {
+	r, ok := b.byID[p.ID]
+	if !ok {
+		r = &Passenger{
+			ID: p.ID, Origin: p.Origin, Destination: p.Destination, Mass: p.Mass,
+			Car: -1, Status: StatusWaiting, Arrived: b.since(p.ArrivedAt),
+		}
+		b.byID[p.ID] = r
+		b.order = append(b.order, p.ID)
+	}
+	return r
+}
+
+func (b *passengerBook) observe(car int, ev elevator.Event) {
+	pp, ok := ev.Payload.(elevator.PassengerPayload)
+	if !ok {
+		return
+	}
+	p := pp.Passenger
+	r := b.record(p)
+	switch ev.Type {
+	case elevator.EventPassengerBoarded:
+		boarded, wait := b.since(p.BoardedAt), p.WaitTime().Seconds()
+		r.Car, r.Status, r.Boarded, r.Wait = car, StatusRiding, &boarded, &wait
+	case elevator.EventPassengerAlighted:
+		alighted, ride, journey := b.since(p.AlightedAt), p.RideTime().Seconds(), p.JourneyTime().Seconds()
+		r.Status, r.Alighted, r.Ride, r.Journey = StatusDelivered, &alighted, &ride, &journey
+	case elevator.EventPassengerEvacuated:
+		alighted := b.since(p.AlightedAt)
+		r.Status, r.Alighted = StatusEvacuated, &alighted
+	}
+}
+
+func (b *passengerBook) reject(p elevator.Passenger) {
+	b.record(p).Status = StatusRejected
+}
+
+// records returns the passengers in order of arrival.
+func (b *passengerBook) records() []Passenger {
+	out := make([]Passenger, 0, len(b.order))
+	for _, id := range b.order {
+		out = append(out, *b.byID[id])
+	}
+	return out
+}
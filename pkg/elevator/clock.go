@@ -0,0 +1,177 @@
+package elevator
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Clock abstracts the passage of time for the engine.
+// Clock은 엔진이 사용하는 시간 흐름을 추상화합니다.
+//
+// AfterFunc schedules f to run once after d. Implementations may run f on any
+// goroutine; the engine serialises its callbacks with its own mutex.
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a pending AfterFunc callback.
+type Timer interface {
+	// Stop prevents the callback from running. It reports whether the call
+	// stopped the timer, false if it had already fired or been stopped.
+	Stop() bool
+}
+
+// RealClock is a Clock backed by the wall clock.
+// RealClock은 실제 시간을 사용하는 Clock입니다.
+type RealClock struct{}
+
+func (RealClock) Now() time.Time { return time.Now() }
+
+func (RealClock) AfterFunc(d time.Duration, f func()) Timer { return time.AfterFunc(d, f) }
+
+// ManualClock is a Clock that only moves when told to.
+// ManualClock은 명시적으로 진행시킬 때만 시간이 흐르는 가상 시계입니다.
+//
+// Callbacks run synchronously inside Advance, in deadline order (registration
+// order on ties), with Now reporting each callback's deadline. This makes runs
+// fully reproducible.
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	seq    uint64
+	timers manualTimers
+}
+
+// NewManualClock creates a ManualClock starting at start.
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d < 0 {
+		d = 0
+	}
+	c.seq++
+	t := &manualTimer{clock: c, when: c.now.Add(d), seq: c.seq, f: f}
+	heap.Push(&c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d, running every callback that falls due.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.AdvanceTo(c.Now().Add(d))
+}
+
+// AdvanceTo moves the clock forward to t, running every callback that falls due.
+// Callbacks scheduled by other callbacks are run as well if they fall due before t.
+func (c *ManualClock) AdvanceTo(t time.Time) {
+	for c.Step(t) {
+	}
+	c.set(t)
+}
+
+// set moves the clock to t without running callbacks; it never goes backwards.
+func (c *ManualClock) set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.After(c.now) {
+		c.now = t
+	}
+}
+
+// Next returns the deadline of the earliest scheduled callback.
+func (c *ManualClock) Next() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.timers) == 0 {
+		return time.Time{}, false
+	}
+	return c.timers[0].when, true
+}
+
+// Step jumps straight to the earliest scheduled callback and runs it, provided
+// it falls due no later than limit. It reports whether a callback ran.
+func (c *ManualClock) Step(limit time.Time) bool {
+	c.mu.Lock()
+	if len(c.timers) == 0 || c.timers[0].when.After(limit) {
+		c.mu.Unlock()
+		return false
+	}
+	next := heap.Pop(&c.timers).(*manualTimer)
+	if next.when.After(c.now) {
+		c.now = next.when
+	}
+	c.mu.Unlock()
+
+	next.f()
+	return true
+}
+
+// Pending returns the number of scheduled callbacks.
+func (c *ManualClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+type manualTimer struct {
+	clock *ManualClock
+	when  time.Time
+	seq   uint64
+	f     func()
+	index int // heap index, -1 once fired or stopped
+}
+
+func (t *manualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	if t.index < 0 {
+		return false
+	}
+	heap.Remove(&t.clock.timers, t.index)
+	return true
+}
+
+// manualTimers is a min-heap of timers ordered by deadline, then registration.
+type manualTimers []*manualTimer
+
+func (h manualTimers) Len() int { return len(h) }
+
+func (h manualTimers) Less(i, j int) bool {
+	if h[i].when.Equal(h[j].when) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].when.Before(h[j].when)
+}
+
+func (h manualTimers) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *manualTimers) Push(x any) {
+	t := x.(*manualTimer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *manualTimers) Pop() any {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	t.index = -1
+	*h = old[:n-1]
+	return t
+}
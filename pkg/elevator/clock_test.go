@@ -0,0 +1,165 @@
+package elevator
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+var testEpoch = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+func TestManualClock_Advance(t *testing.T) {
+	clock := NewManualClock(testEpoch)
+
+	var fired []string
+	clock.AfterFunc(2*time.Second, func() { fired = append(fired, "b") })
+	clock.AfterFunc(1*time.Second, func() {
+		fired = append(fired, "a")
+		// Scheduled from a callback and due before the target: runs too.
+		clock.AfterFunc(500*time.Millisecond, func() { fired = append(fired, "a2") })
+	})
+	stopped := clock.AfterFunc(1500*time.Millisecond, func() { fired = append(fired, "never") })
+	if !stopped.Stop() {
+		t.Error("Expected Stop to report a pending timer")
+	}
+
+	clock.Advance(1 * time.Second)
+	if len(fired) != 1 || fired[0] != "a" {
+		t.Fatalf("After 1s fired %v, want [a]", fired)
+	}
+
+	clock.Advance(5 * time.Second)
+	want := []string{"a", "a2", "b"}
+	if len(fired) != len(want) {
+		t.Fatalf("Fired %v, want %v", fired, want)
+	}
+	for i := range want {
+		if fired[i] != want[i] {
+			t.Errorf("Fired %v, want %v", fired, want)
+			break
+		}
+	}
+	if got := clock.Now(); !got.Equal(testEpoch.Add(6 * time.Second)) {
+		t.Errorf("Now() = %v, want %v", got, testEpoch.Add(6*time.Second))
+	}
+}
+
+func TestElevator_DeterministicDoorCycle(t *testing.T) {
+	clock := NewManualClock(testEpoch)
+	e, err := New(Config{
+		ID:           "T",
+		MinFloor:     1,
+		MaxFloor:     5,
+		InitialFloor: 1,
+		TravelTime:   time.Second,
+		DoorSpeed:    time.Second,
+		DoorOpenTime: 3 * time.Second,
+	}, WithClock(clock))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	e.AddCall(3, CallCar)
+	e.start()
+	defer e.stop()
+
+	clock.Advance(10 * time.Second)
+
+	type step struct {
+		at      time.Duration
+		typ     EventType
+		payload interface{}
+	}
+	want := []step{
+		{100 * time.Millisecond, EventDirectionChange, DirUp},
+		{1100 * time.Millisecond, EventFloorChange, 2},
+		{2100 * time.Millisecond, EventFloorChange, 3},
+		{2100 * time.Millisecond, EventDoorChange, DoorChangePayload{Side: Front, State: DoorOpening}},
+		{2100 * time.Millisecond, EventArrived, ArrivedPayload{Floor: 3, OpenDoorSide: Front}},
+		{3100 * time.Millisecond, EventDoorChange, DoorChangePayload{Side: Front, State: DoorOpen}},
+		{6100 * time.Millisecond, EventDoorChange, DoorChangePayload{Side: Front, State: DoorClosing}},
+		{7100 * time.Millisecond, EventDoorChange, DoorChangePayload{Side: Front, State: DoorClose}},
+		{7100 * time.Millisecond, EventDirectionChange, DirNone},
+	}
+
+	// Position samples while travelling are covered by the motion tests.
+	next := func() (Event, bool) {
+		for {
+			select {
+			case ev := <-e.Events():
+				if ev.Type != EventPosition {
+					return ev, true
+				}
+			default:
+				return Event{}, false
+			}
+		}
+	}
+
+	for i, w := range want {
+		if ev, ok := next(); ok {
+			if ev.Type != w.typ || ev.Payload != w.payload || !ev.Timestamp.Equal(testEpoch.Add(w.at)) {
+				t.Errorf("event %d = %s %v @%v, want %s %v @%v",
+					i, ev.Type, ev.Payload, ev.Timestamp.Sub(testEpoch), w.typ, w.payload, w.at)
+			}
+		} else {
+			t.Fatalf("missing event %d: %s %v", i, w.typ, w.payload)
+		}
+	}
+	if ev, ok := next(); ok {
+		t.Errorf("unexpected extra event %s %v", ev.Type, ev.Payload)
+	}
+}
+
+func TestSimulation_FastForwardDay(t *testing.T) {
+	sim := NewSimulation(testEpoch)
+	defer sim.Close()
+
+	quiet := slog.New(slog.NewTextHandler(io.Discard, nil))
+	e, err := sim.NewElevator(Config{
+		ID:           "SIM",
+		MinFloor:     1,
+		MaxFloor:     10,
+		InitialFloor: 1,
+		TravelTime:   2 * time.Second,
+		DoorSpeed:    time.Second,
+		DoorOpenTime: 3 * time.Second,
+	}, WithLogger(quiet))
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+
+	arrivals := 0
+	var last time.Time
+	e.OnEvent(func(ev Event) {
+		if ev.Type == EventArrived {
+			arrivals++
+		}
+		last = ev.Timestamp
+	})
+
+	// One car call every 10 minutes, alternating between the terminals.
+	const calls = 24 * 6
+	for i := 0; i < calls; i++ {
+		floor := 10
+		if i%2 == 1 {
+			floor = 1
+		}
+		sim.At(time.Duration(i)*10*time.Minute, func() { e.AddCall(floor, CallCar) })
+	}
+
+	if err := sim.RunFor(context.Background(), 24*time.Hour); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+
+	if arrivals != calls {
+		t.Errorf("arrivals = %d, want %d", arrivals, calls)
+	}
+	if got := sim.Elapsed(); got != 24*time.Hour {
+		t.Errorf("Elapsed() = %v, want 24h", got)
+	}
+	if last.After(testEpoch.Add(24 * time.Hour)) {
+		t.Errorf("last event at %v is past the horizon", last)
+	}
+}
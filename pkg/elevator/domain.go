@@ -1,8 +1,8 @@
 package elevator
 
 import (
-	"fmt"
 	"math"
+	"slices"
 	"sort"
 )
 
@@ -32,6 +32,17 @@ const (
 	DirNone Direction = "None"
 )
 
+// delta is the floor increment of one step in d.
+func (d Direction) delta() int {
+	switch d {
+	case DirUp:
+		return 1
+	case DirDown:
+		return -1
+	}
+	return 0
+}
+
 // DoorState represents the physical state of the door.
 // DoorState는 문의 물리 상태를 나타냅니다.
 type DoorState string
@@ -43,12 +54,46 @@ const (
 	DoorClose   DoorState = "Close"
 )
 
+// CallType distinguishes car calls from directional hall calls.
+// CallType은 카 호출과 방향이 있는 홀 호출을 구분합니다.
+type CallType string
+
+const (
+	CallCar      CallType = "Car"      // 카 내부 층 버튼
+	CallHallUp   CallType = "HallUp"   // 승강장 상행 버튼
+	CallHallDown CallType = "HallDown" // 승강장 하행 버튼
+)
+
+// HallCallType returns the hall call type for the given travel direction.
+func HallCallType(d Direction) CallType {
+	if d == DirDown {
+		return CallHallDown
+	}
+	return CallHallUp
+}
+
+// Call identifies a single registered call.
+// Call은 등록된 단일 호출을 식별합니다.
+type Call struct {
+	Floor int
+	Type  CallType
+}
+
+// CallSet is a sorted snapshot of registered calls by type.
+// CallSet은 호출 종류별로 정렬된 등록 호출 목록입니다.
+type CallSet struct {
+	Car      []int
+	HallUp   []int
+	HallDown []int
+}
+
 // FloorConfig holds specific settings for a single floor.
 // FloorConfig는 단일 층의 특정 설정을 저장합니다.
 type FloorConfig struct {
 	FloorNumber  int      // 층 번호
 	IsAccessible bool     // 접근 가능 여부
 	OpenDoorSide DoorSide // 해당 층 도착시 문 열림 방향
+	Height       float64  // 층고 m (이 층에서 윗층까지, 0이면 기본값)
 }
 
 // LogicConfig holds static configuration for the domain logic.
@@ -59,6 +104,7 @@ type LogicConfig struct {
 	InitialFloor int
 	MaxWeight    int
 	FloorConfigs map[int]FloorConfig
+	Scheduler    Scheduler // 배차 전략 (nil이면 Collective Selective)
 }
 
 // LogicActionType defines the action decided by the logic.
@@ -78,7 +124,7 @@ const (
 type LogicAction struct {
 	Type   LogicActionType
 	Target int       // Move 시 목표 층, 혹은 관련 층
-	Dir    Direction // 이동 방향
+	Dir    Direction // 이동 방향 (OpenDoor 시 응대하는 홀 호출 방향)
 }
 
 // ElevatorLogic contains purely business logic for the elevator.
@@ -92,7 +138,13 @@ type ElevatorLogic struct {
 	Direction Direction
 	Doors     map[DoorSide]DoorState
 	Weight    int
-	Calls     map[int]bool // Set of called floors
+
+	// Calls
+	CarCalls      map[int]bool // Set of car call floors
+	HallUpCalls   map[int]bool // Set of hall call floors requesting Up
+	HallDownCalls map[int]bool // Set of hall call floors requesting Down
+
+	order []Call // Registration order of calls, used by FCFS
 }
 
 // NewElevatorLogic creates a new logic instance.
@@ -112,6 +164,10 @@ func NewElevatorLogic(cfg LogicConfig) *ElevatorLogic {
 		}
 	}
 
+	if cfg.Scheduler == nil {
+		cfg.Scheduler = CollectiveScheduler{}
+	}
+
 	return &ElevatorLogic{
 		Config:    cfg,
 		Floor:     cfg.InitialFloor,
@@ -120,26 +176,123 @@ func NewElevatorLogic(cfg LogicConfig) *ElevatorLogic {
 			Front: DoorClose,
 			Rear:  DoorClose,
 		},
-		Calls: make(map[int]bool),
+		CarCalls:      make(map[int]bool),
+		HallUpCalls:   make(map[int]bool),
+		HallDownCalls: make(map[int]bool),
 	}
 }
 
-// AddCall registers a call if valid.
-func (l *ElevatorLogic) AddCall(floor int) error {
+// AddCall registers a call of the given type if valid.
+func (l *ElevatorLogic) AddCall(floor int, t CallType) error {
 	if floor < l.Config.MinFloor || floor > l.Config.MaxFloor {
-		return fmt.Errorf("floor %d out of range", floor)
+		return errorf(ErrOutOfRange, "floor %d out of range", floor)
 	}
 	cfg := l.Config.FloorConfigs[floor]
 	if !cfg.IsAccessible {
-		return fmt.Errorf("floor %d is inaccessible", floor)
+		return errorf(ErrInaccessible, "floor %d is inaccessible", floor)
+	}
+
+	switch t {
+	case CallCar:
+	case CallHallUp:
+		if floor == l.Config.MaxFloor {
+			return errorf(ErrInvalid, "no up hall call at top floor %d", floor)
+		}
+	case CallHallDown:
+		if floor == l.Config.MinFloor {
+			return errorf(ErrInvalid, "no down hall call at bottom floor %d", floor)
+		}
+	default:
+		return errorf(ErrInvalid, "unknown call type %q", t)
+	}
+
+	calls := l.callMap(t)
+	if !calls[floor] {
+		calls[floor] = true
+		l.order = append(l.order, Call{Floor: floor, Type: t})
 	}
-	l.Calls[floor] = true
 	return nil
 }
 
-// RemoveCall removes a call.
-func (l *ElevatorLogic) RemoveCall(floor int) {
-	delete(l.Calls, floor)
+// RemoveCall removes a call of the given type.
+func (l *ElevatorLogic) RemoveCall(floor int, t CallType) {
+	if calls := l.callMap(t); calls != nil {
+		delete(calls, floor)
+		l.forget(Call{Floor: floor, Type: t})
+	}
+}
+
+// forget drops c from the registration order, so a call registered again
+// queues behind the calls made in the meantime.
+func (l *ElevatorLogic) forget(c Call) {
+	if i := slices.Index(l.order, c); i >= 0 {
+		l.order = slices.Delete(l.order, i, i+1)
+	}
+}
+
+// ClearCalls removes every registered call.
+func (l *ElevatorLogic) ClearCalls() {
+	l.CarCalls = make(map[int]bool)
+	l.HallUpCalls = make(map[int]bool)
+	l.HallDownCalls = make(map[int]bool)
+	l.order = nil
+}
+
+// PendingCalls returns the registered calls in the order they were made.
+func (l *ElevatorLogic) PendingCalls() []Call {
+	pending := make([]Call, 0, len(l.order))
+	seen := make(map[Call]bool, len(l.order))
+	for _, c := range l.order {
+		if l.callMap(c.Type)[c.Floor] && !seen[c] {
+			seen[c] = true
+			pending = append(pending, c)
+		}
+	}
+
+	// Calls written to the maps directly have no recorded order; append them.
+	for _, t := range []CallType{CallCar, CallHallUp, CallHallDown} {
+		for _, f := range sortedFloors(l.callMap(t)) {
+			if c := (Call{Floor: f, Type: t}); !seen[c] {
+				pending = append(pending, c)
+			}
+		}
+	}
+	return pending
+}
+
+func (l *ElevatorLogic) callMap(t CallType) map[int]bool {
+	switch t {
+	case CallCar:
+		return l.CarCalls
+	case CallHallUp:
+		return l.HallUpCalls
+	case CallHallDown:
+		return l.HallDownCalls
+	}
+	return nil
+}
+
+// ServeFloor clears the calls answered by stopping at floor.
+// The car call is always cleared; for hall calls only the one matching dir is
+// cleared, or both when dir is DirNone.
+func (l *ElevatorLogic) ServeFloor(floor int, dir Direction) {
+	l.RemoveCall(floor, CallCar)
+	if dir != DirDown {
+		l.RemoveCall(floor, CallHallUp)
+	}
+	if dir != DirUp {
+		l.RemoveCall(floor, CallHallDown)
+	}
+}
+
+// HasCalls reports whether any call is registered.
+func (l *ElevatorLogic) HasCalls() bool {
+	return len(l.CarCalls)+len(l.HallUpCalls)+len(l.HallDownCalls) > 0
+}
+
+// HasCallAt reports whether any call is registered at floor.
+func (l *ElevatorLogic) HasCallAt(floor int) bool {
+	return l.CarCalls[floor] || l.HallUpCalls[floor] || l.HallDownCalls[floor]
 }
 
 // SetDoor updates door state directly (for internal logic transitions).
@@ -158,7 +311,7 @@ func (l *ElevatorLogic) SetDirection(d Direction) {
 }
 
 // DecideNextStep determines what the elevator should do next based on current state.
-// This implements the core SCAN algorithm and Door logic.
+// Target selection is delegated to the configured Scheduler.
 func (l *ElevatorLogic) DecideNextStep() LogicAction {
 	// 1. Check Doors
 	// If any door is not closed, we generally cannot move, unless we are closing them.
@@ -167,21 +320,21 @@ func (l *ElevatorLogic) DecideNextStep() LogicAction {
 	// Or logic just checks state).
 	// Ideally, Logic doesn't handle 'Time'. Service tells logic "DoorTimerExpired".
 
-	// For now, let's implement the movement decision (SCAN).
+	// For now, let's implement the movement decision.
 	// Assuming doors are Closed.
 
 	if !l.AreDoorsClosed() {
 		return LogicAction{Type: ActionNone} // Cannot move if doors open
 	}
 
-	if len(l.Calls) == 0 {
+	if !l.HasCalls() {
 		if l.Direction != DirNone {
 			return LogicAction{Type: ActionStop, Dir: DirNone}
 		}
 		return LogicAction{Type: ActionNone}
 	}
 
-	target, found := l.selectNextTarget()
+	target, serveDir, found := l.Config.Scheduler.NextTarget(l)
 	if !found {
 		// Should stop
 		if l.Direction != DirNone {
@@ -192,7 +345,7 @@ func (l *ElevatorLogic) DecideNextStep() LogicAction {
 
 	// Logic specifics:
 	if target == l.Floor {
-		return LogicAction{Type: ActionOpenDoor, Target: target}
+		return LogicAction{Type: ActionOpenDoor, Target: target, Dir: serveDir}
 	}
 
 	var dir Direction
@@ -205,6 +358,27 @@ func (l *ElevatorLogic) DecideNextStep() LogicAction {
 	return LogicAction{Type: ActionMove, Target: target, Dir: dir}
 }
 
+// NextStop returns the first floor beyond from in dir at which a car travelling
+// in dir would stop, either to serve a call or because it has nothing left to
+// do in that direction. The terminal floor is the last possible stop.
+func (l *ElevatorLogic) NextStop(from int, dir Direction) int {
+	floor, direction, doors := l.Floor, l.Direction, l.Doors
+	defer func() { l.Floor, l.Direction, l.Doors = floor, direction, doors }()
+
+	// Decide as if the doors were closed and the car were passing each floor.
+	l.Doors = map[DoorSide]DoorState{Front: DoorClose, Rear: DoorClose}
+	l.Direction = dir
+	f := from + dir.delta()
+	for f != l.Config.MinFloor && f != l.Config.MaxFloor {
+		l.Floor = f
+		if action := l.DecideNextStep(); action.Type != ActionMove || action.Dir != dir {
+			return f
+		}
+		f += dir.delta()
+	}
+	return f
+}
+
 // AreDoorsClosed checks if all doors are closed.
 func (l *ElevatorLogic) AreDoorsClosed() bool {
 	for _, state := range l.Doors {
@@ -215,58 +389,38 @@ func (l *ElevatorLogic) AreDoorsClosed() bool {
 	return true
 }
 
-// selectNextTarget implements SCAN algorithm.
-func (l *ElevatorLogic) selectNextTarget() (int, bool) {
-	if len(l.Calls) == 0 {
-		return 0, false
+// hasCallBeyond reports whether any call exists strictly beyond floor in dir.
+func (l *ElevatorLogic) hasCallBeyond(floor int, dir Direction) bool {
+	for _, f := range l.pendingFloors() {
+		if (dir == DirUp && f > floor) || (dir == DirDown && f < floor) {
+			return true
+		}
 	}
+	return false
+}
 
-	// Phase 1: Current Direction Scan
-	switch l.Direction {
-	case DirUp:
-		minDist := math.MaxInt64
-		target := -1
-		found := false
-		for f := range l.Calls {
-			if f >= l.Floor { // Include current floor
-				dist := f - l.Floor
-				if dist < minDist {
-					minDist = dist
-					target = f
-					found = true
-				}
-			}
-		}
-		if found {
-			return target, true
-		}
-	case DirDown:
-		minDist := math.MaxInt64
-		target := -1
-		found := false
-		for f := range l.Calls {
-			if f <= l.Floor { // Include current floor
-				dist := l.Floor - f
-				if dist < minDist {
-					minDist = dist
-					target = f
-					found = true
-				}
-			}
-		}
-		if found {
-			return target, true
+// nearestCall returns the closest floor with any call, scanning from 'from' towards 'to'.
+func (l *ElevatorLogic) nearestCall(from, to int) (int, bool) {
+	step := 1
+	if to < from {
+		step = -1
+	}
+	for f := from; f != to+step; f += step {
+		if l.HasCallAt(f) {
+			return f, true
 		}
 	}
+	return 0, false
+}
 
-	// Phase 2: Nearest Call (Direction Reversal or Idle)
-	minDist := math.MaxInt64
-	target := -1
+// nearestAnyCall returns the call floor closest to the car, preferring the
+// lower floor on a tie.
+func (l *ElevatorLogic) nearestAnyCall() (int, bool) {
+	minDist := math.MaxInt
+	target := 0
 	found := false
-
-	for f := range l.Calls {
-		dist := int(math.Abs(float64(f - l.Floor)))
-		if dist < minDist {
+	for _, f := range l.pendingFloors() {
+		if dist := abs(f - l.Floor); dist < minDist {
 			minDist = dist
 			target = f
 			found = true
@@ -275,12 +429,43 @@ func (l *ElevatorLogic) selectNextTarget() (int, bool) {
 	return target, found
 }
 
-// CallFloors returns sorted list of calls.
-func (l *ElevatorLogic) CallFloors() []int {
-	var floors []int
-	for f := range l.Calls {
+// pendingFloors returns every floor with at least one call, in ascending order.
+func (l *ElevatorLogic) pendingFloors() []int {
+	seen := make(map[int]bool, len(l.CarCalls)+len(l.HallUpCalls)+len(l.HallDownCalls))
+	floors := make([]int, 0, len(seen))
+	for _, m := range []map[int]bool{l.CarCalls, l.HallUpCalls, l.HallDownCalls} {
+		for f := range m {
+			if !seen[f] {
+				seen[f] = true
+				floors = append(floors, f)
+			}
+		}
+	}
+	sort.Ints(floors)
+	return floors
+}
+
+// CallFloors returns sorted lists of calls by type.
+func (l *ElevatorLogic) CallFloors() CallSet {
+	return CallSet{
+		Car:      sortedFloors(l.CarCalls),
+		HallUp:   sortedFloors(l.HallUpCalls),
+		HallDown: sortedFloors(l.HallDownCalls),
+	}
+}
+
+func sortedFloors(m map[int]bool) []int {
+	floors := make([]int, 0, len(m))
+	for f := range m {
 		floors = append(floors, f)
 	}
 	sort.Ints(floors)
 	return floors
 }
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
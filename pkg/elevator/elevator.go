@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -51,31 +53,64 @@ type ArrivedPayload struct {
 type OperationMode int
 
 const (
-	ModeAuto      OperationMode = iota // 자동 운행 (기본)
-	ModeManual                         // 수동 제어 (점검 등)
-	ModeMoving                         // 이사 모드 (장시간 문 열림 유지)
-	ModeEmergency                      // 비상 정지 (모든 동작 즉시 중단)
+	ModeAuto        OperationMode = iota // 자동 운행 (기본)
+	ModeManual                           // 수동 제어 (점검 등)
+	ModeMoving                           // 독립 운전 (이사 등: 카 호출만, 닫힘 버튼을 눌러야 문이 닫힘)
+	ModeEmergency                        // 비상 정지 (모든 동작 즉시 중단)
+	ModeFireRecall                       // 소방 1차 운전 (Phase I: 귀환 층으로 복귀)
+	ModeFireService                      // 소방 2차 운전 (Phase II: 소방관 조작)
 )
 
+var modeNames = [...]string{"Auto", "Manual", "Moving", "Emergency", "FireRecall", "FireService"}
+
 func (m OperationMode) String() string {
-	return [...]string{"Auto", "Manual", "Moving", "Emergency"}[m]
+	if !m.Valid() {
+		return fmt.Sprintf("OperationMode(%d)", int(m))
+	}
+	return modeNames[m]
+}
+
+// Valid reports whether m is a known operation mode.
+func (m OperationMode) Valid() bool {
+	return m >= 0 && int(m) < len(modeNames)
+}
+
+// ParseMode accepts an operation mode name, in any case, or number.
+func ParseMode(s string) (OperationMode, error) {
+	for m := ModeAuto; m.Valid(); m++ {
+		if strings.EqualFold(m.String(), s) {
+			return m, nil
+		}
+	}
+	if n, err := strconv.Atoi(s); err == nil && OperationMode(n).Valid() {
+		return OperationMode(n), nil
+	}
+	return 0, errorf(ErrInvalid, "unknown mode %q", s)
 }
 
 // Config holds immutable configuration parameters.
 // Config는 시스템 시작 시 설정되며, 런타임 중에 변경되지 않습니다.
 type Config struct {
-	ID             string
-	TravelTime     time.Duration       // 한 층 이동 시간 - 주행 속도
-	TravelTimeEdge time.Duration       // 한 층 이동 시간 - 시작/정지 속도
-	DoorSpeed      time.Duration       // 문 열림/닫힘 속도
-	DoorOpenTime   time.Duration       // 층 도착 후 문 열림 유지 시간
-	DoorReopenTime time.Duration       // 버튼 조작 후 문 열림 유지 시간
-	InitialFloor   int                 // 초기 층 - 연속 인덱스
-	MinFloor       int                 // 최저 층 인덱스
-	MaxFloor       int                 // 최고 층 인덱스
-	MaxWeight      int                 // 최대 허용 무게 kg
-	FloorConfigs   map[int]FloorConfig // 층 정보
-}
+	ID              string
+	TravelTime      time.Duration       // 한 층 이동 시간 - 주행 속도
+	TravelTimeEdge  time.Duration       // 한 층 이동 시간 - 시작/정지 속도
+	DoorSpeed       time.Duration       // 문 열림/닫힘 속도
+	DoorOpenTime    time.Duration       // 층 도착 후 문 열림 유지 시간
+	DoorReopenTime  time.Duration       // 버튼 조작 후 문 열림 유지 시간
+	InitialFloor    int                 // 초기 층 - 연속 인덱스
+	MinFloor        int                 // 최저 층 인덱스
+	MaxFloor        int                 // 최고 층 인덱스
+	MaxWeight       int                 // 최대 허용 무게 kg
+	FloorConfigs    map[int]FloorConfig // 층 정보
+	Scheduler       Scheduler           // 배차 전략 (nil이면 Collective Selective)
+	Motion          *MotionProfile      // 운동 모델 (nil이면 TravelTime/TravelTimeEdge 사용)
+	Recall          *RecallConfig       // 소방 귀환 층 (nil이면 InitialFloor)
+	InspectionSpeed float64             // 점검 운전 속도 m/s (0이면 DefaultInspectionSpeed)
+	NudgingTime     time.Duration       // 문 감지기 차단 지속 시 넛징 전환 시간 (0이면 DefaultNudgingTime)
+}
+
+// stepInterval is the period of the engine's decision tick.
+const stepInterval = 100 * time.Millisecond
 
 // Elevator is the Application Service.
 // It orchestrates Logic, Time, and Concurrency.
@@ -87,21 +122,97 @@ type Elevator struct {
 	// --- Runtime State ---
 	Mode         OperationMode
 	openWaitTime time.Duration
+	isMoving     bool
+	run          *runPlan  // 진행 중인 주행 (정지 시 nil)
+	jog          *jogRun   // 진행 중인 점검 운전 이동 (정지 시 nil)
+	offLevel     float64   // 층 사이에 정지한 경우 Logic.Floor 기준 높이 차 m
+	servedDir    Direction // 현재 정차에서 응대한 방향 (DirNone: 전체)
+
+	// --- Passengers ---
+	waiting map[int][]*Passenger // 층별 대기 승객
+	riders  []*Passenger         // 탑승 승객
 
 	// --- Loop Control ---
-	doorTimer *time.Timer
+	// Each timer carries a generation; a callback whose generation is stale
+	// (the timer was re-armed or stopped meanwhile) is ignored.
+	clock       Clock
+	running     bool
+	tickTimer   Timer
+	tickGen     uint64
+	doorTimer   Timer
+	doorGen     uint64
+	travelTimer Timer
+	travelGen   uint64
+
+	// Deadlines of the armed timers, kept for Snapshot.
+	tickArmed   armedTimer
+	doorArmed   armedTimer
+	travelArmed armedTimer
+	armSeq      uint64
 
 	// --- Observability ---
-	logger            *slog.Logger
-	eventCh           chan Event
-	droppedEventCount uint64
+	logger    *slog.Logger
+	events    *Subscription   // Events 채널 (WithEventBuffer)
+	subs      []*Subscription // Subscribe로 연 구독
+	observers []func(Event)
+
+	// --- Fire Service ---
+	smoke       map[int]bool // 작동 중인 연기 감지기 층
+	recallFloor int          // Phase I 귀환 중인 층
+
+	// --- Emergency ---
+	emergency EmergencyPhase // 비상 정지 복구 단계
+
+	// --- Door Obstruction ---
+	obstructed   bool      // 문 감지기 차단 중
+	obstructedAt time.Time // 차단 시작 시각
+	nudging      bool      // 넛징 중 (저속 닫힘, 부저)
+
+	// --- Faults ---
+	faults     map[FaultKind]Fault // 주입된 고장
+	correction bool                // 위치 센서 복구 후 보정 운전 대기
+
+	// --- Group ---
+	onLeave  func(*Elevator) // 그룹 배차에서 빠질 때 호출 (Group이 설정)
+	released []Call          // 독립 운전 전환 시 취소되어 그룹에 반환할 홀 호출
 
 	// --- Internal Flags ---
-	isOpenButtonPressed bool
+	isOpenButtonPressed  bool
+	isCloseButtonPressed bool
+}
+
+// Option configures optional Elevator dependencies.
+type Option func(*Elevator)
+
+// WithClock makes the engine use c instead of the wall clock.
+func WithClock(c Clock) Option {
+	return func(e *Elevator) {
+		e.clock = c
+	}
+}
+
+// WithLogger replaces the default slog logger. The car ID is added as an attribute.
+func WithLogger(l *slog.Logger) Option {
+	return func(e *Elevator) {
+		e.logger = l.With("id", e.Config.ID)
+	}
+}
+
+// WithEventBuffer sets the capacity of the Events channel (default 1000).
+// A size of 0 disables the channel; events then only reach subscriptions and
+// OnEvent observers.
+func WithEventBuffer(size int) Option {
+	return func(e *Elevator) {
+		if size <= 0 {
+			e.events = nil
+			return
+		}
+		e.events = newSubscription(SubscribeOptions{Buffer: size, Policy: DropNewest})
+	}
 }
 
 // New initializes a new Elevator instance.
-func New(config Config) (*Elevator, error) {
+func New(config Config, opts ...Option) (*Elevator, error) {
 	// LogicConfig Init
 	logicConfig := LogicConfig{
 		MinFloor:     config.MinFloor,
@@ -109,6 +220,7 @@ func New(config Config) (*Elevator, error) {
 		InitialFloor: config.InitialFloor,
 		MaxWeight:    config.MaxWeight,
 		FloorConfigs: config.FloorConfigs,
+		Scheduler:    config.Scheduler,
 	}
 
 	// Logic Instance
@@ -118,6 +230,24 @@ func New(config Config) (*Elevator, error) {
 	if config.MinFloor > config.MaxFloor {
 		return nil, fmt.Errorf("invalid config: MinFloor (%d) > MaxFloor (%d)", config.MinFloor, config.MaxFloor)
 	}
+	if config.Motion != nil {
+		if err := config.Motion.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if config.InspectionSpeed < 0 {
+		return nil, fmt.Errorf("invalid config: negative inspection speed %.2f", config.InspectionSpeed)
+	}
+	if config.NudgingTime < 0 {
+		return nil, fmt.Errorf("invalid config: negative nudging time %s", config.NudgingTime)
+	}
+	if r := config.Recall; r != nil {
+		for _, f := range []int{r.Floor, r.AlternateFloor} {
+			if f < config.MinFloor || f > config.MaxFloor {
+				return nil, fmt.Errorf("invalid config: recall floor %d out of range", f)
+			}
+		}
+	}
 
 	if config.DoorReopenTime == 0 {
 		config.DoorReopenTime = config.DoorOpenTime
@@ -127,24 +257,23 @@ func New(config Config) (*Elevator, error) {
 		Config:       config,
 		Logic:        logic,
 		Mode:         ModeAuto,
-		doorTimer:    time.NewTimer(0),
-		eventCh:      make(chan Event, 1000),
+		clock:        RealClock{},
+		waiting:      make(map[int][]*Passenger),
+		smoke:        make(map[int]bool),
+		faults:       make(map[FaultKind]Fault),
+		events:       newSubscription(SubscribeOptions{Buffer: 1000, Policy: DropNewest}),
 		logger:       slog.Default().With("id", config.ID),
 		openWaitTime: config.DoorOpenTime,
 	}
-
-	// Stop timer initially
-	if !e.doorTimer.Stop() {
-		select {
-		case <-e.doorTimer.C:
-		default:
-		}
+	for _, opt := range opts {
+		opt(e)
 	}
 
 	e.logger.Info("Elevator Service initialized",
 		"min", config.MinFloor,
 		"max", config.MaxFloor,
 		"init_floor", config.InitialFloor,
+		"scheduler", logic.Config.Scheduler.Name(),
 	)
 
 	return e, nil
@@ -195,10 +324,34 @@ func (e *Elevator) Weight() int {
 	return e.Logic.Weight
 }
 
-func (e *Elevator) DroppedEventCount() uint64 {
+// CurrentMode returns the current operation mode.
+func (e *Elevator) CurrentMode() OperationMode {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	return e.droppedEventCount
+	return e.Mode
+}
+
+// Available reports whether the car can take hall calls from a group controller.
+func (e *Elevator) Available() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.Mode == ModeAuto && !e.outOfService()
+}
+
+// EstimateArrival estimates how long the car needs to answer a hall call at floor in dir.
+func (e *Elevator) EstimateArrival(floor int, dir Direction) time.Duration {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return estimateArrival(e.Logic, e.Config, floor, dir)
+}
+
+// DroppedEventCount returns how many events the Events channel dropped
+// because it was full.
+func (e *Elevator) DroppedEventCount() uint64 {
+	if e.events == nil {
+		return 0
+	}
+	return e.events.Dropped()
 }
 
 func (e *Elevator) Reset() {
@@ -207,7 +360,16 @@ func (e *Elevator) Reset() {
 	e.logger.Info("Resetting elevator state")
 
 	// Create new clean logic
+	e.stopTravelTimer()
+	e.run = nil
+	e.jog = nil
+	e.offLevel = 0
+	e.isMoving = false
+	e.nudging = false
+	e.correction = false
 	e.Logic = NewElevatorLogic(e.Logic.Config)
+	e.waiting = make(map[int][]*Passenger)
+	e.riders = nil
 
 	e.publishEvent(EventFloorChange, e.Logic.Floor)
 	e.publishEvent(EventDirectionChange, e.Logic.Direction)
@@ -215,51 +377,69 @@ func (e *Elevator) Reset() {
 	e.publishEvent(EventDoorChange, DoorChangePayload{Side: Rear, State: DoorClose})
 }
 
-func (e *Elevator) CallFloors() []int {
+func (e *Elevator) CallFloors() CallSet {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 	return e.Logic.CallFloors()
 }
 
+// HasCall reports whether a call of the given type is pending at floor.
+func (e *Elevator) HasCall(floor int, callType CallType) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.Logic.callMap(callType)[floor]
+}
+
+// Events returns the shared event channel, or nil if it is disabled. Events
+// are dropped when it is full, and concurrent receivers split the stream
+// between them; a consumer that needs every event of its own uses Subscribe.
 func (e *Elevator) Events() <-chan Event {
-	return e.eventCh
+	if e.events == nil {
+		return nil
+	}
+	return e.events.ch
 }
 
-func (e *Elevator) AddCall(floor int, isCarCall bool) error {
+// OnEvent registers fn to be called synchronously for every published event,
+// in publish order. fn runs with the engine lock held and must not call back
+// into the Elevator. Intended for headless simulations and recorders.
+func (e *Elevator) OnEvent(fn func(Event)) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	e.observers = append(e.observers, fn)
+}
 
-	err := e.Logic.AddCall(floor)
+func (e *Elevator) AddCall(floor int, callType CallType) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.rejected("AddCall", floor, &err)
+
+	err = e.checkCall(callType)
+	if err == nil && e.stuckButton(floor, callType) {
+		err = errorf(ErrFault, "hall button %d %s is stuck", floor, callType)
+	}
+	if err == nil {
+		err = e.Logic.AddCall(floor, callType)
+	}
 	if err != nil {
-		e.logger.Warn("AddCall failed", "floor", floor, "err", err)
 		return err
 	}
 
-	callType := "Hall"
-	if isCarCall {
-		callType = "Car"
-	}
-	e.logger.Info(callType+" Call registered", "floor", floor)
+	e.logger.Info("Call registered", "floor", floor, "type", callType)
 	return nil
 }
 
-func (e *Elevator) RemoveCall(floor int) {
+func (e *Elevator) RemoveCall(floor int, callType CallType) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	e.Logic.RemoveCall(floor)
-	e.logger.Debug("Call removed", "floor", floor)
+	e.Logic.RemoveCall(floor, callType)
+	e.logger.Debug("Call removed", "floor", floor, "type", callType)
 }
 
 func (e *Elevator) ClearCalls() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	// New logic instance or just clear map? logic doesn't have ClearCalls.
-	// But logic.Calls is map, we can re-make it.
-	// But direct access is risky if logic changes.
-	// Let's iterate and delete? Or just replace the map.
-	// For now adding method to Logic is best but I can't edit domain.go right now.
-	// Accessing field directly since same package.
-	e.Logic.Calls = make(map[int]bool)
+	e.Logic.ClearCalls()
 	e.logger.Info("All calls cleared")
 }
 
@@ -286,17 +466,20 @@ func (e *Elevator) PressOpenButton() {
 	defer e.mu.Unlock()
 	e.isOpenButtonPressed = true
 	e.logger.Debug("Open Button Pressed")
+	if e.Mode == ModeEmergency {
+		return
+	}
+	if e.Mode.fireService() {
+		e.fireServiceDoors()
+		return
+	}
 
 	// If door is closing, reopen immediately
 	if e.Logic.Doors[Front] == DoorClosing || e.Logic.Doors[Rear] == DoorClosing {
-		e.setDoor(Front, DoorOpening)
-		e.setDoor(Rear, DoorOpening)
-		// Reset timer for reopening logic (handled in step/timeout) or explicit here?
-		// handleDoorTimeout checks state. If we set to Opening, next timeout will switch to Open.
-		e.doorTimer.Reset(e.Config.DoorSpeed)
+		e.reopenDoors()
 	} else if e.Logic.Doors[Front] == DoorOpen {
 		// Extend hold time
-		e.doorTimer.Reset(e.Config.DoorReopenTime)
+		e.armDoorTimer(e.Config.DoorReopenTime)
 	}
 }
 
@@ -306,6 +489,10 @@ func (e *Elevator) ReleaseOpenButton() {
 	defer e.mu.Unlock()
 	e.isOpenButtonPressed = false
 	e.logger.Debug("Open Button Released")
+	if e.Mode.fireService() {
+		e.fireServiceDoors()
+		return
+	}
 	// If doors are open, the timer is supposedly running or checked in handleDoorTimeout.
 	// We rely on the loop checking the flag.
 }
@@ -314,12 +501,41 @@ func (e *Elevator) ReleaseOpenButton() {
 func (e *Elevator) PressCloseButton() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	e.isCloseButtonPressed = true
 	e.logger.Debug("Close Button Pressed")
+	if e.Mode == ModeEmergency {
+		return
+	}
+	if e.Mode.fireService() {
+		e.fireServiceDoors()
+		return
+	}
+	if e.Mode == ModeMoving {
+		e.closeOnIndependent()
+		return
+	}
 
 	// Only effective if doors are open and safe to close
+	if (e.Logic.Doors[Front] == DoorOpen || e.Logic.Doors[Rear] == DoorOpen) && e.overloaded() {
+		err := errorf(ErrOverload, "car %s is overloaded", e.Config.ID)
+		e.rejected("PressClose", e.Logic.Floor, &err)
+		return
+	}
 	if (e.Logic.Doors[Front] == DoorOpen || e.Logic.Doors[Rear] == DoorOpen) && !e.isOpenButtonPressed {
 		// Close immediately (shorten timer)
-		e.doorTimer.Reset(1 * time.Millisecond) // Trigger timeout almost immediately
+		e.armDoorTimer(1 * time.Millisecond) // Trigger timeout almost immediately
+	}
+}
+
+// ReleaseCloseButton signals that the close button is released.
+// Outside fire service the close button acts on the press alone.
+func (e *Elevator) ReleaseCloseButton() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.isCloseButtonPressed = false
+	e.logger.Debug("Close Button Released")
+	if e.Mode.fireService() {
+		e.fireServiceDoors()
 	}
 }
 
@@ -334,40 +550,105 @@ func (e *Elevator) SetDoor(side DoorSide, state DoorState) {
 	}
 }
 
-func (e *Elevator) SetMode(mode OperationMode) {
+// SetMode changes the operation mode. A car of a group put on independent
+// service hands its hall calls and waiting passengers back to the group.
+// The change is refused while an emergency fault is latched.
+func (e *Elevator) SetMode(mode OperationMode) (err error) {
 	e.mu.Lock()
-	defer e.mu.Unlock()
+	switch {
+	case !mode.Valid():
+		err = errorf(ErrInvalid, "invalid mode %d", int(mode))
+	case e.emergency == EmergencyLatched && e.Mode != mode:
+		err = errorf(ErrState, "car %s emergency fault is not reset", e.Config.ID)
+	}
+	if err != nil {
+		e.rejected("SetMode", e.Logic.Floor, &err)
+		e.mu.Unlock()
+		return err
+	}
+	e.setMode(mode)
+	leave := e.onLeave
+	if e.Mode != ModeMoving {
+		leave = nil
+	}
+	e.mu.Unlock()
 
+	// Outside the car lock: the group locks itself and then its cars.
+	if leave != nil {
+		leave(e)
+	}
+	return nil
+}
+
+func (e *Elevator) setMode(mode OperationMode) {
 	if e.Mode == mode {
 		return
 	}
+	if e.emergency == EmergencyLatched {
+		e.logger.Warn("Mode change refused, emergency fault not reset", "mode", mode)
+		return
+	}
 
-	e.logger.Info("Operation Mode Changed", "from", e.Mode, "to", mode)
+	from := e.Mode
+	e.logger.Info("Operation Mode Changed", "from", from, "to", mode)
 	e.Mode = mode
 	e.publishEvent(EventModeChange, mode)
 
-	if mode == ModeEmergency {
-		e.logger.Warn("Emergency Stop Activated")
-		e.doorTimer.Stop()
-		e.setDirection(DirNone) // Updates logic and publishes event
+	switch {
+	case mode == ModeEmergency:
+		e.startEmergency()
+	case mode == ModeFireRecall:
+		e.startRecall()
+	case mode == ModeFireService:
+		e.startFireService()
+	case mode == ModeManual:
+		e.startInspection()
+	case mode == ModeMoving:
+		e.startIndependent()
+	}
+	if from.fireService() && !mode.fireService() {
+		e.endFireService()
+	}
+	if from == ModeManual {
+		e.endInspection()
+	}
+	if from == ModeMoving {
+		e.endIndependent()
+	}
+	if from == ModeEmergency {
+		e.endEmergency()
 	}
 }
 
+// dispatching reports whether the engine moves the car by itself to serve
+// its calls in the current mode.
+func (e *Elevator) dispatching() bool {
+	return e.Mode == ModeAuto || e.Mode == ModeMoving || e.Mode.fireService()
+}
+
+// overloaded reports whether the load exceeds the rated load.
+func (e *Elevator) overloaded() bool {
+	return e.Config.MaxWeight > 0 && e.Logic.Weight > e.Config.MaxWeight
+}
+
 // Private helpers (State Updates & Events) -----------------------------------
 
 func (e *Elevator) publishEvent(eventType EventType, payload interface{}) {
 	event := Event{
 		Type:      eventType,
 		Payload:   payload,
-		Timestamp: time.Now(),
+		Timestamp: e.clock.Now(),
 	}
 
-	select {
-	case e.eventCh <- event:
-	default:
-		e.droppedEventCount++
-		if e.droppedEventCount%100 == 1 {
-			e.logger.Error("Event Channel Saturated", "dropped", e.droppedEventCount)
+	for _, fn := range e.observers {
+		fn(event)
+	}
+	if e.events != nil && e.events.deliver(event) > 0 && e.events.Dropped()%100 == 1 {
+		e.logger.Error("Event Channel Saturated", "dropped", e.events.Dropped())
+	}
+	for _, s := range e.subs {
+		if s.deliver(event) > 0 && s.Dropped()%100 == 1 {
+			e.logger.Warn("Event subscriber saturated", "policy", s.policy, "dropped", s.Dropped())
 		}
 	}
 }
@@ -395,48 +676,140 @@ func (e *Elevator) setDoor(side DoorSide, state DoorState) {
 
 // Engine Loop ----------------------------------------------------------------
 
+// Run drives the engine until ctx is cancelled.
 func (e *Elevator) Run(ctx context.Context) error {
 	e.logger.Info("Elevator Engine Started")
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
-	defer e.doorTimer.Stop()
-
-	travelTimer := time.NewTimer(e.Config.TravelTime)
-	travelTimer.Stop()
-
-	isMoving := false
-
-	for {
-		select {
-		case <-ctx.Done():
-			e.logger.Info("Engine Stopping")
-			return ctx.Err()
-		case <-ticker.C:
-			e.step(&isMoving, travelTimer)
-		case <-travelTimer.C:
-			// Movement done
-			shouldContinue, duration := e.handleMoveComplete()
-			if shouldContinue {
-				travelTimer.Reset(duration)
-			} else {
-				isMoving = false
-				e.logger.Info("Travel timer stopped/completed")
-			}
-		case <-e.doorTimer.C:
-			e.handleDoorTimeout()
-		}
+	e.start()
+	defer e.stop()
+
+	<-ctx.Done()
+	e.logger.Info("Engine Stopping")
+	return ctx.Err()
+}
+
+// start arms the decision tick. All further work happens in clock callbacks.
+func (e *Elevator) start() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.running = true
+	e.scheduleTick()
+}
+
+// stop cancels every pending timer.
+func (e *Elevator) stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.running = false
+	e.stopTickTimer()
+	e.stopDoorTimer()
+	e.stopTravelTimer()
+}
+
+func (e *Elevator) scheduleTick() {
+	e.armTick(stepInterval)
+}
+
+func (e *Elevator) armTick(d time.Duration) {
+	e.stopTickTimer()
+	gen := e.tickGen
+	e.tickArmed = e.armed(d)
+	e.tickTimer = e.clock.AfterFunc(d, func() { e.onTick(gen) })
+}
+
+func (e *Elevator) stopTickTimer() {
+	if e.tickTimer != nil {
+		e.tickTimer.Stop()
 	}
+	e.tickArmed = armedTimer{}
+	e.tickGen++
 }
 
-// step calls DecidNextStep from Logic and enacts the result.
-func (e *Elevator) step(isMoving *bool, travelTimer *time.Timer) {
+func (e *Elevator) onTick(gen uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.running || gen != e.tickGen {
+		return
+	}
+	e.step()
+	e.scheduleTick()
+}
+
+func (e *Elevator) armDoorTimer(d time.Duration) {
+	e.stopDoorTimer()
+	gen := e.doorGen
+	e.doorArmed = e.armed(d)
+	e.doorTimer = e.clock.AfterFunc(d, func() { e.onDoorTimer(gen) })
+}
+
+func (e *Elevator) stopDoorTimer() {
+	if e.doorTimer != nil {
+		e.doorTimer.Stop()
+	}
+	e.doorArmed = armedTimer{}
+	e.doorGen++
+}
+
+func (e *Elevator) onDoorTimer(gen uint64) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	if gen != e.doorGen {
+		return
+	}
+	e.doorArmed = armedTimer{}
+	e.handleDoorTimeout()
+}
+
+func (e *Elevator) armTravelTimer(d time.Duration) {
+	e.stopTravelTimer()
+	gen := e.travelGen
+	e.travelArmed = e.armed(d)
+	e.travelTimer = e.clock.AfterFunc(d, func() { e.onTravelTimer(gen) })
+}
 
-	if e.Mode != ModeAuto {
+func (e *Elevator) stopTravelTimer() {
+	if e.travelTimer != nil {
+		e.travelTimer.Stop()
+	}
+	e.travelArmed = armedTimer{}
+	e.travelGen++
+}
+
+func (e *Elevator) onTravelTimer(gen uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if gen != e.travelGen {
 		return
 	}
-	if *isMoving {
+	e.travelArmed = armedTimer{}
+
+	if e.run != nil {
+		e.handleMoveComplete()
+	} else if e.jog != nil {
+		e.handleJogLevel()
+	}
+}
+
+// step calls DecidNextStep from Logic and enacts the result.
+func (e *Elevator) step() {
+	if e.jog != nil {
+		e.publishPosition()
+		return
+	}
+	if e.isMoving {
+		e.publishPosition()
+		if e.replan(e.clock.Now().Sub(e.run.start)) {
+			e.armNextCrossing()
+		}
+		return
+	}
+	if !e.dispatching() {
+		return
+	}
+	if e.faultStep() {
+		return
+	}
+	if e.offLevel != 0 {
+		e.relevel()
 		return
 	}
 
@@ -448,30 +821,11 @@ func (e *Elevator) step(isMoving *bool, travelTimer *time.Timer) {
 		// Update Direction
 		e.setDirection(action.Dir)
 
-		target := action.Target
-		// Calculate Duration (TravelTime)
-		// For simplicity, naive fixed duration for now.
-		// Real elevator might need checking if it's 1 floor or more?
-		// Logic returns Target, but moving is step by step.
-		// Wait, logic says "Move towards Target".
-		// We initiate move to (Current + 1) or (Current - 1).
-
-		// Logic action tells us "Go to strict Target or Move in Dir?"
-		// My Logic impl: "ActionMove, Dir=Up, Target=8".
-		// So we start moving.
-
-		duration := e.Config.TravelTime
-
-		*isMoving = true
-		if !travelTimer.Stop() {
-			select {
-			case <-travelTimer.C:
-			default:
-			}
-		}
-		travelTimer.Reset(duration)
+		// Plan the run up to the first floor the car has to stop at.
+		e.startRun(action.Dir)
+		e.reregisterWaiting(e.Logic.Floor)
 
-		e.logger.Debug("Started Moving", "dir", action.Dir, "target", target)
+		e.logger.Debug("Started Moving", "dir", action.Dir, "target", action.Target)
 
 	case ActionStop:
 		// Logic decided to stop (idle).
@@ -481,63 +835,22 @@ func (e *Elevator) step(isMoving *bool, travelTimer *time.Timer) {
 
 	case ActionOpenDoor:
 		// Arrived at target or already at target.
-		e.handleArrival(action.Target) // Opens door, clears call
+		e.handleArrival(action.Target, action.Dir) // Opens door, clears call
 
 	case ActionNone:
 		// Do nothing
 	}
 }
 
-func (e *Elevator) handleMoveComplete() (bool, time.Duration) {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-
-	// 1. Physically move 1 floor
-	newFloor := e.Logic.Floor
-	if e.Logic.Direction == DirUp {
-		newFloor++
-	} else if e.Logic.Direction == DirDown {
-		newFloor--
-	}
-	e.setFloor(newFloor)
-
-	// 2. Ask logic what to do next *at this floor*
-	// Logic might say "Open Door" (if call exists here) or "Continue Move".
-
-	// We check logic *again*.
-	// But Logic.DecideNextStep will see we are at newFloor.
-	action := e.Logic.DecideNextStep()
-
-	switch action.Type {
-	case ActionOpenDoor:
-		// We should stop here.
-		e.handleArrival(e.Logic.Floor)
-		return false, 0
-
-	case ActionMove:
-		// Continue moving
-		// Check if direction changed?
-		if action.Dir != e.Logic.Direction {
-			// Change direction -> Stop first?
-			// Simplified: Update dir and continue.
-			e.setDirection(action.Dir)
-		}
-		return true, e.Config.TravelTime
-
-	default:
-		// ActionNone or Stop -> Stop
-		e.setDirection(DirNone)
-		return false, 0
-	}
-}
-
-func (e *Elevator) handleArrival(floor int) {
-	e.logger.Info("Arrived at floor", "floor", floor)
+func (e *Elevator) handleArrival(floor int, dir Direction) {
+	e.logger.Info("Arrived at floor", "floor", floor, "serve", dir)
 
 	// Determine Open Side from Config
-	openSide := Front // Default
-	if cfg, ok := e.Config.FloorConfigs[floor]; ok {
-		openSide = cfg.OpenDoorSide
+	openSide := e.openSide(floor)
+
+	if e.Mode == ModeFireService {
+		e.fireServiceArrival(floor, openSide)
+		return
 	}
 
 	// Update Doors
@@ -548,8 +861,13 @@ func (e *Elevator) handleArrival(floor int) {
 		e.setDoor(Rear, DoorOpening)
 	}
 
-	// Remove Call logic
-	e.Logic.RemoveCall(floor)
+	// Clear the car call and the hall call answered in 'dir'.
+	// At a reversal point the car now travels the other way.
+	e.Logic.ServeFloor(floor, dir)
+	e.servedDir = dir
+	if dir != DirNone {
+		e.setDirection(dir)
+	}
 
 	// Publish Arrived
 	e.publishEvent(EventArrived, ArrivedPayload{
@@ -563,17 +881,18 @@ func (e *Elevator) handleArrival(floor int) {
 	// The original code set timer for 'DoorSpeed'.
 
 	e.openWaitTime = e.Config.DoorOpenTime
-	e.doorTimer.Reset(e.Config.DoorSpeed)
+	e.armDoorTimer(e.Config.DoorSpeed)
 }
 
 func (e *Elevator) handleDoorTimeout() {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-
 	state := e.Logic.Doors[Front]
 	if state == DoorClose {
 		state = e.Logic.Doors[Rear]
 	}
+	if e.hasFault(FaultDoorJam) && (state == DoorOpening || state == DoorClosing) {
+		e.logger.Warn("Door jammed", "state", state)
+		return
+	}
 
 	switch state {
 	case DoorOpening:
@@ -584,40 +903,91 @@ func (e *Elevator) handleDoorTimeout() {
 		if e.Logic.Doors[Rear] == DoorOpening {
 			e.setDoor(Rear, DoorOpen)
 		}
+		if e.Mode.fireService() {
+			e.fireServiceDoorsOpen()
+			return
+		}
+		if e.Mode == ModeEmergency {
+			e.rescueDoorsOpen()
+			return
+		}
+
+		// Passengers alight and board while the doors are open.
+		e.exchangePassengers(e.Logic.Floor)
+		if e.Mode == ModeMoving {
+			e.logger.Info("Doors OPEN, held until the close button is pressed")
+			return
+		}
+
 		// Hold for openWaitTime
 		e.logger.Info("Doors OPEN", "hold", e.openWaitTime)
-		e.doorTimer.Reset(e.openWaitTime)
+		e.armDoorTimer(e.openWaitTime)
 
 	case DoorOpen:
+		if e.Mode.fireService() || e.Mode == ModeEmergency {
+			return // no automatic closing on fire service or after a rescue
+		}
 		// Try to close
 		// Check overload
-		if e.Config.MaxWeight > 0 && e.Logic.Weight > e.Config.MaxWeight {
+		if e.overloaded() {
 			e.logger.Warn("Overloaded, holding doors")
-			e.doorTimer.Reset(e.openWaitTime)
+			e.armDoorTimer(e.openWaitTime)
+			return
+		}
+		// Check door lock (the car could not leave)
+		if e.hasFault(FaultDoorLock) {
+			e.armDoorTimer(e.openWaitTime)
 			return
 		}
 
 		// Check button (isOpenButtonPressed)
 		if e.isOpenButtonPressed {
 			e.logger.Debug("Button pressed, holding doors")
-			e.doorTimer.Reset(e.Config.DoorReopenTime)
+			e.armDoorTimer(e.Config.DoorReopenTime)
 			return
 		}
 
-		// Close
-		if e.Logic.Doors[Front] == DoorOpen {
-			e.setDoor(Front, DoorClosing)
-		}
-		if e.Logic.Doors[Rear] == DoorOpen {
-			e.setDoor(Rear, DoorClosing)
+		// Check door sensor (hold, or nudge once held too long)
+		if e.holdObstructed() {
+			return
 		}
-		e.doorTimer.Reset(e.Config.DoorSpeed)
+
+		// Close
+		e.closeDoors()
 
 	case DoorClosing:
+		if e.obstructionActive() {
+			e.reopenDoors()
+			return
+		}
 		// Transition to Close
 		e.setDoor(Front, DoorClose)
 		e.setDoor(Rear, DoorClose)
+		e.stopNudging()
 		e.logger.Info("Doors Closed")
 		// Triggers run loop to move if needed
 	}
 }
+
+// closeDoors starts closing every open door.
+func (e *Elevator) closeDoors() {
+	if e.Logic.Doors[Front] == DoorOpen {
+		e.setDoor(Front, DoorClosing)
+	}
+	if e.Logic.Doors[Rear] == DoorOpen {
+		e.setDoor(Rear, DoorClosing)
+	}
+	e.armDoorTimer(e.doorCloseTime())
+}
+
+// reopenDoors reopens closing doors; the next door timeout finds them open.
+func (e *Elevator) reopenDoors() {
+	e.stopNudging()
+	if e.Logic.Doors[Front] == DoorClosing {
+		e.setDoor(Front, DoorOpening)
+	}
+	if e.Logic.Doors[Rear] == DoorClosing {
+		e.setDoor(Rear, DoorOpening)
+	}
+	e.armDoorTimer(e.Config.DoorSpeed)
+}
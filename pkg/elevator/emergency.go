@@ -0,0 +1,155 @@
+package elevator
+
+import "math"
+
+// Emergency stop event types.
+const (
+	EventEmergencyStop  EventType = "EmergencyStop"
+	EventEmergencyReset EventType = "EmergencyReset"
+	EventRescueStarted  EventType = "RescueStarted"
+	EventRescueComplete EventType = "RescueComplete"
+)
+
+// levelTolerance is the distance in m within which a car counts as level.
+const levelTolerance = 1e-6
+
+// EmergencyPhase is the stage of the emergency stop lifecycle.
+// EmergencyPhase는 비상 정지 이후 복구 단계를 나타냅니다.
+type EmergencyPhase string
+
+const (
+	EmergencyNone    EmergencyPhase = ""        // 비상 정지 아님
+	EmergencyLatched EmergencyPhase = "Latched" // 정지 후 고장 래치 (리셋 필요)
+	EmergencyReset   EmergencyPhase = "Reset"   // 리셋 확인됨, 구출 운전 가능
+	EmergencyRescue  EmergencyPhase = "Rescue"  // 가까운 층으로 저속 구출 운전 중
+	EmergencyRescued EmergencyPhase = "Rescued" // 구출 층에서 문 열림
+)
+
+func (p EmergencyPhase) valid() bool {
+	switch p {
+	case EmergencyNone, EmergencyLatched, EmergencyReset, EmergencyRescue, EmergencyRescued:
+		return true
+	}
+	return false
+}
+
+// EmergencyPayload carries detail for emergency stop and rescue events.
+// EmergencyPayload는 비상 정지·구출 이벤트의 세부 정보를 담고 있습니다.
+type EmergencyPayload struct {
+	Floor int     // 마지막으로 지난 층 (구출 완료 시 도착 층)
+	Level float64 // 층 단위 위치 (층 사이면 소수)
+}
+
+// EmergencyPhase returns the stage of the emergency stop lifecycle.
+func (e *Elevator) EmergencyPhase() EmergencyPhase {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.emergency
+}
+
+// ResetEmergency acknowledges the fault latched by an emergency stop. The
+// car stays stopped; it may then be rescued or returned to another mode.
+func (e *Elevator) ResetEmergency() (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.rejected("ResetEmergency", e.Logic.Floor, &err)
+	if e.Mode != ModeEmergency {
+		return errorf(ErrModeForbids, "car %s is not on emergency stop", e.Config.ID)
+	}
+	if e.emergency != EmergencyLatched {
+		return errorf(ErrState, "car %s emergency fault is already reset", e.Config.ID)
+	}
+	e.emergency = EmergencyReset
+	e.logger.Warn("Emergency fault reset")
+	e.publishEmergency(EventEmergencyReset)
+	return nil
+}
+
+// Rescue moves a reset car at inspection speed to the nearest landing and
+// opens its doors there. A car already level with a floor opens its doors.
+func (e *Elevator) Rescue() (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.rejected("Rescue", e.Logic.Floor, &err)
+	switch {
+	case e.Mode != ModeEmergency:
+		return errorf(ErrModeForbids, "car %s is not on emergency stop", e.Config.ID)
+	case e.emergency == EmergencyLatched:
+		return errorf(ErrState, "car %s emergency fault is not reset", e.Config.ID)
+	case e.emergency != EmergencyReset:
+		return errorf(ErrState, "car %s rescue already %s", e.Config.ID, e.emergency)
+	}
+	if e.offLevel != 0 {
+		if err := e.motionFault(); err != nil {
+			return err
+		}
+	}
+	e.emergency = EmergencyRescue
+	e.logger.Warn("Rescue started")
+	e.publishEmergency(EventRescueStarted)
+	if e.offLevel != 0 {
+		e.relevel()
+		return nil
+	}
+	e.rescueLevel()
+	return nil
+}
+
+// startEmergency stops the car at once, wherever it is, and latches the fault.
+func (e *Elevator) startEmergency() {
+	e.logger.Warn("Emergency Stop Activated")
+	e.stopDoorTimer()
+	e.halt()
+	e.emergency = EmergencyLatched
+	e.publishEmergency(EventEmergencyStop)
+}
+
+// halt stops a run or jog at once and leaves the car where it is, which may
+// be between floors.
+func (e *Elevator) halt() {
+	if e.run != nil {
+		pos := e.position().Position
+		e.stopTravelTimer()
+		e.run = nil
+		e.isMoving = false
+		e.offLevel = pos - e.floorPosition(e.Logic.Floor)
+		if math.Abs(e.offLevel) < levelTolerance {
+			e.offLevel = 0 // stopped right at a floor
+		}
+	}
+	e.stopJog()
+	e.setDirection(DirNone) // Updates logic and publishes event
+}
+
+// endEmergency returns the car to service after an emergency stop.
+func (e *Elevator) endEmergency() {
+	e.emergency = EmergencyNone
+	e.stopJog()
+	if e.Mode == ModeAuto && e.doorState() == DoorOpen {
+		e.armDoorTimer(e.openWaitTime)
+	}
+}
+
+// rescueLevel opens the doors of a rescued car level with a floor.
+func (e *Elevator) rescueLevel() {
+	if e.doorState() == DoorOpen {
+		e.rescueDoorsOpen()
+		return
+	}
+	e.moveDoors(DoorOpening)
+}
+
+// rescueDoorsOpen runs when the doors have opened at the rescue landing;
+// every rider leaves the car.
+func (e *Elevator) rescueDoorsOpen() {
+	floor := e.Logic.Floor
+	e.evacuate(floor)
+	e.emergency = EmergencyRescued
+	e.logger.Warn("Rescue complete", "floor", floor)
+	e.publishEmergency(EventRescueComplete)
+}
+
+func (e *Elevator) publishEmergency(t EventType) {
+	pos := e.position()
+	e.publishEvent(t, EmergencyPayload{Floor: e.Logic.Floor, Level: pos.Level})
+}
@@ -0,0 +1,100 @@
+package elevator
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestElevator_EmergencyRescue(t *testing.T) {
+	sim := NewSimulation(testEpoch)
+	defer sim.Close()
+	e, err := sim.NewElevator(Config{
+		ID: "E", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+		TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second,
+		InspectionSpeed: 0.5,
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+
+	var lifecycle []EventType
+	var evacuated []int
+	var stopped EmergencyPayload
+	e.OnEvent(func(ev Event) {
+		switch ev.Type {
+		case EventEmergencyStop:
+			stopped = ev.Payload.(EmergencyPayload)
+			fallthrough
+		case EventEmergencyReset, EventRescueStarted, EventRescueComplete:
+			lifecycle = append(lifecycle, ev.Type)
+		case EventPassengerAlighted:
+			t.Errorf("passenger alighted at %d, want evacuated", ev.Payload.(PassengerPayload).Floor)
+		case EventPassengerEvacuated:
+			evacuated = append(evacuated, ev.Payload.(PassengerPayload).Floor)
+		}
+	})
+
+	sim.At(0, func() {
+		if err := e.AddPassenger(&Passenger{ID: "P1", Origin: 1, Destination: 8}); err != nil {
+			t.Errorf("AddPassenger: %v", err)
+		}
+	})
+	// The doors close at about 5s; travelling up at 1 floor/s the car is
+	// just past 4 at 8.3s, so it is rescued down to 4.
+	sim.At(8300*time.Millisecond, func() {
+		e.SetMode(ModeEmergency)
+		e.SetMode(ModeAuto)
+		if m := e.CurrentMode(); m != ModeEmergency {
+			t.Errorf("mode after unacknowledged SetMode = %s, want Emergency", m)
+		}
+		if err := e.Rescue(); err == nil {
+			t.Error("Rescue before reset succeeded, want error")
+		}
+	})
+	sim.At(20*time.Second, func() {
+		if p := e.Position(); p.Speed != 0 || p.Level == float64(e.Floor()) {
+			t.Errorf("after emergency stop position = %+v, want stopped between floors", p)
+		}
+		if err := e.ResetEmergency(); err != nil {
+			t.Errorf("ResetEmergency: %v", err)
+		}
+		if err := e.Rescue(); err != nil {
+			t.Errorf("Rescue: %v", err)
+		}
+	})
+	sim.At(40*time.Second, func() {
+		if p := e.Position(); p.Level != float64(e.Floor()) || e.Door(Front) != DoorOpen {
+			t.Errorf("after rescue level %.3f floor %d door %s, want level with doors open", p.Level, e.Floor(), e.Door(Front))
+		}
+		if ph := e.EmergencyPhase(); ph != EmergencyRescued {
+			t.Errorf("phase = %q, want Rescued", ph)
+		}
+		e.SetMode(ModeAuto)
+	})
+	if err := sim.RunFor(context.Background(), time.Minute); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+
+	want := []EventType{EventEmergencyStop, EventEmergencyReset, EventRescueStarted, EventRescueComplete}
+	if len(lifecycle) != len(want) {
+		t.Fatalf("lifecycle = %v, want %v", lifecycle, want)
+	}
+	for i := range want {
+		if lifecycle[i] != want[i] {
+			t.Errorf("lifecycle = %v, want %v", lifecycle, want)
+			break
+		}
+	}
+	if stopped.Level == float64(stopped.Floor) {
+		t.Errorf("EmergencyStop at level %.2f, want between floors", stopped.Level)
+	}
+	if len(evacuated) != 1 || evacuated[0] != 4 {
+		t.Errorf("evacuated at %v, want [4]", evacuated)
+	}
+	if e.CurrentMode() != ModeAuto || e.EmergencyPhase() != EmergencyNone {
+		t.Errorf("mode %s phase %q after return to service", e.CurrentMode(), e.EmergencyPhase())
+	}
+}
@@ -0,0 +1,92 @@
+package elevator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode classifies why a command was rejected.
+// ErrorCode는 명령이 거부된 이유를 분류합니다.
+//
+// An ErrorCode is an error itself: errors returned by the engine wrap one,
+// so errors.Is(err, ErrOutOfRange) tests the class and ErrorCodeOf extracts it.
+type ErrorCode string
+
+const (
+	ErrOutOfRange   ErrorCode = "OutOfRange"   // 층·카 번호가 범위 밖
+	ErrInaccessible ErrorCode = "Inaccessible" // 서비스하지 않는 층
+	ErrModeForbids  ErrorCode = "ModeForbids"  // 현재 운행 모드에서 허용되지 않음
+	ErrOverload     ErrorCode = "Overload"     // 과부하
+	ErrInvalid      ErrorCode = "Invalid"      // 잘못된 인자
+	ErrState        ErrorCode = "State"        // 현재 상태에서 수행할 수 없음
+	ErrFault        ErrorCode = "Fault"        // 장치 고장
+	ErrUnavailable  ErrorCode = "Unavailable"  // 응답할 수 있는 카 없음
+)
+
+func (c ErrorCode) Error() string {
+	switch c {
+	case ErrOutOfRange:
+		return "out of range"
+	case ErrInaccessible:
+		return "inaccessible"
+	case ErrModeForbids:
+		return "not allowed in the current mode"
+	case ErrOverload:
+		return "overloaded"
+	case ErrInvalid:
+		return "invalid argument"
+	case ErrState:
+		return "not possible in the current state"
+	case ErrFault:
+		return "equipment fault"
+	case ErrUnavailable:
+		return "no car available"
+	}
+	return string(c)
+}
+
+// ErrorCodeOf returns the code wrapped by err, or "" if there is none.
+func ErrorCodeOf(err error) ErrorCode {
+	var code ErrorCode
+	if errors.As(err, &code) {
+		return code
+	}
+	return ""
+}
+
+// ErrorPayload carries detail for error and fault cleared events. An error
+// event is published for every command the car rejects, and for every
+// injected fault.
+// ErrorPayload는 거부된 명령과 고장 발생·해제 이벤트의 세부 정보를 담고 있습니다.
+type ErrorPayload struct {
+	Code    ErrorCode
+	Op      string // 거부된 명령 (고장 발생·해제면 빈 값)
+	Floor   int    // 명령 대상 층 (없으면 카의 현재 층)
+	Message string
+	Fault   *Fault // 고장 발생·해제 시
+}
+
+// codedError is an error message classified by a code.
+type codedError struct {
+	code ErrorCode
+	msg  string
+}
+
+func (e *codedError) Error() string { return e.msg }
+func (e *codedError) Unwrap() error { return e.code }
+
+// errorf formats an error message classified by code.
+func errorf(code ErrorCode, format string, args ...any) error {
+	return &codedError{code: code, msg: fmt.Sprintf(format, args...)}
+}
+
+// rejected publishes an error event when *errp holds the error of command op
+// on floor. It is deferred by the commands while the lock is held.
+func (e *Elevator) rejected(op string, floor int, errp *error) {
+	err := *errp
+	if err == nil {
+		return
+	}
+	e.logger.Warn("Command rejected", "op", op, "floor", floor, "err", err)
+	e.publishEvent(EventError, ErrorPayload{Code: ErrorCodeOf(err), Op: op, Floor: floor, Message: err.Error()})
+}
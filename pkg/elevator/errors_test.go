@@ -0,0 +1,66 @@
+package elevator
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestElevator_RejectedCommands(t *testing.T) {
+	sim := NewSimulation(testEpoch)
+	defer sim.Close()
+	e, err := sim.NewElevator(Config{
+		ID: "R", MinFloor: 1, MaxFloor: 10, InitialFloor: 1, MaxWeight: 600,
+		TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second,
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+
+	var got []ErrorPayload
+	e.OnEvent(func(ev Event) {
+		if ev.Type == EventError {
+			got = append(got, ev.Payload.(ErrorPayload))
+		}
+	})
+
+	tests := []struct {
+		name  string
+		run   func() error
+		code  ErrorCode
+		op    string
+		floor int
+	}{
+		{"floor out of range", func() error { return e.AddCall(11, CallCar) }, ErrOutOfRange, "AddCall", 11},
+		{"up call at top floor", func() error { return e.AddCall(10, CallHallUp) }, ErrInvalid, "AddCall", 10},
+		{"jog outside inspection", func() error { return e.PressJog(DirUp) }, ErrModeForbids, "PressJog", 1},
+		{"reset without emergency", func() error { return e.ResetEmergency() }, ErrModeForbids, "ResetEmergency", 1},
+		{"clear inactive fault", func() error { return e.ClearFault(FaultDriveTrip) }, ErrState, "ClearFault", 1},
+		{"invalid mode", func() error { return e.SetMode(OperationMode(42)) }, ErrInvalid, "SetMode", 1},
+		{"passenger too heavy", func() error {
+			return e.AddPassenger(&Passenger{ID: "p1", Origin: 3, Destination: 5, Mass: 700})
+		}, ErrOverload, "AddPassenger", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			err := tt.run()
+			if !errors.Is(err, tt.code) || ErrorCodeOf(err) != tt.code {
+				t.Fatalf("err = %v (code %q), want code %q", err, ErrorCodeOf(err), tt.code)
+			}
+			if len(got) != 1 {
+				t.Fatalf("%d error events, want 1", len(got))
+			}
+			if p := got[0]; p.Code != tt.code || p.Op != tt.op || p.Floor != tt.floor || p.Message != err.Error() {
+				t.Errorf("payload = %+v, want code %s op %s floor %d message %q", p, tt.code, tt.op, tt.floor, err)
+			}
+		})
+	}
+
+	got = nil
+	if err := e.AddCall(5, CallCar); err != nil || len(got) != 0 {
+		t.Errorf("valid call: err %v, %d error events, want none", err, len(got))
+	}
+}
@@ -0,0 +1,249 @@
+package elevator
+
+import "sort"
+
+// EventFaultCleared is published when an injected fault is cleared.
+// A fault being raised publishes EventError.
+const EventFaultCleared EventType = "FaultCleared"
+
+// DefaultLevelingError is the distance in m a car stops off level on a
+// leveling fault when Fault.Offset is 0.
+const DefaultLevelingError = 0.05
+
+// FaultKind identifies an injectable equipment failure.
+// FaultKind는 주입할 수 있는 장치 고장의 종류입니다.
+type FaultKind string
+
+const (
+	FaultDoorJam         FaultKind = "DoorJam"         // 문 모터 고착: 여닫히던 문이 그 자리에서 멈춤
+	FaultDoorLock        FaultKind = "DoorLock"        // 도어 잠금 불량: 출발 불가, 문을 열고 대기
+	FaultDriveTrip       FaultKind = "DriveTrip"       // 구동 장치 트립: 즉시 정지
+	FaultLeveling        FaultKind = "Leveling"        // 착상 오차: 층 사이에 정지, 문 열지 않음
+	FaultOverspeed       FaultKind = "Overspeed"       // 과속: 조속기·비상 정지 장치 작동
+	FaultPositionSensor  FaultKind = "PositionSensor"  // 위치 센서 상실: 즉시 정지, 복구 후 보정 운전
+	FaultStuckHallButton FaultKind = "StuckHallButton" // 홀 버튼 고착: 한 번 응답 후 버튼 무시
+)
+
+// FaultKinds lists every fault kind.
+var FaultKinds = []FaultKind{
+	FaultDoorJam, FaultDoorLock, FaultDriveTrip, FaultLeveling,
+	FaultOverspeed, FaultPositionSensor, FaultStuckHallButton,
+}
+
+func (k FaultKind) valid() bool {
+	for _, v := range FaultKinds {
+		if k == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Fault is an equipment failure injected into a car.
+// Fault는 카에 주입된 장치 고장입니다.
+type Fault struct {
+	Kind   FaultKind
+	Floor  int       // StuckHallButton: 버튼이 고착된 층
+	Dir    Direction // StuckHallButton: 고착된 버튼 방향
+	Offset float64   // Leveling: 착상 오차 m (0이면 DefaultLevelingError)
+}
+
+// InjectFault injects a failure into the car and publishes EventError. The
+// car responds with the safe behaviour of the fault until ClearFault:
+//
+//   - DoorJam: doors opening or closing stop where they are, and the next
+//     door movement jams if none is in progress. The car cannot leave.
+//   - DoorLock: the car does not depart; it opens its doors at the landing and
+//     keeps them open. A run in progress completes.
+//   - DriveTrip, Overspeed: the car stops at once, possibly between floors,
+//     and does not move, not even on inspection.
+//   - Leveling: the car stops Offset past each floor it stops at and keeps
+//     its doors closed. Once cleared it relevels and opens them.
+//   - PositionSensor: the car stops at once and does not move. Once cleared
+//     it makes a correction run at inspection speed to the lowest floor.
+//   - StuckHallButton: the call of the button is registered once; after it is
+//     answered the button is ignored, so it cannot call the car back forever.
+//
+// A car with any fault but StuckHallButton is unavailable to its group.
+// Only one fault of each kind can be active.
+func (e *Elevator) InjectFault(f Fault) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.rejected("InjectFault", e.Logic.Floor, &err)
+
+	switch {
+	case !f.Kind.valid():
+		return errorf(ErrInvalid, "unknown fault %q", f.Kind)
+	case f.Kind == FaultStuckHallButton && (f.Floor < e.Config.MinFloor || f.Floor > e.Config.MaxFloor):
+		return errorf(ErrOutOfRange, "stuck hall button floor %d out of range", f.Floor)
+	case f.Kind == FaultStuckHallButton && f.Dir != DirUp && f.Dir != DirDown:
+		return errorf(ErrInvalid, "invalid stuck hall button direction %q", f.Dir)
+	case f.Offset < 0:
+		return errorf(ErrInvalid, "negative leveling error %.3f", f.Offset)
+	}
+	if _, ok := e.faults[f.Kind]; ok {
+		return errorf(ErrState, "car %s fault %s is already active", e.Config.ID, f.Kind)
+	}
+	e.faults[f.Kind] = f
+	e.logger.Error("Fault injected", "fault", f.Kind)
+	e.publishEvent(EventError, ErrorPayload{Code: ErrFault, Floor: e.Logic.Floor, Message: f.Kind.message(), Fault: &f})
+
+	switch f.Kind {
+	case FaultDoorJam:
+		if s := e.doorState(); s == DoorOpening || s == DoorClosing {
+			e.stopDoorTimer()
+		}
+	case FaultDriveTrip, FaultOverspeed, FaultPositionSensor:
+		e.halt()
+	case FaultStuckHallButton:
+		if e.checkCall(HallCallType(f.Dir)) == nil {
+			if err := e.Logic.AddCall(f.Floor, HallCallType(f.Dir)); err != nil {
+				e.logger.Warn("Stuck hall button call rejected", "floor", f.Floor, "err", err)
+			}
+		}
+	}
+	return nil
+}
+
+// ClearFault clears an injected fault and publishes EventFaultCleared.
+func (e *Elevator) ClearFault(kind FaultKind) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.rejected("ClearFault", e.Logic.Floor, &err)
+
+	f, ok := e.faults[kind]
+	if !ok {
+		return errorf(ErrState, "car %s fault %s is not active", e.Config.ID, kind)
+	}
+	delete(e.faults, kind)
+	e.logger.Info("Fault cleared", "fault", kind)
+	e.publishEvent(EventFaultCleared, ErrorPayload{Code: ErrFault, Floor: e.Logic.Floor, Message: kind.message() + " cleared", Fault: &f})
+
+	switch kind {
+	case FaultDoorJam:
+		if s := e.doorState(); s == DoorOpening || s == DoorClosing {
+			e.armDoorTimer(e.Config.DoorSpeed) // finish the interrupted movement
+		}
+	case FaultPositionSensor:
+		e.correction = true
+	case FaultStuckHallButton:
+		if e.dispatching() {
+			e.reregisterAll()
+		}
+	}
+	return nil
+}
+
+// ActiveFaults returns the faults injected and not cleared, by kind.
+func (e *Elevator) ActiveFaults() []Fault {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.activeFaults()
+}
+
+func (e *Elevator) activeFaults() []Fault {
+	out := make([]Fault, 0, len(e.faults))
+	for _, f := range e.faults {
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Kind < out[j].Kind })
+	return out
+}
+
+func (k FaultKind) message() string {
+	switch k {
+	case FaultDoorJam:
+		return "door motor jammed"
+	case FaultDoorLock:
+		return "door lock not proven"
+	case FaultDriveTrip:
+		return "drive tripped"
+	case FaultLeveling:
+		return "leveling error"
+	case FaultOverspeed:
+		return "overspeed, governor tripped"
+	case FaultPositionSensor:
+		return "floor position sensor lost"
+	case FaultStuckHallButton:
+		return "hall button stuck"
+	}
+	return string(k)
+}
+
+func (e *Elevator) hasFault(kind FaultKind) bool {
+	_, ok := e.faults[kind]
+	return ok
+}
+
+// outOfService reports whether a fault takes the car out of group service.
+func (e *Elevator) outOfService() bool {
+	for kind := range e.faults {
+		if kind != FaultStuckHallButton {
+			return true
+		}
+	}
+	return false
+}
+
+// motionFault returns an error if a fault keeps the car from moving.
+func (e *Elevator) motionFault() error {
+	for _, kind := range []FaultKind{FaultDriveTrip, FaultOverspeed, FaultPositionSensor, FaultDoorLock} {
+		if e.hasFault(kind) {
+			return errorf(ErrFault, "car %s cannot move: %s", e.Config.ID, kind.message())
+		}
+	}
+	return nil
+}
+
+// faultStep enacts the safe behaviour of the active faults on a tick of a
+// dispatching car and reports whether it took the tick.
+func (e *Elevator) faultStep() bool {
+	switch {
+	case e.hasFault(FaultDriveTrip), e.hasFault(FaultOverspeed), e.hasFault(FaultPositionSensor):
+		return true
+	case e.hasFault(FaultDoorLock):
+		if e.offLevel == 0 && e.doorState() == DoorClose {
+			e.logger.Warn("Door lock fault, opening doors at landing", "floor", e.Logic.Floor)
+			e.moveDoors(DoorOpening)
+		}
+		return true
+	case e.hasFault(FaultLeveling) && e.offLevel != 0:
+		return true
+	case e.correction:
+		if e.doorState() == DoorClose {
+			e.correction = false
+			e.startCorrection()
+		}
+		return true
+	}
+	return false
+}
+
+// startCorrection moves the car at inspection speed to the lowest floor to
+// find its position again after the position sensor was lost.
+func (e *Elevator) startCorrection() {
+	if e.Logic.Floor == e.Config.MinFloor && e.offLevel == 0 {
+		return
+	}
+	e.logger.Info("Correction run", "floor", e.Config.MinFloor)
+	e.startJog(DirDown, e.Config.MinFloor)
+}
+
+// levelingError returns how far past the floor a car stops on a leveling
+// fault, 0 without one.
+func (e *Elevator) levelingError() float64 {
+	f, ok := e.faults[FaultLeveling]
+	switch {
+	case !ok:
+		return 0
+	case f.Offset > 0:
+		return f.Offset
+	}
+	return DefaultLevelingError
+}
+
+// stuckButton reports whether the hall button for callType at floor is stuck.
+func (e *Elevator) stuckButton(floor int, callType CallType) bool {
+	f, ok := e.faults[FaultStuckHallButton]
+	return ok && callType != CallCar && f.Floor == floor && HallCallType(f.Dir) == callType
+}
@@ -0,0 +1,157 @@
+package elevator
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestElevator_Faults(t *testing.T) {
+	stopped := func(t *testing.T, e *Elevator) {
+		if p := e.Position(); p.Speed != 0 || p.Level == math.Trunc(p.Level) {
+			t.Errorf("position = %+v, want stopped between floors", p)
+		}
+	}
+	tests := []struct {
+		name   string
+		fault  Fault
+		at     time.Duration // injected at; cleared at 20s
+		check  func(t *testing.T, e *Elevator)
+		lowest int // lowest floor visited after clearing
+	}{
+		{"door jam", Fault{Kind: FaultDoorJam}, 0, func(t *testing.T, e *Elevator) {
+			if f, d := e.Floor(), e.Door(Front); f != 6 || d != DoorOpening {
+				t.Errorf("floor %d door %s, want doors stuck opening at 6", f, d)
+			}
+		}, 6},
+		{"door lock", Fault{Kind: FaultDoorLock}, 0, func(t *testing.T, e *Elevator) {
+			if f, d := e.Floor(), e.Door(Front); f != 1 || d != DoorOpen {
+				t.Errorf("floor %d door %s, want parked open at 1", f, d)
+			}
+		}, 1},
+		{"drive trip", Fault{Kind: FaultDriveTrip}, 2500 * time.Millisecond, stopped, 3},
+		{"overspeed", Fault{Kind: FaultOverspeed}, 2500 * time.Millisecond, func(t *testing.T, e *Elevator) {
+			stopped(t, e)
+			e.SetMode(ModeManual)
+			if err := e.PressJog(DirUp); err == nil {
+				t.Error("PressJog with the governor tripped succeeded, want error")
+			}
+			e.SetMode(ModeAuto)
+			_ = e.AddCall(6, CallCar) // cancelled on inspection
+		}, 3},
+		{"leveling", Fault{Kind: FaultLeveling, Offset: 0.1}, 0, func(t *testing.T, e *Elevator) {
+			if p, d := e.Position(), e.Door(Front); e.Floor() != 6 || p.Level == 6 || d != DoorClose {
+				t.Errorf("level %.3f door %s, want stopped off level at 6 with doors closed", p.Level, d)
+			}
+		}, 6},
+		{"position sensor", Fault{Kind: FaultPositionSensor}, 2500 * time.Millisecond, stopped, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := NewSimulation(testEpoch)
+			defer sim.Close()
+			e, err := sim.NewElevator(Config{
+				ID: "F", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+				TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second,
+				InspectionSpeed: 0.5,
+			}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+			if err != nil {
+				t.Fatalf("NewElevator: %v", err)
+			}
+
+			var raised, cleared int
+			lowest := e.Config.MaxFloor
+			e.OnEvent(func(ev Event) {
+				switch ev.Type {
+				case EventError:
+					if ev.Payload.(ErrorPayload).Fault != nil {
+						raised++
+					}
+				case EventFaultCleared:
+					cleared++
+				case EventFloorChange:
+					if ev.Timestamp.After(testEpoch.Add(20 * time.Second)) {
+						lowest = min(lowest, ev.Payload.(int))
+					}
+				}
+			})
+
+			sim.At(0, func() { _ = e.AddCall(6, CallCar) })
+			sim.At(tt.at, func() {
+				if err := e.InjectFault(tt.fault); err != nil {
+					t.Errorf("InjectFault: %v", err)
+				}
+				if err := e.InjectFault(tt.fault); err == nil {
+					t.Error("second InjectFault of the same kind succeeded, want error")
+				}
+			})
+			sim.At(20*time.Second, func() {
+				tt.check(t, e)
+				if e.Available() {
+					t.Error("faulty car available for hall calls")
+				}
+				if err := e.ClearFault(tt.fault.Kind); err != nil {
+					t.Errorf("ClearFault: %v", err)
+				}
+				lowest = e.Floor()
+			})
+			if err := sim.RunFor(context.Background(), time.Minute); err != nil {
+				t.Fatalf("RunFor: %v", err)
+			}
+
+			if raised != 1 || cleared != 1 {
+				t.Errorf("%d Error and %d FaultCleared events, want 1 each", raised, cleared)
+			}
+			if p, d := e.Position(), e.Door(Front); e.Floor() != 6 || p.Level != 6 || d != DoorClose {
+				t.Errorf("after clearing: floor %d level %.3f door %s, want level at 6 with doors closed", e.Floor(), p.Level, d)
+			}
+			if lowest != tt.lowest {
+				t.Errorf("lowest floor after clearing = %d, want %d", lowest, tt.lowest)
+			}
+		})
+	}
+}
+
+func TestElevator_StuckHallButton(t *testing.T) {
+	sim := NewSimulation(testEpoch)
+	defer sim.Close()
+	e, err := sim.NewElevator(Config{
+		ID: "F", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+		TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second,
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+	if err := e.InjectFault(Fault{Kind: FaultStuckHallButton, Floor: 11, Dir: DirUp}); err == nil {
+		t.Error("stuck button out of range succeeded, want error")
+	}
+
+	sim.At(0, func() {
+		if err := e.InjectFault(Fault{Kind: FaultStuckHallButton, Floor: 4, Dir: DirUp}); err != nil {
+			t.Errorf("InjectFault: %v", err)
+		}
+	})
+	sim.At(30*time.Second, func() {
+		if f := e.Floor(); f != 4 {
+			t.Errorf("floor = %d, want 4 after answering the stuck button", f)
+		}
+		if !e.Available() {
+			t.Error("car with a stuck hall button unavailable, want available")
+		}
+		if err := e.AddCall(4, CallHallUp); err == nil {
+			t.Error("call from the stuck button accepted, want error")
+		}
+		if err := e.AddCall(4, CallHallDown); err != nil {
+			t.Errorf("call from the other button: %v", err)
+		}
+	})
+	if err := sim.RunFor(context.Background(), time.Minute); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+	if got := e.ActiveFaults(); len(got) != 1 || got[0].Kind != FaultStuckHallButton {
+		t.Errorf("ActiveFaults = %v, want the stuck button", got)
+	}
+}
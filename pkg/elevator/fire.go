@@ -0,0 +1,249 @@
+package elevator
+
+// Fire service event types.
+const (
+	EventSmokeDetector  EventType = "SmokeDetector"
+	EventRecallStarted  EventType = "RecallStarted"
+	EventRecallComplete EventType = "RecallComplete"
+)
+
+// RecallConfig sets the floors a car returns to on fire service Phase I recall.
+// RecallConfig는 소방 1차 운전(Phase I) 시 귀환할 층을 설정합니다.
+type RecallConfig struct {
+	Floor          int // 지정 귀환 층
+	AlternateFloor int // 대체 귀환 층 (지정 귀환 층의 감지기가 작동한 경우)
+}
+
+// SmokeDetectorPayload carries detail for smoke detector events.
+// SmokeDetectorPayload는 연기 감지기 이벤트의 세부 정보를 담고 있습니다.
+type SmokeDetectorPayload struct {
+	Floor  int
+	Active bool
+}
+
+// RecallPayload carries detail for recall events.
+// RecallPayload는 소방 귀환 이벤트의 세부 정보를 담고 있습니다.
+type RecallPayload struct {
+	Floor     int
+	Alternate bool // 대체 귀환 층으로 귀환
+}
+
+// fireService reports whether m is Phase I recall or Phase II operation.
+func (m OperationMode) fireService() bool {
+	return m == ModeFireRecall || m == ModeFireService
+}
+
+// SetSmokeDetector reports the smoke detector on floor as active or cleared.
+//
+// An active detector starts Phase I recall of a car in automatic or moving
+// service. If the detector is on the designated recall floor, the car returns
+// to the alternate floor instead, even if it is already on its way. Clearing a
+// detector does not end recall; the car stays on fire service until its mode
+// is changed.
+func (e *Elevator) SetSmokeDetector(floor int, active bool) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.rejected("SetSmokeDetector", floor, &err)
+
+	if floor < e.Config.MinFloor || floor > e.Config.MaxFloor {
+		return errorf(ErrOutOfRange, "floor %d out of range", floor)
+	}
+	if e.smoke[floor] == active {
+		return nil
+	}
+	if active {
+		e.smoke[floor] = true
+		e.logger.Warn("Smoke detector activated", "floor", floor)
+	} else {
+		delete(e.smoke, floor)
+		e.logger.Info("Smoke detector cleared", "floor", floor)
+	}
+	e.publishEvent(EventSmokeDetector, SmokeDetectorPayload{Floor: floor, Active: active})
+
+	if !active {
+		return nil
+	}
+	switch e.Mode {
+	case ModeAuto, ModeMoving:
+		e.setMode(ModeFireRecall)
+	case ModeFireRecall:
+		if floor == e.recallFloor {
+			e.startRecall()
+		}
+	}
+	return nil
+}
+
+// SmokeDetectors returns the floors whose smoke detectors are active.
+func (e *Elevator) SmokeDetectors() []int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return sortedFloors(e.smoke)
+}
+
+// recall returns the recall floors, defaulting to the initial floor.
+func (e *Elevator) recall() RecallConfig {
+	if r := e.Config.Recall; r != nil {
+		return *r
+	}
+	return RecallConfig{Floor: e.Config.InitialFloor, AlternateFloor: e.Config.InitialFloor}
+}
+
+// checkCall rejects the calls the current mode does not take.
+func (e *Elevator) checkCall(t CallType) error {
+	switch {
+	case e.Mode == ModeFireRecall:
+		return errorf(ErrModeForbids, "car %s is on fire service recall", e.Config.ID)
+	case e.Mode == ModeFireService && t != CallCar:
+		return errorf(ErrModeForbids, "car %s takes car calls only on fire service", e.Config.ID)
+	case e.Mode == ModeManual:
+		return errorf(ErrModeForbids, "car %s is on inspection", e.Config.ID)
+	case e.Mode == ModeMoving && t != CallCar:
+		return errorf(ErrModeForbids, "car %s takes car calls only on independent service", e.Config.ID)
+	}
+	return nil
+}
+
+// startRecall cancels every call and sends the car non-stop to the recall
+// floor, where it parks with its doors open. A car travelling away from the
+// recall floor stops at the nearest floor it still can and reverses without
+// opening its doors.
+func (e *Elevator) startRecall() {
+	recall := e.recall()
+	floor, alternate := recall.Floor, false
+	if e.smoke[floor] {
+		floor, alternate = recall.AlternateFloor, true
+	}
+	e.recallFloor = floor
+	e.isOpenButtonPressed = false
+	e.isCloseButtonPressed = false
+	e.Logic.ClearCalls()
+	e.logger.Warn("Fire service recall", "floor", floor, "alternate", alternate)
+	e.publishEvent(EventRecallStarted, RecallPayload{Floor: floor, Alternate: alternate})
+
+	state := e.doorState()
+	if !e.isMoving && e.Logic.Floor == floor && state != DoorClose {
+		switch state {
+		case DoorOpen:
+			e.stopDoorTimer()
+			e.publishEvent(EventRecallComplete, RecallPayload{Floor: floor, Alternate: alternate})
+		case DoorClosing:
+			e.moveDoors(DoorOpening)
+		}
+		return // DoorOpening completes the recall when the doors are open
+	}
+
+	// Door reopening devices are disabled: doors open elsewhere close now.
+	if state == DoorOpen || state == DoorOpening {
+		e.moveDoors(DoorClosing)
+	}
+	if err := e.Logic.AddCall(floor, CallCar); err != nil {
+		e.logger.Error("Recall floor rejected", "floor", floor, "err", err)
+	}
+}
+
+// startFireService enters Phase II: hall calls are cancelled and the doors
+// are operated by constant pressure on the door buttons only.
+func (e *Elevator) startFireService() {
+	e.Logic.ClearCalls()
+	e.isOpenButtonPressed = false
+	e.isCloseButtonPressed = false
+	if e.doorState() == DoorOpen {
+		e.stopDoorTimer()
+	}
+}
+
+// endFireService returns the car to normal service after fire service.
+func (e *Elevator) endFireService() {
+	e.recallFloor = 0
+	if e.Mode != ModeAuto {
+		return
+	}
+	if e.doorState() == DoorOpen {
+		e.armDoorTimer(e.openWaitTime)
+	}
+	e.reregisterAll()
+}
+
+// fireServiceArrival stops the car on Phase II. The doors stay closed until
+// the firefighter opens them, and the remaining car calls are cancelled.
+func (e *Elevator) fireServiceArrival(floor int, openSide DoorSide) {
+	e.Logic.ClearCalls()
+	e.setDirection(DirNone)
+	e.publishEvent(EventArrived, ArrivedPayload{Floor: floor, OpenDoorSide: openSide})
+}
+
+// fireServiceDoorsOpen runs when the doors have fully opened on fire service.
+// There is no automatic closing in either phase.
+func (e *Elevator) fireServiceDoorsOpen() {
+	floor := e.Logic.Floor
+	if e.Mode == ModeFireService {
+		e.alightPassengers(floor)
+		return
+	}
+	// Phase I: everybody leaves the car at the recall floor.
+	e.evacuate(floor)
+	if floor == e.recallFloor {
+		recall := e.recall()
+		e.publishEvent(EventRecallComplete, RecallPayload{Floor: floor, Alternate: floor != recall.Floor})
+	}
+}
+
+// fireServiceDoors applies the door buttons on fire service. On Phase I they
+// are ignored. On Phase II doors move only while a button is held and reverse
+// if it is released before they finish.
+func (e *Elevator) fireServiceDoors() {
+	if e.Mode != ModeFireService || e.isMoving {
+		return
+	}
+	switch state := e.doorState(); {
+	case e.isCloseButtonPressed && (state == DoorOpen || state == DoorOpening):
+		e.moveDoors(DoorClosing)
+	case e.isOpenButtonPressed && !e.isCloseButtonPressed && (state == DoorClose || state == DoorClosing):
+		e.moveDoors(DoorOpening)
+	case !e.isOpenButtonPressed && state == DoorOpening:
+		e.moveDoors(DoorClosing)
+	case !e.isCloseButtonPressed && state == DoorClosing:
+		e.moveDoors(DoorOpening)
+	}
+}
+
+// doorState returns the state of the front door, or of the rear door when the
+// front one is closed.
+func (e *Elevator) doorState() DoorState {
+	if state := e.Logic.Doors[Front]; state != DoorClose {
+		return state
+	}
+	return e.Logic.Doors[Rear]
+}
+
+// moveDoors starts opening the doors of the current floor, or closing every
+// door that is not closed, and arms the door timer.
+func (e *Elevator) moveDoors(state DoorState) {
+	for _, side := range []DoorSide{Front, Rear} {
+		if state == DoorOpening && e.openSide(e.Logic.Floor)&side != 0 ||
+			state == DoorClosing && e.Logic.Doors[side] != DoorClose {
+			e.setDoor(side, state)
+		}
+	}
+	e.armDoorTimer(e.Config.DoorSpeed)
+}
+
+// openSide returns the doors that open at floor.
+func (e *Elevator) openSide(floor int) DoorSide {
+	if cfg, ok := e.Config.FloorConfigs[floor]; ok {
+		return cfg.OpenDoorSide
+	}
+	return Front
+}
+
+// SetSmokeDetector reports a smoke detector to every car of the group.
+// 연기 감지기 신호는 건물 전체에 적용되므로 모든 카에 전달됩니다.
+func (g *Group) SetSmokeDetector(floor int, active bool) error {
+	for _, car := range g.cars {
+		if err := car.SetSmokeDetector(floor, active); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package elevator
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newFireTestCar(t *testing.T, sim *Simulation) *Elevator {
+	t.Helper()
+	e, err := sim.NewElevator(Config{
+		ID: "F", MinFloor: 1, MaxFloor: 10, InitialFloor: 3,
+		TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second,
+		Recall: &RecallConfig{Floor: 1, AlternateFloor: 2},
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+	return e
+}
+
+func TestElevator_FireRecall(t *testing.T) {
+	tests := []struct {
+		name      string
+		smokeAt   time.Duration
+		smoke     int
+		wantFloor int
+		wantTurn  int // highest floor reached
+	}{
+		// Travelling up 3 -> 9 between 4 and 5, too late to stop at 5: stops
+		// at 6, reverses with the doors closed and returns non-stop.
+		{"moving away", 1500 * time.Millisecond, 7, 1, 6},
+		// Smoke on the recall floor sends the car to the alternate floor.
+		{"alternate floor", 1500 * time.Millisecond, 1, 2, 6},
+		// Doors open at 9 when the detector trips: they close and the car leaves.
+		{"doors open", 8 * time.Second, 4, 1, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := NewSimulation(testEpoch)
+			defer sim.Close()
+			e := newFireTestCar(t, sim)
+
+			var stops []int
+			turn := 0
+			var opened []int
+			var complete *RecallPayload
+			tripped := false
+			e.OnEvent(func(ev Event) {
+				if ev.Type == EventFloorChange {
+					turn = max(turn, ev.Payload.(int))
+				}
+				if !tripped {
+					return
+				}
+				switch ev.Type {
+				case EventArrived:
+					stops = append(stops, ev.Payload.(ArrivedPayload).Floor)
+				case EventDoorChange:
+					if ev.Payload.(DoorChangePayload).State == DoorOpening {
+						opened = append(opened, e.Logic.Floor)
+					}
+				case EventRecallComplete:
+					p := ev.Payload.(RecallPayload)
+					complete = &p
+				}
+			})
+			sim.At(0, func() { _ = e.AddCall(9, CallCar) })
+			sim.At(tt.smokeAt, func() {
+				tripped = true
+				if err := e.SetSmokeDetector(tt.smoke, true); err != nil {
+					t.Errorf("SetSmokeDetector: %v", err)
+				}
+				if err := e.AddCall(8, CallCar); err == nil {
+					t.Error("AddCall during recall succeeded, want error")
+				}
+			})
+			if err := sim.RunFor(context.Background(), time.Minute); err != nil {
+				t.Fatal(err)
+			}
+
+			if e.CurrentMode() != ModeFireRecall {
+				t.Errorf("mode = %v, want FireRecall", e.CurrentMode())
+			}
+			if !equalInts(stops, []int{tt.wantFloor}) || turn != tt.wantTurn {
+				t.Errorf("turned at %d and stopped at %v, want %d and [%d]", turn, stops, tt.wantTurn, tt.wantFloor)
+			}
+			if !equalInts(opened, []int{tt.wantFloor}) {
+				t.Errorf("doors opened at %v, want only %d", opened, tt.wantFloor)
+			}
+			if complete == nil || complete.Floor != tt.wantFloor {
+				t.Errorf("RecallComplete = %+v, want floor %d", complete, tt.wantFloor)
+			}
+			if e.Floor() != tt.wantFloor || e.Door(Front) != DoorOpen {
+				t.Errorf("parked at %d with doors %s, want %d open", e.Floor(), e.Door(Front), tt.wantFloor)
+			}
+		})
+	}
+}
+
+func TestElevator_FireService(t *testing.T) {
+	ctx := context.Background()
+	sim := NewSimulation(testEpoch)
+	defer sim.Close()
+	e := newFireTestCar(t, sim)
+	run := func(d time.Duration) {
+		t.Helper()
+		if err := sim.RunFor(ctx, d); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	e.SetMode(ModeFireRecall)
+	run(20 * time.Second)
+	e.SetMode(ModeFireService)
+	run(20 * time.Second)
+	if e.Door(Front) != DoorOpen {
+		t.Fatalf("doors = %s after entering Phase II, want to stay open", e.Door(Front))
+	}
+
+	// Releasing the close button before the doors have closed reopens them.
+	e.PressCloseButton()
+	run(500 * time.Millisecond)
+	e.ReleaseCloseButton()
+	run(2 * time.Second)
+	if e.Door(Front) != DoorOpen {
+		t.Fatalf("doors = %s after early release, want open", e.Door(Front))
+	}
+
+	if err := e.AddCall(4, CallHallDown); err == nil {
+		t.Error("hall call on Phase II succeeded, want error")
+	}
+	if err := e.AddCall(4, CallCar); err != nil {
+		t.Fatalf("car call on Phase II: %v", err)
+	}
+	_ = e.AddCall(6, CallCar)
+	e.PressCloseButton()
+	run(2 * time.Second)
+	e.ReleaseCloseButton()
+	run(20 * time.Second)
+
+	// The car stops at 4 with its doors closed and the call to 6 cancelled.
+	if e.Floor() != 4 || e.Door(Front) != DoorClose || e.HasCall(6, CallCar) {
+		t.Fatalf("floor %d doors %s call 6 %v, want 4 closed and no call", e.Floor(), e.Door(Front), e.HasCall(6, CallCar))
+	}
+
+	// Constant pressure: the doors open only while the button is held.
+	e.PressOpenButton()
+	run(500 * time.Millisecond)
+	e.ReleaseOpenButton()
+	run(2 * time.Second)
+	if e.Door(Front) != DoorClose {
+		t.Fatalf("doors = %s after early release, want closed", e.Door(Front))
+	}
+	e.PressOpenButton()
+	run(2 * time.Second)
+	e.ReleaseOpenButton()
+	run(10 * time.Second)
+	if e.Door(Front) != DoorOpen {
+		t.Fatalf("doors = %s, want open", e.Door(Front))
+	}
+
+	// Back in automatic service the doors close on their own.
+	e.SetMode(ModeAuto)
+	run(10 * time.Second)
+	if e.Door(Front) != DoorClose {
+		t.Errorf("doors = %s after return to Auto, want closed", e.Door(Front))
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
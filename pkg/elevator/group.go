@@ -0,0 +1,459 @@
+package elevator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// AssignmentPolicy decides which car of a Group answers a hall call.
+// AssignmentPolicy는 그룹 내에서 홀 호출에 응답할 카를 결정합니다.
+//
+// Assign returns the index of the chosen car in cars, or -1 if no car can take the call.
+type AssignmentPolicy interface {
+	Name() string
+	Assign(cars []*Elevator, floor int, dir Direction) int
+}
+
+// Built-in assignment policy names.
+const (
+	PolicyETA     = "eta"
+	PolicyNearest = "nearest"
+)
+
+// NewAssignmentPolicy returns the built-in policy registered under name.
+// An empty name selects the ETA policy.
+func NewAssignmentPolicy(name string) (AssignmentPolicy, error) {
+	switch name {
+	case "", PolicyETA:
+		return ETAPolicy{}, nil
+	case PolicyNearest:
+		return NearestCarPolicy{}, nil
+	}
+	return nil, fmt.Errorf("unknown assignment policy %q", name)
+}
+
+// NearestCarPolicy assigns the call to the closest available car.
+type NearestCarPolicy struct{}
+
+func (NearestCarPolicy) Name() string { return PolicyNearest }
+
+func (NearestCarPolicy) Assign(cars []*Elevator, floor int, dir Direction) int {
+	best, bestDist := -1, 0
+	for i, car := range cars {
+		if !car.Available() {
+			continue
+		}
+		if dist := abs(car.Floor() - floor); best < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
+// ETAPolicy assigns the call to the available car with the shortest estimated time of arrival.
+type ETAPolicy struct{}
+
+func (ETAPolicy) Name() string { return PolicyETA }
+
+func (ETAPolicy) Assign(cars []*Elevator, floor int, dir Direction) int {
+	best := -1
+	var bestETA time.Duration
+	for i, car := range cars {
+		if !car.Available() {
+			continue
+		}
+		if eta := car.EstimateArrival(floor, dir); best < 0 || eta < bestETA {
+			best, bestETA = i, eta
+		}
+	}
+	return best
+}
+
+// GroupConfig holds the configuration of a bank of cars.
+// GroupConfig는 여러 대의 카로 구성된 엘리베이터 뱅크의 설정입니다.
+type GroupConfig struct {
+	ID     string
+	Cars   int              // 카 대수
+	Car    Config           // 카 공통 설정 (ID는 "<GroupID>-<번호>"로 부여)
+	Policy AssignmentPolicy // 홀 호출 할당 정책 (nil이면 ETA)
+}
+
+// GroupEvent is an Event tagged with the index of the car that produced it.
+// GroupEvent는 이벤트를 발생시킨 카의 번호가 붙은 Event입니다.
+type GroupEvent struct {
+	Car int
+	Event
+}
+
+// Group is a group controller owning several cars that share one shaft range.
+// Group은 같은 층 범위를 운행하는 여러 대의 카를 관리하는 군관리 제어기입니다.
+type Group struct {
+	ID string
+
+	mu     sync.Mutex
+	cars   []*Elevator
+	policy AssignmentPolicy
+
+	subMu  sync.Mutex           // 그룹 구독 목록과 전달을 보호
+	events *GroupSubscription   // Events 채널
+	subs   []*GroupSubscription // Subscribe로 연 구독
+	logger *slog.Logger
+}
+
+// GroupSubscribeOptions configures a group subscription.
+// GroupSubscribeOptions는 그룹 이벤트 구독 설정입니다.
+type GroupSubscribeOptions struct {
+	SubscribeOptions
+	Cars []int // 받을 카 번호 (비어 있으면 전부)
+}
+
+// GroupSubscription is a merged event stream of its own for one consumer of
+// a Group. The backpressure policies behave as for a Subscription; with
+// CoalesceLatest, each car's states are coalesced separately.
+// GroupSubscription은 그룹 소비자 한 명 전용의 병합 이벤트 스트림입니다.
+type GroupSubscription struct {
+	stream[GroupEvent]
+}
+
+// groupStateKey identifies the state of one car a group event reports.
+type groupStateKey struct {
+	Car int
+	stateKey
+}
+
+// NewGroup creates a group of identical cars. opts are applied to every car.
+func NewGroup(cfg GroupConfig, opts ...Option) (*Group, error) {
+	if cfg.Cars < 1 {
+		return nil, fmt.Errorf("invalid group config: Cars (%d) < 1", cfg.Cars)
+	}
+	if cfg.Policy == nil {
+		cfg.Policy = ETAPolicy{}
+	}
+
+	// The group reads its cars through subscriptions; their shared Events
+	// channel would only fill up unless opts enable it again.
+	opts = append([]Option{WithEventBuffer(0)}, opts...)
+	cars := make([]*Elevator, 0, cfg.Cars)
+	for i := 0; i < cfg.Cars; i++ {
+		carCfg := cfg.Car
+		carCfg.ID = fmt.Sprintf("%s-%d", cfg.ID, i+1)
+		car, err := New(carCfg, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create car %d: %w", i+1, err)
+		}
+		cars = append(cars, car)
+	}
+
+	g := &Group{
+		ID:     cfg.ID,
+		cars:   cars,
+		policy: cfg.Policy,
+		logger: slog.Default().With("group", cfg.ID),
+	}
+	g.events = g.newSubscription(GroupSubscribeOptions{SubscribeOptions: SubscribeOptions{Buffer: 1000 * cfg.Cars, Policy: DropNewest}})
+	for _, car := range cars {
+		car.onLeave = g.rehome
+	}
+	g.logger.Info("Group initialized", "cars", cfg.Cars, "policy", cfg.Policy.Name())
+	return g, nil
+}
+
+// Cars returns the cars of the group in index order.
+func (g *Group) Cars() []*Elevator {
+	return append([]*Elevator(nil), g.cars...)
+}
+
+// Car returns the car at index i.
+func (g *Group) Car(i int) (*Elevator, error) {
+	if i < 0 || i >= len(g.cars) {
+		return nil, errorf(ErrOutOfRange, "car %d out of range", i)
+	}
+	return g.cars[i], nil
+}
+
+// Events returns the shared merged event stream of every car while Run is
+// active. Events are dropped when it is full, and concurrent receivers split
+// the stream between them; a consumer that needs every event of its own uses
+// Subscribe.
+func (g *Group) Events() <-chan GroupEvent {
+	return g.events.ch
+}
+
+// DroppedEventCount returns how many events the Events channel dropped
+// because it was full.
+func (g *Group) DroppedEventCount() uint64 {
+	return g.events.Dropped()
+}
+
+// Subscribe opens a new merged event stream, fed while Run is active. Every
+// subscription receives the events it selects independently of the others.
+func (g *Group) Subscribe(opts GroupSubscribeOptions) (*GroupSubscription, error) {
+	var err error
+	if opts.SubscribeOptions, err = opts.SubscribeOptions.normalize(); err != nil {
+		return nil, err
+	}
+	for _, i := range opts.Cars {
+		if i < 0 || i >= len(g.cars) {
+			return nil, errorf(ErrOutOfRange, "car %d out of range", i)
+		}
+	}
+	s := g.newSubscription(opts)
+	g.subMu.Lock()
+	defer g.subMu.Unlock()
+	g.subs = append(g.subs, s)
+	return s, nil
+}
+
+func (g *Group) newSubscription(opts GroupSubscribeOptions) *GroupSubscription {
+	types := typeSet(opts.Types)
+	var cars map[int]bool
+	if len(opts.Cars) > 0 {
+		cars = make(map[int]bool, len(opts.Cars))
+		for _, i := range opts.Cars {
+			cars[i] = true
+		}
+	}
+	s := &GroupSubscription{}
+	s.init(opts.SubscribeOptions, func(ev GroupEvent) bool {
+		return (types == nil || types[ev.Type]) && (cars == nil || cars[ev.Car])
+	}, func(ev GroupEvent) (any, bool) {
+		k, ok := coalesceKey(ev.Event)
+		return groupStateKey{Car: ev.Car, stateKey: k}, ok
+	})
+	return s
+}
+
+// Unsubscribe ends the subscription and closes its stream. Events still
+// buffered can be received before the close.
+func (g *Group) Unsubscribe(s *GroupSubscription) {
+	s.cancel()
+
+	g.subMu.Lock()
+	defer g.subMu.Unlock()
+	for i, sub := range g.subs {
+		if sub == s {
+			g.subs = append(g.subs[:i], g.subs[i+1:]...)
+			close(s.ch)
+			return
+		}
+	}
+}
+
+// publish hands ev to the Events channel and every subscription.
+func (g *Group) publish(ev GroupEvent) {
+	g.subMu.Lock()
+	defer g.subMu.Unlock()
+	if g.events.deliver(ev) > 0 && g.events.Dropped()%100 == 1 {
+		g.logger.Error("Event Channel Saturated", "dropped", g.events.Dropped())
+	}
+	for _, s := range g.subs {
+		if s.deliver(ev) > 0 && s.Dropped()%100 == 1 {
+			g.logger.Warn("Event subscriber saturated", "policy", s.policy, "dropped", s.Dropped())
+		}
+	}
+}
+
+// AddHallCall registers a hall call and assigns it to a car.
+// If a car already holds the same call, that car is returned.
+func (g *Group) AddHallCall(floor int, dir Direction) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	idx, err := g.assign(floor, dir)
+	if err != nil {
+		return -1, err
+	}
+	if err := g.cars[idx].AddCall(floor, HallCallType(dir)); err != nil {
+		return -1, err
+	}
+	return idx, nil
+}
+
+// AddPassenger assigns the passenger's hall call to a car and puts the
+// passenger on that car's landing queue.
+func (g *Group) AddPassenger(p *Passenger) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	idx, err := g.assign(p.Origin, p.Direction())
+	if err != nil {
+		return -1, err
+	}
+	if err := g.cars[idx].AddPassenger(p); err != nil {
+		return -1, err
+	}
+	return idx, nil
+}
+
+// assign picks the car for a hall call, reusing a car that already holds it.
+func (g *Group) assign(floor int, dir Direction) (int, error) {
+	callType := HallCallType(dir)
+	for i, car := range g.cars {
+		if car.HasCall(floor, callType) {
+			return i, nil
+		}
+	}
+
+	idx := g.policy.Assign(g.cars, floor, dir)
+	if idx < 0 {
+		return -1, errorf(ErrUnavailable, "no car available for hall call at floor %d", floor)
+	}
+	g.logger.Info("Hall call assigned", "floor", floor, "dir", dir, "car", idx)
+	return idx, nil
+}
+
+// RemoveHallCall cancels a hall call on whichever car holds it.
+func (g *Group) RemoveHallCall(floor int, dir Direction) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, car := range g.cars {
+		car.RemoveCall(floor, HallCallType(dir))
+	}
+}
+
+// AddCarCall registers a car call on car i.
+func (g *Group) AddCarCall(i, floor int) error {
+	car, err := g.Car(i)
+	if err != nil {
+		return err
+	}
+	return car.AddCall(floor, CallCar)
+}
+
+// HallCalls returns the hall calls pending across the group.
+func (g *Group) HallCalls() CallSet {
+	up := make(map[int]bool)
+	down := make(map[int]bool)
+	for _, car := range g.cars {
+		calls := car.CallFloors()
+		for _, f := range calls.HallUp {
+			up[f] = true
+		}
+		for _, f := range calls.HallDown {
+			down[f] = true
+		}
+	}
+	return CallSet{HallUp: sortedFloors(up), HallDown: sortedFloors(down)}
+}
+
+// OnEvent registers fn as a synchronous observer on every car.
+// See Elevator.OnEvent for the constraints on fn.
+func (g *Group) OnEvent(fn func(GroupEvent)) {
+	for i, car := range g.cars {
+		car.OnEvent(func(ev Event) { fn(GroupEvent{Car: i, Event: ev}) })
+	}
+}
+
+// Reset resets every car.
+func (g *Group) Reset() {
+	for _, car := range g.cars {
+		car.Reset()
+	}
+}
+
+// Run starts every car and merges their events until ctx is cancelled.
+func (g *Group) Run(ctx context.Context) error {
+	// Subscribe before any car starts, so the merged streams miss nothing.
+	// Block makes the relay lossless; each group subscription then applies
+	// its own policy.
+	subs := make([]*Subscription, len(g.cars))
+	for i, car := range g.cars {
+		sub, err := car.Subscribe(SubscribeOptions{Policy: Block})
+		if err != nil {
+			return err
+		}
+		defer car.Unsubscribe(sub)
+		subs[i] = sub
+	}
+
+	var wg sync.WaitGroup
+	for i, car := range g.cars {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := car.Run(ctx); err != nil && ctx.Err() == nil {
+				g.logger.Error("Car run error", "car", i, "error", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			g.forwardEvents(ctx, i, car, subs[i])
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// forwardEvents relays the events of car i from sub to the group streams
+// until ctx is cancelled, then ends sub.
+func (g *Group) forwardEvents(ctx context.Context, i int, car *Elevator, sub *Subscription) {
+	defer car.Unsubscribe(sub)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-sub.Events():
+			g.publish(GroupEvent{Car: i, Event: ev})
+		}
+	}
+}
+
+// estimateArrival estimates the time for a car in state l to reach floor and
+// answer a hall call in dir. Stops on the way are charged one door cycle each.
+func estimateArrival(l *ElevatorLogic, cfg Config, floor int, dir Direction) time.Duration {
+	dwell := 2*cfg.DoorSpeed + cfg.DoorOpenTime
+
+	// Route: the car either heads straight for floor, or must first finish
+	// its current sweep and come back.
+	route := []int{l.Floor, floor}
+	ahead := (l.Direction == DirUp && floor >= l.Floor) || (l.Direction == DirDown && floor <= l.Floor)
+	if l.Direction != DirNone && (!ahead || dir != l.Direction) {
+		far := l.Floor
+		for _, f := range l.pendingFloors() {
+			if (l.Direction == DirUp && f > far) || (l.Direction == DirDown && f < far) {
+				far = f
+			}
+		}
+		route = []int{l.Floor, far, floor}
+	}
+
+	var floors, stops int
+	pending := l.pendingFloors()
+	for i := 1; i < len(route); i++ {
+		from, to := route[i-1], route[i]
+		floors += abs(to - from)
+		lo, hi := min(from, to), max(from, to)
+		for _, f := range pending {
+			if f > lo && f < hi || f == to && to != floor {
+				stops++
+			}
+		}
+	}
+
+	// Each run between stops pays for accelerating and braking on top of the
+	// pass-through time per floor.
+	perFloor, perRun := cfg.TravelTime, time.Duration(0)
+	if cfg.TravelTimeEdge > cfg.TravelTime {
+		perRun = 2 * (cfg.TravelTimeEdge - cfg.TravelTime)
+	}
+	if m := cfg.Motion; m != nil {
+		height := m.FloorHeight
+		if height == 0 {
+			height = DefaultFloorHeight
+		}
+		perFloor = seconds(height / m.RatedSpeed)
+		perRun = seconds(m.RatedSpeed/m.Acceleration + m.Acceleration/m.Jerk)
+	}
+
+	eta := time.Duration(floors)*perFloor + time.Duration(stops)*(dwell+perRun)
+	if floors > 0 {
+		eta += perRun
+	}
+	if !l.AreDoorsClosed() {
+		eta += dwell
+	}
+	return eta
+}
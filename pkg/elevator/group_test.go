@@ -0,0 +1,246 @@
+package elevator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestGroup(t *testing.T, cars int, policy AssignmentPolicy) *Group {
+	t.Helper()
+	g, err := NewGroup(GroupConfig{
+		ID:     "G",
+		Cars:   cars,
+		Policy: policy,
+		Car: Config{
+			MinFloor:     1,
+			MaxFloor:     10,
+			InitialFloor: 1,
+			TravelTime:   time.Second,
+			DoorSpeed:    time.Second,
+			DoorOpenTime: 3 * time.Second,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewGroup: %v", err)
+	}
+	return g
+}
+
+func TestNewGroup_Invalid(t *testing.T) {
+	if _, err := NewGroup(GroupConfig{ID: "G", Cars: 0}); err == nil {
+		t.Error("Expected error for empty group, got nil")
+	}
+}
+
+func TestGroup_AddHallCall(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy AssignmentPolicy
+	}{
+		{"nearest", NearestCarPolicy{}},
+		{"eta", ETAPolicy{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGroup(t, 3, tt.policy)
+			g.cars[1].Logic.SetFloor(8)
+
+			car, err := g.AddHallCall(7, DirDown)
+			if err != nil {
+				t.Fatalf("AddHallCall: %v", err)
+			}
+			if car != 1 {
+				t.Errorf("Expected call assigned to car 1, got %d", car)
+			}
+
+			// The same call is not assigned twice.
+			again, _ := g.AddHallCall(7, DirDown)
+			if again != car {
+				t.Errorf("Expected duplicate call to stay on car %d, got %d", car, again)
+			}
+
+			hall := g.HallCalls()
+			if len(hall.HallDown) != 1 || hall.HallDown[0] != 7 {
+				t.Errorf("Unexpected group hall calls %+v", hall)
+			}
+		})
+	}
+}
+
+func TestGroup_SkipsUnavailableCars(t *testing.T) {
+	g := newTestGroup(t, 2, NearestCarPolicy{})
+	g.cars[0].SetMode(ModeManual)
+
+	car, err := g.AddHallCall(1, DirUp)
+	if err != nil {
+		t.Fatalf("AddHallCall: %v", err)
+	}
+	if car != 1 {
+		t.Errorf("Expected call assigned to car 1, got %d", car)
+	}
+
+	g.cars[1].SetMode(ModeManual)
+	if _, err := g.AddHallCall(5, DirUp); err == nil {
+		t.Error("Expected error when no car is available, got nil")
+	}
+}
+
+func TestEstimateArrival(t *testing.T) {
+	cfg := Config{TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second}
+	logic := NewElevatorLogic(LogicConfig{MinFloor: 1, MaxFloor: 10, InitialFloor: 2})
+
+	// Idle car: straight travel.
+	if got, want := estimateArrival(logic, cfg, 6, DirUp), 4*time.Second; got != want {
+		t.Errorf("idle ETA = %v, want %v", got, want)
+	}
+
+	// Moving up with a stop on the way: one door cycle is added.
+	logic.SetDirection(DirUp)
+	logic.AddCall(4, CallCar)
+	if got, want := estimateArrival(logic, cfg, 6, DirUp), 4*time.Second+5*time.Second; got != want {
+		t.Errorf("en-route ETA = %v, want %v", got, want)
+	}
+
+	// Call behind the car: it finishes the sweep to 4 then comes back to 1.
+	if got, want := estimateArrival(logic, cfg, 1, DirUp), 5*time.Second+5*time.Second; got != want {
+		t.Errorf("reversal ETA = %v, want %v", got, want)
+	}
+}
+
+func TestGroup_Subscribe(t *testing.T) {
+	clock := NewManualClock(testEpoch)
+	g, err := NewGroup(GroupConfig{
+		ID:   "G",
+		Cars: 2,
+		Car: Config{
+			MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+			TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second,
+		},
+	}, WithClock(clock), WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewGroup: %v", err)
+	}
+	if _, err := g.Subscribe(GroupSubscribeOptions{Cars: []int{2}}); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Subscribe to car 2: %v, want %s", err, ErrOutOfRange)
+	}
+	if _, err := g.Subscribe(GroupSubscribeOptions{SubscribeOptions: SubscribeOptions{Policy: "Sometimes"}}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Subscribe with an unknown policy: %v, want %s", err, ErrInvalid)
+	}
+
+	var mu sync.Mutex
+	perCar := make([][]Event, 2)
+	g.OnEvent(func(ev GroupEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		perCar[ev.Car] = append(perCar[ev.Car], ev.Event)
+	})
+	subscribe := func(opts GroupSubscribeOptions) *GroupSubscription {
+		s, err := g.Subscribe(opts)
+		if err != nil {
+			t.Fatalf("Subscribe(%+v): %v", opts, err)
+		}
+		return s
+	}
+	tiny := subscribe(GroupSubscribeOptions{SubscribeOptions: SubscribeOptions{Buffer: 1, Policy: DropNewest}})
+	second := subscribe(GroupSubscribeOptions{Cars: []int{1}})
+	floors := subscribe(GroupSubscribeOptions{SubscribeOptions: SubscribeOptions{Types: []EventType{EventFloorChange}}})
+	all := subscribe(GroupSubscribeOptions{SubscribeOptions: SubscribeOptions{Buffer: 1, Policy: Block}})
+
+	// A consumer reading car 0 directly no longer takes events from the group.
+	direct, err := g.cars[0].Subscribe(SubscribeOptions{})
+	if err != nil {
+		t.Fatalf("car Subscribe: %v", err)
+	}
+
+	var merged []GroupEvent
+	received := make(chan struct{})
+	go func() {
+		defer close(received)
+		for ev := range all.Events() {
+			merged = append(merged, ev)
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := make(chan error)
+	go func() { ran <- g.Run(ctx) }()
+	waitFor(t, func() bool {
+		for _, car := range g.cars {
+			car.mu.RLock()
+			ready := car.running && len(car.subs) > 0
+			car.mu.RUnlock()
+			if !ready {
+				return false
+			}
+		}
+		return true
+	})
+
+	g.AddCarCall(0, 3)
+	g.AddCarCall(1, 6)
+	clock.Advance(30 * time.Second)
+	mu.Lock()
+	total := len(perCar[0]) + len(perCar[1])
+	mu.Unlock()
+	waitFor(t, func() bool { return int(g.events.Dropped())+len(g.events.ch) == total })
+	cancel()
+	<-ran
+	for _, s := range []*GroupSubscription{tiny, second, floors, all} {
+		g.Unsubscribe(s)
+	}
+	g.cars[0].Unsubscribe(direct)
+	<-received
+
+	split := make([][]Event, 2)
+	for _, ev := range merged {
+		split[ev.Car] = append(split[ev.Car], ev.Event)
+	}
+	if !reflect.DeepEqual(split, perCar) {
+		t.Errorf("merged stream per car = %v, want %v", split, perCar)
+	}
+	var fromSecond, fromFloors, fromDirect int
+	for ev := range second.Events() {
+		if ev.Car != 1 {
+			t.Errorf("car filter passed an event of car %d", ev.Car)
+		}
+		fromSecond++
+	}
+	for ev := range floors.Events() {
+		if ev.Type != EventFloorChange {
+			t.Errorf("type filter passed a %s event", ev.Type)
+		}
+		fromFloors++
+	}
+	for range direct.Events() {
+		fromDirect++
+	}
+	if fromSecond != len(perCar[1]) || fromFloors == 0 || fromDirect != len(perCar[0]) {
+		t.Errorf("car 1 stream %d events, want %d; floor stream %d; direct car 0 stream %d, want %d",
+			fromSecond, len(perCar[1]), fromFloors, fromDirect, len(perCar[0]))
+	}
+	if got, want := tiny.Dropped(), uint64(total-1); got != want {
+		t.Errorf("tiny Dropped() = %d, want %d", got, want)
+	}
+	if all.Dropped() != 0 || second.Dropped() != 0 {
+		t.Errorf("dropped %d and %d events, want none", all.Dropped(), second.Dropped())
+	}
+}
+
+// waitFor polls cond until it holds, failing the test after a few seconds.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
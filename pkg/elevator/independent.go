@@ -0,0 +1,98 @@
+package elevator
+
+// Independent service (ModeMoving) takes a car out of group dispatch for a
+// single user, e.g. moving furniture: it serves car calls only and its doors
+// stay open at a stop until the close button is pressed.
+
+// startIndependent enters independent service. Hall calls are cancelled;
+// a car in a group keeps them in released for the group to reassign.
+func (e *Elevator) startIndependent() {
+	for _, c := range e.Logic.PendingCalls() {
+		if c.Type == CallCar {
+			continue
+		}
+		e.Logic.RemoveCall(c.Floor, c.Type)
+		if e.onLeave != nil {
+			e.released = append(e.released, c)
+		}
+	}
+	if e.doorState() == DoorOpen {
+		e.stopDoorTimer() // hold the doors open
+	}
+}
+
+// endIndependent returns the car to normal service after independent service.
+func (e *Elevator) endIndependent() {
+	e.released = nil
+	if e.Mode != ModeAuto {
+		return
+	}
+	if e.doorState() == DoorOpen {
+		e.armDoorTimer(e.openWaitTime)
+	}
+	e.reregisterAll()
+}
+
+// closeOnIndependent closes the doors on the close button unless the car is
+// overloaded, the open button is held or the door sensor is blocked.
+func (e *Elevator) closeOnIndependent() {
+	if e.doorState() != DoorOpen || e.isOpenButtonPressed || e.overloaded() || e.obstructionActive() {
+		return
+	}
+	e.closeDoors()
+}
+
+// handOver removes the hall calls the car released on leaving group service
+// and its waiting passengers, for the group to reassign.
+func (e *Elevator) handOver() ([]Call, []*Passenger) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	calls := e.released
+	e.released = nil
+	var waiting []*Passenger
+	for f := e.Config.MinFloor; f <= e.Config.MaxFloor; f++ {
+		waiting = append(waiting, e.waiting[f]...)
+	}
+	e.waiting = make(map[int][]*Passenger)
+	return calls, waiting
+}
+
+// requeue puts back a passenger handed over that no other car could take.
+func (e *Elevator) requeue(p *Passenger) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.waiting[p.Origin] = append(e.waiting[p.Origin], p)
+	e.registerHallCall(p)
+}
+
+// rehome reassigns the hall calls and waiting passengers of a car that left
+// group dispatch to the cars still available.
+func (g *Group) rehome(from *Elevator) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	calls, waiting := from.handOver()
+	for _, c := range calls {
+		dir := DirUp
+		if c.Type == CallHallDown {
+			dir = DirDown
+		}
+		idx, err := g.assign(c.Floor, dir)
+		if err == nil {
+			err = g.cars[idx].AddCall(c.Floor, c.Type)
+		}
+		if err != nil {
+			g.logger.Warn("Released hall call dropped", "car", from.Config.ID, "floor", c.Floor, "type", c.Type, "error", err)
+		}
+	}
+	for _, p := range waiting {
+		idx, err := g.assign(p.Origin, p.Direction())
+		if err == nil {
+			err = g.cars[idx].AddPassenger(p)
+		}
+		if err != nil {
+			g.logger.Warn("Waiting passenger kept", "car", from.Config.ID, "passenger", p.ID, "error", err)
+			from.requeue(p)
+		}
+	}
+}
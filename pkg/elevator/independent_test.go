@@ -0,0 +1,108 @@
+package elevator
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestElevator_IndependentService(t *testing.T) {
+	sim := NewSimulation(testEpoch)
+	defer sim.Close()
+	e, err := sim.NewElevator(Config{
+		ID: "M", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+		TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second,
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+
+	var closing []time.Duration
+	e.OnEvent(func(ev Event) {
+		if ev.Type == EventDoorChange && ev.Payload.(DoorChangePayload).State == DoorClosing {
+			closing = append(closing, ev.Timestamp.Sub(testEpoch))
+		}
+	})
+	door := func(at time.Duration, want DoorState) {
+		sim.At(at, func() {
+			if got := e.Door(Front); got != want {
+				t.Errorf("door at %v = %s, want %s", at, got, want)
+			}
+		})
+	}
+
+	sim.At(0, func() {
+		_ = e.AddCall(4, CallHallUp)
+		e.SetMode(ModeMoving)
+		if calls := e.CallFloors(); len(calls.HallUp) != 0 {
+			t.Errorf("hall calls after entering independent service = %v, want none", calls.HallUp)
+		}
+		if err := e.AddCall(6, CallHallDown); err == nil {
+			t.Error("hall call on independent service succeeded, want error")
+		}
+		if err := e.AddCall(5, CallCar); err != nil {
+			t.Errorf("car call: %v", err)
+		}
+	})
+	door(time.Minute, DoorOpen) // held at 5 until the close button
+	sim.At(time.Minute, func() { e.PressCloseButton() })
+	door(time.Minute+500*time.Millisecond, DoorClosing)
+
+	// Back on automatic service the doors close by themselves again.
+	sim.At(70*time.Second, func() { _ = e.AddCall(7, CallCar) })
+	sim.At(80*time.Second, func() { e.SetMode(ModeAuto) })
+	door(90*time.Second, DoorClose)
+
+	if err := sim.RunFor(context.Background(), 100*time.Second); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+	if len(closing) != 2 || closing[0] != time.Minute || closing[1] != 83*time.Second {
+		t.Errorf("doors started closing at %v, want [1m0s 1m23s]", closing)
+	}
+	if f := e.Floor(); f != 7 {
+		t.Errorf("floor = %d, want 7", f)
+	}
+}
+
+func TestGroup_IndependentServiceHandsOver(t *testing.T) {
+	g := newTestGroup(t, 2, NearestCarPolicy{})
+	for _, car := range g.cars {
+		car.logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	if _, err := g.AddHallCall(8, DirDown); err != nil {
+		t.Fatalf("AddHallCall: %v", err)
+	}
+	idx, err := g.AddPassenger(&Passenger{ID: "P1", Origin: 5, Destination: 2})
+	if err != nil {
+		t.Fatalf("AddPassenger: %v", err)
+	}
+	if idx != 0 {
+		t.Fatalf("passenger assigned to car %d, want 0", idx)
+	}
+
+	g.cars[0].SetMode(ModeMoving)
+
+	if calls := g.cars[0].CallFloors(); len(calls.HallUp)+len(calls.HallDown) != 0 || len(g.cars[0].Waiting()) != 0 {
+		t.Errorf("car 0 kept hall calls %v/%v and %d waiting", calls.HallUp, calls.HallDown, len(g.cars[0].Waiting()))
+	}
+	calls := g.cars[1].CallFloors()
+	if !equalInts(calls.HallDown, []int{5, 8}) {
+		t.Errorf("car 1 hall down calls = %v, want [5 8]", calls.HallDown)
+	}
+	if w := g.cars[1].Waiting(); len(w) != 1 || w[0].ID != "P1" {
+		t.Errorf("car 1 waiting = %v, want P1", w)
+	}
+
+	// With no car left, the waiting passenger stays with the car and calls
+	// again when it returns to service; the bare hall call is dropped.
+	g.cars[1].SetMode(ModeMoving)
+	if w := g.cars[1].Waiting(); len(w) != 1 {
+		t.Errorf("car 1 waiting = %d passengers, want 1 kept", len(w))
+	}
+	g.cars[1].SetMode(ModeAuto)
+	if calls := g.cars[1].CallFloors(); !equalInts(calls.HallDown, []int{5}) {
+		t.Errorf("car 1 hall down calls after resuming = %v, want [5]", calls.HallDown)
+	}
+}
@@ -0,0 +1,188 @@
+package elevator
+
+import "time"
+
+// DefaultInspectionSpeed is the jog speed in m/s used when
+// Config.InspectionSpeed is 0.
+const DefaultInspectionSpeed = 0.3
+
+// jogRun is a constant-speed inspection movement. It is split into segments
+// between floor levels so that floor changes are published as they are passed.
+// jogRun은 점검 운전(저속 수동 이동) 중인 이동 구간입니다.
+type jogRun struct {
+	dir    Direction
+	start  time.Time // 현재 구간 출발 시각
+	from   float64   // 현재 구간 출발 위치 m
+	next   int       // 다음에 도달할 층
+	target int       // 이 층에 도달하면 정지 (종단 층 또는 착상 층)
+}
+
+// inspectionSpeed returns the configured jog speed.
+func (e *Elevator) inspectionSpeed() float64 {
+	if e.Config.InspectionSpeed > 0 {
+		return e.Config.InspectionSpeed
+	}
+	return DefaultInspectionSpeed
+}
+
+// PressJog starts moving the car in dir at inspection speed. The car keeps
+// moving until ReleaseJog is called or it reaches the terminal floor in dir.
+//
+// Jogging is only possible on inspection (ModeManual) with the doors closed,
+// once the car has finished the run it was on when inspection started.
+func (e *Elevator) PressJog(dir Direction) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.rejected("PressJog", e.Logic.Floor, &err)
+
+	switch {
+	case e.Mode != ModeManual:
+		return errorf(ErrModeForbids, "car %s is not on inspection", e.Config.ID)
+	case dir != DirUp && dir != DirDown:
+		return errorf(ErrInvalid, "invalid jog direction %q", dir)
+	case e.run != nil:
+		return errorf(ErrState, "car %s is still completing its run", e.Config.ID)
+	case e.doorState() != DoorClose:
+		return errorf(ErrState, "car %s doors are not closed", e.Config.ID)
+	}
+	if err := e.motionFault(); err != nil {
+		return err
+	}
+	if j := e.jog; j != nil {
+		if j.dir == dir {
+			return nil
+		}
+		e.stopJog()
+	}
+
+	limit := e.Config.MaxFloor
+	if dir == DirDown {
+		limit = e.Config.MinFloor
+	}
+	if e.Logic.Floor == limit && e.offLevel == 0 {
+		return errorf(ErrState, "car %s is at the terminal limit", e.Config.ID)
+	}
+	e.logger.Info("Inspection jog", "dir", dir)
+	e.startJog(dir, limit)
+	return nil
+}
+
+// ReleaseJog stops an inspection jog. The car stops where it is, which may be
+// between floors.
+func (e *Elevator) ReleaseJog() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.jog == nil {
+		return
+	}
+	e.stopJog()
+	e.logger.Info("Inspection jog stopped", "floor", e.Logic.Floor, "off_level", e.offLevel)
+}
+
+// startJog starts moving in dir until the car is level with target.
+func (e *Elevator) startJog(dir Direction, target int) {
+	// The next floor level is the one the car stands at, or is just past,
+	// unless it already moved off it in dir.
+	next := e.Logic.Floor
+	if e.offLevel == 0 || (e.offLevel > 0) == (dir == DirUp) {
+		next += dir.delta()
+	}
+	e.jog = &jogRun{
+		dir:    dir,
+		start:  e.clock.Now(),
+		from:   e.floorPosition(e.Logic.Floor) + e.offLevel,
+		next:   next,
+		target: target,
+	}
+	e.isMoving = true
+	e.setDirection(dir)
+	e.armJogLevel()
+}
+
+// armJogLevel arms the travel timer for the car to be level with the next floor.
+func (e *Elevator) armJogLevel() {
+	j := e.jog
+	dist := e.floorPosition(j.next) - j.from
+	if dist < 0 {
+		dist = -dist
+	}
+	e.armTravelTimer(seconds(dist / e.inspectionSpeed()))
+}
+
+// handleJogLevel runs when a jogging car is level with the next floor.
+func (e *Elevator) handleJogLevel() {
+	j := e.jog
+	e.offLevel = 0
+	e.setFloor(j.next)
+	j.from, j.start = e.floorPosition(j.next), e.clock.Now()
+	if j.next == j.target {
+		e.stopJog()
+		e.logger.Info("Inspection jog stopped at floor", "floor", j.next)
+		if e.emergency == EmergencyRescue {
+			e.rescueLevel()
+		}
+		return
+	}
+	j.next += j.dir.delta()
+	e.publishPosition()
+	e.armJogLevel()
+}
+
+// stopJog stops a jog at the current position.
+func (e *Elevator) stopJog() {
+	if e.jog == nil {
+		return
+	}
+	pos := e.position().Position
+	e.stopTravelTimer()
+	e.jog = nil
+	e.isMoving = false
+	e.offLevel = pos - e.floorPosition(e.Logic.Floor)
+	e.setDirection(DirNone)
+	e.publishPosition()
+}
+
+// relevel moves a car left between floors, by inspection or an emergency
+// stop, to the nearest floor at inspection speed.
+func (e *Elevator) relevel() {
+	f := e.Logic.Floor
+	dir, target := DirDown, f
+	switch {
+	case e.offLevel > 0 && e.offLevel > e.floorHeight(f)/2:
+		dir, target = DirUp, f+1
+	case e.offLevel < 0 && -e.offLevel > e.floorHeight(f-1)/2:
+		target = f - 1
+	case e.offLevel < 0:
+		dir = DirUp
+	}
+	e.logger.Info("Releveling", "floor", target)
+	e.startJog(dir, target)
+}
+
+// startInspection enters inspection: calls are cancelled and the car stops
+// at the end of its current run without opening its doors.
+func (e *Elevator) startInspection() {
+	e.Logic.ClearCalls()
+}
+
+// endInspection stops any jog when the car leaves inspection. A car left
+// between floors relevels before it takes calls again.
+func (e *Elevator) endInspection() {
+	e.stopJog()
+	if e.Mode == ModeAuto {
+		e.reregisterAll()
+	}
+}
+
+// levelAt converts a height above the lowest floor to a fractional floor level.
+func (e *Elevator) levelAt(pos float64) float64 {
+	f := e.Config.MinFloor
+	for ; f < e.Config.MaxFloor; f++ {
+		h := e.floorHeight(f)
+		if pos < h {
+			break
+		}
+		pos -= h
+	}
+	return float64(f) + pos/e.floorHeight(f)
+}
@@ -0,0 +1,142 @@
+package elevator
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// newInspectionTestCar jogs at 0.5 m/s: 7s per 3.5m floor.
+func newInspectionTestCar(t *testing.T, sim *Simulation) *Elevator {
+	t.Helper()
+	e, err := sim.NewElevator(Config{
+		ID: "I", MinFloor: 1, MaxFloor: 10, InitialFloor: 3,
+		TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second,
+		InspectionSpeed: 0.5,
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+	return e
+}
+
+func TestElevator_InspectionJog(t *testing.T) {
+	sim := NewSimulation(testEpoch)
+	defer sim.Close()
+	e := newInspectionTestCar(t, sim)
+
+	var floors []int
+	opened := false
+	e.OnEvent(func(ev Event) {
+		switch ev.Type {
+		case EventFloorChange:
+			floors = append(floors, ev.Payload.(int))
+		case EventDoorChange:
+			opened = opened || ev.Payload.(DoorChangePayload).State == DoorOpening
+		}
+	})
+	ctx := context.Background()
+	run := func(d time.Duration) {
+		t.Helper()
+		if err := sim.RunFor(ctx, d); err != nil {
+			t.Fatalf("RunFor: %v", err)
+		}
+	}
+	jog := func(dir Direction) {
+		t.Helper()
+		if err := e.PressJog(dir); err != nil {
+			t.Fatalf("PressJog(%s): %v", dir, err)
+		}
+	}
+
+	if err := e.PressJog(DirUp); err == nil {
+		t.Error("PressJog outside inspection succeeded, want error")
+	}
+	e.SetMode(ModeManual)
+	if err := e.AddCall(5, CallCar); err == nil {
+		t.Error("AddCall on inspection succeeded, want error")
+	}
+
+	// Jog up 3 -> 4 and release halfway to 5.
+	jog(DirUp)
+	run(10500 * time.Millisecond)
+	e.ReleaseJog()
+	run(5 * time.Second)
+	if p := e.Position(); e.Floor() != 4 || p.Level != 4.5 || p.Speed != 0 {
+		t.Errorf("after release floor %d level %.2f speed %.2f, want 4 4.50 0", e.Floor(), p.Level, p.Speed)
+	}
+	if e.Direction() != DirNone {
+		t.Errorf("direction = %s, want None", e.Direction())
+	}
+
+	// Held up, the car stops at the terminal floor.
+	jog(DirUp)
+	run(time.Minute)
+	if p := e.Position(); e.Floor() != 10 || p.Level != 10 {
+		t.Errorf("at limit floor %d level %.2f, want 10", e.Floor(), p.Level)
+	}
+	if err := e.PressJog(DirUp); err == nil {
+		t.Error("PressJog beyond the terminal limit succeeded, want error")
+	}
+	e.ReleaseJog()
+
+	want := []int{4, 5, 6, 7, 8, 9, 10}
+	if !equalInts(floors, want) {
+		t.Errorf("floors = %v, want %v", floors, want)
+	}
+	if opened {
+		t.Error("doors opened on inspection")
+	}
+}
+
+func TestElevator_InspectionRelevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		release time.Duration // jog up from 3 for this long
+		want    int           // floor levelled to
+	}{
+		{"below half", 9 * time.Second, 4}, // 1m above 4
+		{"above half", 12 * time.Second, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := NewSimulation(testEpoch)
+			defer sim.Close()
+			e := newInspectionTestCar(t, sim)
+
+			var arrived []int
+			e.OnEvent(func(ev Event) {
+				if ev.Type == EventArrived {
+					arrived = append(arrived, ev.Payload.(ArrivedPayload).Floor)
+				}
+			})
+			sim.At(0, func() {
+				e.SetMode(ModeManual)
+				if err := e.PressJog(DirUp); err != nil {
+					t.Errorf("PressJog: %v", err)
+				}
+			})
+			sim.At(tt.release, func() {
+				e.ReleaseJog()
+				e.SetMode(ModeAuto)
+			})
+			sim.At(tt.release+5*time.Second, func() {
+				if p := e.Position(); e.Floor() != tt.want || p.Level != float64(tt.want) {
+					t.Errorf("after relevel floor %d level %.3f, want %d", e.Floor(), p.Level, tt.want)
+				}
+			})
+			sim.At(tt.release+10*time.Second, func() { _ = e.AddCall(8, CallCar) })
+			if err := sim.RunFor(context.Background(), time.Minute); err != nil {
+				t.Fatalf("RunFor: %v", err)
+			}
+			if p := e.Position(); p.Level != 8 {
+				t.Errorf("level = %.3f, want 8", p.Level)
+			}
+			if !equalInts(arrived, []int{8}) {
+				t.Errorf("arrived = %v, want [8]", arrived)
+			}
+		})
+	}
+}
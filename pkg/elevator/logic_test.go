@@ -1,6 +1,7 @@
 package elevator
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -32,16 +33,29 @@ func TestElevatorLogic_AddCall(t *testing.T) {
 	logic := NewElevatorLogic(cfg)
 
 	// Valid Call
-	err := logic.AddCall(3)
+	err := logic.AddCall(3, CallCar)
 	if err != nil {
 		t.Errorf("Failed to add valid call: %v", err)
 	}
-	if !logic.Calls[3] {
+	if !logic.CarCalls[3] {
 		t.Errorf("Call at 3 not registered")
 	}
 
+	// Hall calls are kept apart from car calls
+	if err := logic.AddCall(3, CallHallDown); err != nil {
+		t.Errorf("Failed to add hall call: %v", err)
+	}
+	if !logic.HallDownCalls[3] || logic.HallUpCalls[3] {
+		t.Errorf("Hall down call at 3 not registered separately")
+	}
+
+	// Invalid Call (No up call at top floor)
+	if err := logic.AddCall(5, CallHallUp); err == nil {
+		t.Error("Expected error for up hall call at top floor, got nil")
+	}
+
 	// Invalid Call (Out of range)
-	err = logic.AddCall(6)
+	err = logic.AddCall(6, CallCar)
 	if err == nil {
 		t.Error("Expected error for out-of-range call, got nil")
 	}
@@ -51,7 +65,7 @@ func TestElevatorLogic_AddCall(t *testing.T) {
 		2: {FloorNumber: 2, IsAccessible: false},
 	}
 	logic = NewElevatorLogic(cfg)
-	err = logic.AddCall(2)
+	err = logic.AddCall(2, CallCar)
 	if err == nil {
 		t.Error("Expected error for inaccessible floor, got nil")
 	}
@@ -66,7 +80,7 @@ func TestElevatorLogic_DecideNextStep_SCAN(t *testing.T) {
 	logic := NewElevatorLogic(cfg)
 
 	// Scenario 1: Idle, Call above -> Move Up
-	logic.AddCall(8)
+	logic.AddCall(8, CallCar)
 	action := logic.DecideNextStep()
 	if action.Type != ActionMove || action.Dir != DirUp || action.Target != 8 {
 		t.Errorf("Scenario 1 failed: Expected Move Up to 8, got %v", action)
@@ -76,8 +90,8 @@ func TestElevatorLogic_DecideNextStep_SCAN(t *testing.T) {
 	logic = NewElevatorLogic(cfg) // Reset
 	logic.Floor = 5
 	logic.Direction = DirUp
-	logic.AddCall(2) // Below
-	logic.AddCall(9) // Above
+	logic.AddCall(2, CallCar) // Below
+	logic.AddCall(9, CallCar) // Above
 	action = logic.DecideNextStep()
 	if action.Type != ActionMove || action.Dir != DirUp || action.Target != 9 {
 		t.Errorf("Scenario 2 failed: Expected Move Up to 9, got %v", action)
@@ -88,8 +102,8 @@ func TestElevatorLogic_DecideNextStep_SCAN(t *testing.T) {
 	// Logic implementation handles this.
 	logic = NewElevatorLogic(cfg)
 	logic.Direction = DirUp
-	logic.Calls = make(map[int]bool)
-	logic.AddCall(2)
+	logic.ClearCalls()
+	logic.AddCall(2, CallCar)
 
 	action = logic.DecideNextStep()
 	// Depending on implementation, it might return Move Down directly OR Stop first.
@@ -100,7 +114,7 @@ func TestElevatorLogic_DecideNextStep_SCAN(t *testing.T) {
 
 	// Scenario 4: Arrived at Target -> Open Door
 	logic = NewElevatorLogic(cfg) // Floor 5
-	logic.AddCall(5)
+	logic.AddCall(5, CallCar)
 	action = logic.DecideNextStep()
 	if action.Type != ActionOpenDoor || action.Target != 5 {
 		t.Errorf("Scenario 4 failed: Expected OpenDoor at 5, got %v", action)
@@ -113,9 +127,131 @@ func TestElevatorLogic_Doors(t *testing.T) {
 
 	// Doors Open -> No Move
 	logic.Doors[Front] = DoorOpen
-	logic.AddCall(3)
+	logic.AddCall(3, CallCar)
 	action := logic.DecideNextStep()
 	if action.Type != ActionNone {
 		t.Errorf("Expected ActionNone when doors are open, got %v", action)
 	}
 }
+
+func TestElevatorLogic_DecideNextStep_HallCalls(t *testing.T) {
+	cfg := LogicConfig{MinFloor: 1, MaxFloor: 10, InitialFloor: 5}
+
+	tests := []struct {
+		name     string
+		dir      Direction
+		calls    map[int]CallType
+		wantType LogicActionType
+		wantDir  Direction
+	}{
+		{"up car passes down hall call", DirUp, map[int]CallType{5: CallHallDown, 8: CallCar}, ActionMove, DirUp},
+		{"up car stops for up hall call", DirUp, map[int]CallType{5: CallHallUp, 8: CallCar}, ActionOpenDoor, DirUp},
+		{"down hall call is reversal point", DirUp, map[int]CallType{5: CallHallDown, 2: CallCar}, ActionOpenDoor, DirDown},
+		{"down car stops for car call", DirDown, map[int]CallType{5: CallCar, 2: CallHallUp}, ActionOpenDoor, DirDown},
+		{"idle car answers hall call here", DirNone, map[int]CallType{5: CallHallUp}, ActionOpenDoor, DirUp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logic := NewElevatorLogic(cfg)
+			logic.Direction = tt.dir
+			for f, ct := range tt.calls {
+				if err := logic.AddCall(f, ct); err != nil {
+					t.Fatalf("AddCall(%d, %s): %v", f, ct, err)
+				}
+			}
+			action := logic.DecideNextStep()
+			if action.Type != tt.wantType || action.Dir != tt.wantDir {
+				t.Errorf("DecideNextStep() = %+v, want type %v dir %s", action, tt.wantType, tt.wantDir)
+			}
+		})
+	}
+}
+
+func TestElevatorLogic_ServeFloor(t *testing.T) {
+	logic := NewElevatorLogic(LogicConfig{MinFloor: 1, MaxFloor: 5, InitialFloor: 3})
+	logic.AddCall(3, CallCar)
+	logic.AddCall(3, CallHallUp)
+	logic.AddCall(3, CallHallDown)
+
+	logic.ServeFloor(3, DirUp)
+	if logic.CarCalls[3] || logic.HallUpCalls[3] {
+		t.Error("Expected car and up hall call to be cleared")
+	}
+	if !logic.HallDownCalls[3] {
+		t.Error("Expected down hall call to remain")
+	}
+
+	calls := logic.CallFloors()
+	if len(calls.Car) != 0 || len(calls.HallUp) != 0 || len(calls.HallDown) != 1 {
+		t.Errorf("Unexpected call set %+v", calls)
+	}
+}
+
+func TestElevatorLogic_PendingCalls(t *testing.T) {
+	logic := NewElevatorLogic(LogicConfig{MinFloor: 1, MaxFloor: 10, InitialFloor: 1})
+	logic.AddCall(5, CallCar)
+	logic.AddCall(8, CallCar)
+	logic.RemoveCall(5, CallCar)
+	logic.AddCall(3, CallCar)
+	logic.AddCall(5, CallCar) // re-registered: queues behind 8 and 3
+
+	want := []Call{{8, CallCar}, {3, CallCar}, {5, CallCar}}
+	if got := logic.PendingCalls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PendingCalls() = %v, want %v", got, want)
+	}
+
+	// Served calls leave the order too, so it does not grow with traffic.
+	for i := 0; i < 1000; i++ {
+		logic.AddCall(2, CallHallUp)
+		logic.ServeFloor(2, DirUp)
+	}
+	logic.ServeFloor(8, DirNone)
+	if got := len(logic.order); got != 2 {
+		t.Errorf("order holds %d calls, want 2", got)
+	}
+}
+
+func TestSchedulers_NextTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		scheduler  string
+		dir        Direction
+		calls      []Call
+		wantTarget int
+	}{
+		// Car at 5, calls at 3 (older) and 9.
+		{"collective keeps direction", SchedulerCollective, DirUp, []Call{{3, CallCar}, {9, CallCar}}, 9},
+		{"look keeps direction", SchedulerLOOK, DirUp, []Call{{3, CallCar}, {9, CallCar}}, 9},
+		{"fcfs serves oldest call", SchedulerFCFS, DirUp, []Call{{3, CallCar}, {9, CallCar}}, 3},
+		{"sstf serves nearest call", SchedulerSSTF, DirUp, []Call{{3, CallCar}, {9, CallCar}}, 3},
+		// Nothing ahead: LOOK reverses, SCAN runs on to the terminal floor.
+		{"look reverses when nothing ahead", SchedulerLOOK, DirUp, []Call{{3, CallCar}}, 3},
+		{"scan runs to terminal floor", SchedulerSCAN, DirUp, []Call{{3, CallCar}}, 10},
+		// LOOK stops for an opposite hall call, collective passes it.
+		{"look stops for opposite hall call", SchedulerLOOK, DirUp, []Call{{5, CallHallDown}, {8, CallCar}}, 5},
+		{"collective passes opposite hall call", SchedulerCollective, DirUp, []Call{{5, CallHallDown}, {8, CallCar}}, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewScheduler(tt.scheduler)
+			if err != nil {
+				t.Fatalf("NewScheduler(%q): %v", tt.scheduler, err)
+			}
+			logic := NewElevatorLogic(LogicConfig{MinFloor: 1, MaxFloor: 10, InitialFloor: 5, Scheduler: s})
+			logic.Direction = tt.dir
+			for _, c := range tt.calls {
+				logic.AddCall(c.Floor, c.Type)
+			}
+			target, _, ok := s.NextTarget(logic)
+			if !ok || target != tt.wantTarget {
+				t.Errorf("NextTarget() = %d, %v, want %d", target, ok, tt.wantTarget)
+			}
+		})
+	}
+
+	if _, err := NewScheduler("elevator-algebra"); err == nil {
+		t.Error("Expected error for unknown scheduler, got nil")
+	}
+}
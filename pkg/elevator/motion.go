@@ -0,0 +1,387 @@
+package elevator
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// EventPosition reports the car position and speed while travelling.
+const EventPosition EventType = "Position"
+
+// DefaultFloorHeight is the storey height used when none is configured.
+const DefaultFloorHeight = 3.5
+
+// MotionProfile describes the kinematics of the drive.
+// MotionProfile은 구동부의 운동 특성(정격 속도, 가속도, 저크)을 정의합니다.
+type MotionProfile struct {
+	RatedSpeed   float64 // 정격 속도 m/s
+	Acceleration float64 // 최대 가속도 m/s²
+	Jerk         float64 // 최대 저크 m/s³
+	FloorHeight  float64 // 기본 층고 m (FloorConfig.Height가 우선, 0이면 DefaultFloorHeight)
+}
+
+func (m *MotionProfile) validate() error {
+	if m.RatedSpeed <= 0 || m.Acceleration <= 0 || m.Jerk <= 0 {
+		return fmt.Errorf("invalid motion profile: speed %.2f, acceleration %.2f and jerk %.2f must be positive",
+			m.RatedSpeed, m.Acceleration, m.Jerk)
+	}
+	if m.FloorHeight < 0 {
+		return fmt.Errorf("invalid motion profile: negative floor height %.2f", m.FloorHeight)
+	}
+	return nil
+}
+
+// PositionPayload carries detail for position events.
+// PositionPayload는 위치 이벤트의 세부 정보를 담고 있습니다.
+type PositionPayload struct {
+	Position float64 // 최저 층 기준 높이 m
+	Speed    float64 // 속도 m/s (하강 시 음수)
+	Level    float64 // 층 단위 위치 (예: 3.5는 3층과 4층 사이)
+}
+
+// sCurve is a jerk-limited, symmetric point-to-point motion over a distance.
+// The acceleration phase has a jerk-up (t1), constant acceleration (t2) and
+// jerk-down (t1) part; deceleration mirrors it.
+type sCurve struct {
+	jerk     float64
+	peakAcc  float64
+	peakVel  float64
+	t1, t2   float64 // 저크 구간, 등가속 구간 (s)
+	cruise   float64 // 정속 구간 (s)
+	distance float64
+	rated    bool // 정격 속도 도달 여부
+}
+
+func newSCurve(m *MotionProfile, distance float64) sCurve {
+	c := sCurve{jerk: m.Jerk, distance: distance}
+	if accelDistance(m, m.RatedSpeed)*2 <= distance {
+		c.setPeak(m, m.RatedSpeed)
+		c.rated = true
+		c.cruise = (distance - 2*accelDistance(m, m.RatedSpeed)) / m.RatedSpeed
+		return c
+	}
+	// Short run: find the peak speed whose accel+decel distance fits exactly.
+	lo, hi := 0.0, m.RatedSpeed
+	for i := 0; i < 60; i++ {
+		mid := (lo + hi) / 2
+		if accelDistance(m, mid)*2 < distance {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	c.setPeak(m, lo)
+	return c
+}
+
+func (c *sCurve) setPeak(m *MotionProfile, v float64) {
+	c.peakVel = v
+	if v >= m.Acceleration*m.Acceleration/m.Jerk {
+		c.peakAcc = m.Acceleration
+		c.t1 = m.Acceleration / m.Jerk
+		c.t2 = v/m.Acceleration - c.t1
+	} else {
+		c.t1 = math.Sqrt(v / m.Jerk)
+		c.peakAcc = m.Jerk * c.t1
+	}
+}
+
+// accelDistance is the distance needed to reach v from standstill.
+func accelDistance(m *MotionProfile, v float64) float64 {
+	if v >= m.Acceleration*m.Acceleration/m.Jerk {
+		return v / 2 * (v/m.Acceleration + m.Acceleration/m.Jerk)
+	}
+	return v * math.Sqrt(v/m.Jerk)
+}
+
+func (c sCurve) accelTime() float64 { return 2*c.t1 + c.t2 }
+
+func (c sCurve) total() float64 { return 2*c.accelTime() + c.cruise }
+
+// commit is the last moment at which the motion is identical to that of any
+// longer run; after it the car is committed to stopping at this distance.
+func (c sCurve) commit() float64 {
+	if c.rated {
+		return c.accelTime() + c.cruise
+	}
+	return c.t1 + c.t2
+}
+
+// accel returns distance and speed t seconds into the acceleration phase.
+func (c sCurve) accel(t float64) (float64, float64) {
+	j, a := c.jerk, c.peakAcc
+	if t <= c.t1 {
+		return j * t * t * t / 6, j * t * t / 2
+	}
+	v1, s1 := j*c.t1*c.t1/2, j*c.t1*c.t1*c.t1/6
+	if t <= c.t1+c.t2 {
+		tau := t - c.t1
+		return s1 + v1*tau + a*tau*tau/2, v1 + a*tau
+	}
+	v2, s2 := v1+a*c.t2, s1+v1*c.t2+a*c.t2*c.t2/2
+	tau := math.Min(t-c.t1-c.t2, c.t1)
+	return s2 + v2*tau + a*tau*tau/2 - j*tau*tau*tau/6, v2 + a*tau - j*tau*tau/2
+}
+
+// at returns distance travelled and speed t seconds after departure.
+func (c sCurve) at(t float64) (float64, float64) {
+	ta := c.accelTime()
+	switch {
+	case t <= 0:
+		return 0, 0
+	case t <= ta:
+		return c.accel(t)
+	case t <= ta+c.cruise:
+		s, _ := c.accel(ta)
+		return s + c.peakVel*(t-ta), c.peakVel
+	case t < c.total():
+		s, v := c.accel(c.total() - t)
+		return c.distance - s, v
+	}
+	return c.distance, 0
+}
+
+// timeAt returns the time at which distance x is reached.
+func (c sCurve) timeAt(x float64) float64 {
+	lo, hi := 0.0, c.total()
+	for i := 0; i < 60; i++ {
+		mid := (lo + hi) / 2
+		if s, _ := c.at(mid); s < x {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+// runPlan is a run between two stops.
+// runPlan은 두 정차 층 사이의 한 번의 주행 계획입니다.
+type runPlan struct {
+	start    time.Time
+	from, to int
+	dir      Direction
+	passed   int             // 지나온 층 수
+	heights  []float64       // 구간별 층고 m
+	crossing []time.Duration // crossing[i]: 출발 후 (i+1)번째 층 도달 시각
+	commit   time.Duration   // 이 시각 이후에는 정차 층을 바꿀 수 없음
+	curve    *sCurve         // nil이면 구간별 등속 이동
+}
+
+func (r *runPlan) floors() int { return len(r.crossing) }
+
+// floor returns the n-th floor of the run.
+func (r *runPlan) floor(n int) int { return r.from + r.dir.delta()*n }
+
+// state returns distance and speed elapsed after departure.
+func (r *runPlan) state(elapsed time.Duration) (float64, float64) {
+	if r.curve != nil {
+		return r.curve.at(elapsed.Seconds())
+	}
+	var dist float64
+	var prev time.Duration
+	for i, c := range r.crossing {
+		if elapsed < c {
+			speed := r.heights[i] / (c - prev).Seconds()
+			return dist + speed*(elapsed-prev).Seconds(), speed
+		}
+		dist += r.heights[i]
+		prev = c
+	}
+	return dist, 0
+}
+
+// planRun plans a run from one floor to another departing at start.
+func (e *Elevator) planRun(start time.Time, from, to int, dir Direction) *runPlan {
+	n := abs(to - from)
+	r := &runPlan{start: start, from: from, to: to, dir: dir}
+	for i := 0; i < n; i++ {
+		lower := r.floor(i)
+		if dir == DirDown {
+			lower = r.floor(i + 1)
+		}
+		r.heights = append(r.heights, e.floorHeight(lower))
+	}
+
+	if m := e.Config.Motion; m != nil {
+		var total float64
+		for _, h := range r.heights {
+			total += h
+		}
+		curve := newSCurve(m, total)
+		r.curve = &curve
+		var dist float64
+		for _, h := range r.heights {
+			dist += h
+			r.crossing = append(r.crossing, seconds(curve.timeAt(dist)))
+		}
+		r.crossing[n-1] = seconds(curve.total()) // exact, not bisected
+		r.commit = seconds(curve.commit())
+		return r
+	}
+
+	// Without a motion profile the first and last floor of a run take
+	// TravelTimeEdge and pass-through floors take TravelTime.
+	edge := e.Config.TravelTimeEdge
+	if edge == 0 {
+		edge = e.Config.TravelTime
+	}
+	var t time.Duration
+	for i := 0; i < n; i++ {
+		seg := e.Config.TravelTime
+		if i == 0 {
+			seg += edge - e.Config.TravelTime
+		}
+		if i == n-1 {
+			seg += edge - e.Config.TravelTime
+			r.commit = t
+		}
+		t += seg
+		r.crossing = append(r.crossing, t)
+	}
+	return r
+}
+
+// floorHeight is the distance from floor to the floor above.
+func (e *Elevator) floorHeight(floor int) float64 {
+	if h := e.Logic.Config.FloorConfigs[floor].Height; h > 0 {
+		return h
+	}
+	if m := e.Config.Motion; m != nil && m.FloorHeight > 0 {
+		return m.FloorHeight
+	}
+	return DefaultFloorHeight
+}
+
+// floorPosition is the height of floor above the lowest floor.
+func (e *Elevator) floorPosition(floor int) float64 {
+	var pos float64
+	for f := e.Config.MinFloor; f < floor; f++ {
+		pos += e.floorHeight(f)
+	}
+	return pos
+}
+
+// Position returns the car height above the lowest floor, its speed and its
+// fractional floor level.
+func (e *Elevator) Position() PositionPayload {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.position()
+}
+
+func (e *Elevator) position() PositionPayload {
+	if j := e.jog; j != nil {
+		dist := e.inspectionSpeed() * e.clock.Now().Sub(j.start).Seconds()
+		pos := j.from + float64(j.dir.delta())*dist
+		return PositionPayload{Position: pos, Speed: float64(j.dir.delta()) * e.inspectionSpeed(), Level: e.levelAt(pos)}
+	}
+	r := e.run
+	if r == nil {
+		pos := e.floorPosition(e.Logic.Floor)
+		if e.offLevel != 0 {
+			return PositionPayload{Position: pos + e.offLevel, Level: e.levelAt(pos + e.offLevel)}
+		}
+		return PositionPayload{Position: pos, Level: float64(e.Logic.Floor)}
+	}
+	dist, speed := r.state(e.clock.Now().Sub(r.start))
+	base := e.floorPosition(r.from)
+	level := float64(r.from)
+	// Convert distance to a fractional level floor by floor (heights may vary).
+	rest := dist
+	for _, h := range r.heights {
+		if rest < h {
+			level += float64(r.dir.delta()) * rest / h
+			break
+		}
+		rest -= h
+		level += float64(r.dir.delta())
+	}
+	return PositionPayload{
+		Position: base + float64(r.dir.delta())*dist,
+		Speed:    float64(r.dir.delta()) * speed,
+		Level:    level,
+	}
+}
+
+func (e *Elevator) publishPosition() {
+	e.publishEvent(EventPosition, e.position())
+}
+
+// startRun departs towards the first floor the car has to stop at in dir.
+func (e *Elevator) startRun(dir Direction) {
+	to := e.Logic.NextStop(e.Logic.Floor, dir)
+	e.run = e.planRun(e.clock.Now(), e.Logic.Floor, to, dir)
+	e.isMoving = true
+	e.armTravelTimer(e.run.crossing[0])
+	e.logger.Debug("Run started", "from", e.run.from, "to", to, "duration", e.run.crossing[e.run.floors()-1])
+}
+
+// replan moves the planned stop when calls changed during the run, elapsed
+// after departure. The car only switches to a nearer floor while it can still
+// decelerate in time, and only extends the run before it has started braking.
+func (e *Elevator) replan(elapsed time.Duration) bool {
+	r := e.run
+	to := e.Logic.NextStop(e.Logic.Floor, r.dir)
+	if to == r.to {
+		return false
+	}
+	next := e.planRun(r.start, r.from, to, r.dir)
+	if next.floors() <= r.passed || elapsed > min(next.commit, r.commit) {
+		e.logger.Debug("Stop change rejected, committed", "planned", r.to, "wanted", to)
+		return false
+	}
+	next.passed = r.passed
+	e.run = next
+	e.logger.Debug("Stop changed", "from", r.to, "to", to)
+	return true
+}
+
+// armNextCrossing arms the travel timer for the next floor of the run.
+func (e *Elevator) armNextCrossing() {
+	r := e.run
+	e.armTravelTimer(r.crossing[r.passed] - e.clock.Now().Sub(r.start))
+}
+
+// handleMoveComplete runs when the car is level with the next floor of the run.
+func (e *Elevator) handleMoveComplete() {
+	r := e.run
+	r.passed++
+	e.setFloor(r.floor(r.passed))
+	if r.passed < r.floors() {
+		e.replan(r.crossing[r.passed-1])
+	}
+	if e.run.passed < e.run.floors() {
+		e.publishPosition()
+		e.armNextCrossing()
+		return
+	}
+
+	// Stopped at the planned floor.
+	e.run = nil
+	e.isMoving = false
+	if d := e.levelingError(); d > 0 {
+		e.offLevel = float64(r.dir.delta()) * d
+		e.logger.Warn("Stopped off level", "floor", e.Logic.Floor, "off_level", e.offLevel)
+	}
+	e.publishPosition()
+	if !e.dispatching() || e.offLevel != 0 {
+		e.setDirection(DirNone)
+		return
+	}
+
+	action := e.Logic.DecideNextStep()
+	switch action.Type {
+	case ActionOpenDoor:
+		e.handleArrival(e.Logic.Floor, action.Dir)
+	case ActionMove:
+		e.setDirection(action.Dir) // the next tick departs again
+	default:
+		e.setDirection(DirNone)
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,188 @@
+package elevator
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSCurve(t *testing.T) {
+	m := &MotionProfile{RatedSpeed: 2.5, Acceleration: 1, Jerk: 1}
+
+	tests := []struct {
+		name      string
+		distance  float64
+		wantRated bool
+		wantTotal float64 // 0: not checked
+	}{
+		// D/v + v/a + a/j for runs that reach rated speed.
+		{"long run", 35, true, 35/2.5 + 2.5 + 1},
+		{"one floor", 3.5, false, 0},
+		{"jerk limited", 0.5, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newSCurve(m, tt.distance)
+			if c.rated != tt.wantRated {
+				t.Errorf("rated = %v, want %v", c.rated, tt.wantRated)
+			}
+			if tt.wantTotal != 0 && math.Abs(c.total()-tt.wantTotal) > 1e-6 {
+				t.Errorf("total = %.4fs, want %.4fs", c.total(), tt.wantTotal)
+			}
+			if s, v := c.at(c.total()); math.Abs(s-tt.distance) > 1e-6 || v != 0 {
+				t.Errorf("at(end) = %.4fm %.4fm/s, want %.4fm at rest", s, v, tt.distance)
+			}
+
+			// Distance is continuous and non-decreasing; speed stays within limits.
+			var prev float64
+			for i := 0; i <= 1000; i++ {
+				s, v := c.at(c.total() * float64(i) / 1000)
+				if s < prev-1e-9 || v < -1e-9 || v > m.RatedSpeed+1e-9 {
+					t.Fatalf("step %d: distance %.6f (prev %.6f), speed %.6f", i, s, prev, v)
+				}
+				prev = s
+			}
+			if got := c.timeAt(tt.distance / 2); math.Abs(got-c.total()/2) > 1e-6 {
+				t.Errorf("timeAt(half) = %.4fs, want %.4fs", got, c.total()/2)
+			}
+		})
+	}
+}
+
+// runElevator starts e on clock, applies calls at the given offsets after
+// start and returns the floors the car stopped at with their arrival offsets.
+func runElevator(t *testing.T, e *Elevator, clock *ManualClock, d time.Duration, calls map[time.Duration][]int) ([]int, []time.Duration) {
+	t.Helper()
+	start := clock.Now()
+	var floors []int
+	var at []time.Duration
+	e.OnEvent(func(ev Event) {
+		if ev.Type == EventArrived {
+			floors = append(floors, ev.Payload.(ArrivedPayload).Floor)
+			at = append(at, ev.Timestamp.Sub(start))
+		}
+	})
+	for offset, fs := range calls {
+		fs := fs
+		clock.AfterFunc(offset, func() {
+			for _, f := range fs {
+				if err := e.AddCall(f, CallCar); err != nil {
+					t.Errorf("AddCall(%d): %v", f, err)
+				}
+			}
+		})
+	}
+	e.start()
+	defer e.stop()
+	clock.Advance(d)
+	return floors, at
+}
+
+func TestElevator_TravelTimeEdge(t *testing.T) {
+	clock := NewManualClock(testEpoch)
+	e, err := New(Config{
+		ID: "T", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+		TravelTime: time.Second, TravelTimeEdge: 1500 * time.Millisecond,
+		DoorSpeed: time.Second, DoorOpenTime: time.Second,
+	}, WithClock(clock), WithEventBuffer(0))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	// Departs on the first tick (100ms): 1.5s + 1s + 1.5s to floor 4.
+	floors, at := runElevator(t, e, clock, 10*time.Second, map[time.Duration][]int{0: {4}})
+	if len(floors) != 1 || floors[0] != 4 || at[0] != 4100*time.Millisecond {
+		t.Errorf("Arrived at %v after %v, want [4] after 4.1s", floors, at)
+	}
+}
+
+func TestElevator_CommittedStop(t *testing.T) {
+	clock := NewManualClock(testEpoch)
+	e, err := New(Config{
+		ID: "T", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+		TravelTime: time.Second, TravelTimeEdge: 1500 * time.Millisecond,
+		DoorSpeed: time.Second, DoorOpenTime: time.Second,
+	}, WithClock(clock), WithEventBuffer(0))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	// At 1.7s the car (departed at 100ms) is between floors 2 and 3 at full
+	// speed: too late to stop at 3, early enough to stop at 4.
+	floors, _ := runElevator(t, e, clock, 60*time.Second, map[time.Duration][]int{
+		0:                       {6},
+		1700 * time.Millisecond: {3, 4},
+	})
+	want := []int{4, 6, 3}
+	if len(floors) != len(want) {
+		t.Fatalf("Stopped at %v, want %v", floors, want)
+	}
+	for i := range want {
+		if floors[i] != want[i] {
+			t.Fatalf("Stopped at %v, want %v", floors, want)
+		}
+	}
+}
+
+func TestElevator_MotionProfile(t *testing.T) {
+	clock := NewManualClock(testEpoch)
+	motion := &MotionProfile{RatedSpeed: 2.5, Acceleration: 1, Jerk: 1, FloorHeight: 3}
+	e, err := New(Config{
+		ID: "T", MinFloor: 1, MaxFloor: 20, InitialFloor: 1,
+		DoorSpeed: time.Second, DoorOpenTime: time.Second,
+		Motion: motion,
+		FloorConfigs: map[int]FloorConfig{
+			1: {FloorNumber: 1, OpenDoorSide: Front, Height: 5}, // 로비 층고 5m
+		},
+	}, WithClock(clock), WithEventBuffer(0))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var positions []PositionPayload
+	var floorChanges []time.Duration
+	e.OnEvent(func(ev Event) {
+		switch ev.Type {
+		case EventPosition:
+			positions = append(positions, ev.Payload.(PositionPayload))
+		case EventFloorChange:
+			floorChanges = append(floorChanges, ev.Timestamp.Sub(testEpoch))
+		}
+	})
+
+	// 5m lobby + 8 floors of 3m = 29m: rated speed is reached.
+	floors, at := runElevator(t, e, clock, 30*time.Second, map[time.Duration][]int{0: {10}})
+	wantRun := seconds(newSCurve(motion, 29).total())
+	if len(floors) != 1 || floors[0] != 10 || at[0] != 100*time.Millisecond+wantRun {
+		t.Fatalf("Arrived at %v after %v, want [10] after %v", floors, at, 100*time.Millisecond+wantRun)
+	}
+
+	// Start and stop floors take longer than pass-through floors.
+	first := floorChanges[0] - 100*time.Millisecond
+	middle := floorChanges[5] - floorChanges[4]
+	last := floorChanges[8] - floorChanges[7]
+	if first <= middle || last <= middle {
+		t.Errorf("Floor times first %v, middle %v, last %v", first, middle, last)
+	}
+
+	var maxSpeed float64
+	for i, p := range positions {
+		if i > 0 && p.Position < positions[i-1].Position {
+			t.Fatalf("Position went backwards: %v after %v", p, positions[i-1])
+		}
+		maxSpeed = math.Max(maxSpeed, p.Speed)
+	}
+	if math.Abs(maxSpeed-motion.RatedSpeed) > 1e-9 {
+		t.Errorf("Max speed %.3f, want %.3f", maxSpeed, motion.RatedSpeed)
+	}
+	if end := positions[len(positions)-1]; end.Position != 29 || end.Level != 10 || end.Speed != 0 {
+		t.Errorf("Final position %+v, want 29m at level 10", end)
+	}
+}
+
+func TestNew_InvalidMotion(t *testing.T) {
+	_, err := New(Config{MinFloor: 1, MaxFloor: 5, Motion: &MotionProfile{RatedSpeed: 1}})
+	if err == nil {
+		t.Error("Expected error for motion profile without acceleration and jerk")
+	}
+}
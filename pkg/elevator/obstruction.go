@@ -0,0 +1,124 @@
+package elevator
+
+import "time"
+
+// Door obstruction event types.
+const (
+	EventObstruction EventType = "Obstruction"
+	EventNudging     EventType = "Nudging"
+)
+
+// DefaultNudgingTime is how long the doors may be held by an obstruction
+// before nudging when Config.NudgingTime is 0.
+const DefaultNudgingTime = 20 * time.Second
+
+// nudgingSlowdown is the factor by which nudging doors close more slowly.
+const nudgingSlowdown = 2
+
+// ObstructionPayload carries detail for obstruction events.
+// ObstructionPayload는 문 감지기(광전 장치·세이프티 엣지) 이벤트의 세부 정보를 담고 있습니다.
+type ObstructionPayload struct {
+	Blocked bool
+}
+
+// NudgingPayload carries detail for nudging events.
+// NudgingPayload는 넛징(저속 강제 닫힘) 이벤트의 세부 정보를 담고 있습니다.
+type NudgingPayload struct {
+	Active bool // 부저 울림
+}
+
+// SetObstruction reports the door light curtain / safety edge as blocked or
+// clear. A blocked sensor reopens closing doors and keeps open doors from
+// closing, as the open button does. If it stays blocked for
+// Config.NudgingTime, the car nudges: the doors close at reduced speed with
+// the buzzer sounding, regardless of the sensor.
+//
+// The sensor is ignored on fire service and emergency stop.
+func (e *Elevator) SetObstruction(blocked bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.obstructed == blocked {
+		return
+	}
+	e.obstructed = blocked
+	if blocked {
+		e.obstructedAt = e.clock.Now()
+		e.logger.Info("Door obstruction detected")
+	} else {
+		e.logger.Info("Door obstruction cleared")
+	}
+	e.publishEvent(EventObstruction, ObstructionPayload{Blocked: blocked})
+
+	if e.obstructionActive() && e.doorState() == DoorClosing {
+		e.reopenDoors()
+	}
+}
+
+// Obstructed reports whether the door sensor is blocked.
+func (e *Elevator) Obstructed() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.obstructed
+}
+
+// Nudging reports whether the doors are nudging closed.
+func (e *Elevator) Nudging() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.nudging
+}
+
+// nudgingTime returns the configured obstruction limit.
+func (e *Elevator) nudgingTime() time.Duration {
+	if e.Config.NudgingTime > 0 {
+		return e.Config.NudgingTime
+	}
+	return DefaultNudgingTime
+}
+
+// obstructionActive reports whether a blocked sensor holds the doors.
+func (e *Elevator) obstructionActive() bool {
+	return e.obstructed && !e.nudging && !e.Mode.fireService() && e.Mode != ModeEmergency
+}
+
+// holdObstructed runs when open doors are due to close. It keeps them open
+// while the sensor is blocked, or starts nudging once the limit is reached,
+// and reports whether it did either.
+func (e *Elevator) holdObstructed() bool {
+	if !e.obstructionActive() {
+		return false
+	}
+	left := e.nudgingTime() - e.clock.Now().Sub(e.obstructedAt)
+	if left <= 0 {
+		e.startNudging()
+		return true
+	}
+	e.logger.Debug("Door obstructed, holding doors")
+	e.armDoorTimer(min(left, e.Config.DoorReopenTime))
+	return true
+}
+
+// startNudging closes the doors at reduced speed with the buzzer sounding.
+func (e *Elevator) startNudging() {
+	e.nudging = true
+	e.logger.Warn("Door obstructed too long, nudging", "limit", e.nudgingTime())
+	e.publishEvent(EventNudging, NudgingPayload{Active: true})
+	e.closeDoors()
+}
+
+// stopNudging silences the buzzer once the doors have closed or reopened.
+func (e *Elevator) stopNudging() {
+	if !e.nudging {
+		return
+	}
+	e.nudging = false
+	e.publishEvent(EventNudging, NudgingPayload{Active: false})
+}
+
+// doorCloseTime returns how long the doors take to close.
+func (e *Elevator) doorCloseTime() time.Duration {
+	if e.nudging {
+		return nudgingSlowdown * e.Config.DoorSpeed
+	}
+	return e.Config.DoorSpeed
+}
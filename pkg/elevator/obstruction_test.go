@@ -0,0 +1,67 @@
+package elevator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestElevator_DoorObstruction(t *testing.T) {
+	sim := NewSimulation(testEpoch)
+	defer sim.Close()
+	e, err := sim.NewElevator(Config{
+		ID: "O", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+		TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second,
+		NudgingTime: 10 * time.Second,
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+
+	var got []string
+	e.OnEvent(func(ev Event) {
+		at := ev.Timestamp.Sub(testEpoch)
+		switch p := ev.Payload.(type) {
+		case DoorChangePayload:
+			got = append(got, fmt.Sprintf("%v %s", at, p.State))
+		case NudgingPayload:
+			got = append(got, fmt.Sprintf("%v nudging=%t", at, p.Active))
+		}
+	})
+
+	// A sensor blocked while the doors close reopens them; once it clears
+	// they close after the reopen time.
+	sim.At(0, func() { _ = e.AddCall(1, CallHallUp) })
+	sim.At(4500*time.Millisecond, func() { e.SetObstruction(true) })
+	sim.At(6*time.Second, func() { e.SetObstruction(false) })
+
+	// A sensor blocked for NudgingTime is overridden: the doors close at
+	// half speed with the buzzer on.
+	sim.At(20*time.Second, func() { _ = e.AddCall(1, CallHallUp) })
+	sim.At(22*time.Second, func() { e.SetObstruction(true) })
+	sim.At(31*time.Second, func() {
+		if e.Door(Front) != DoorOpen || e.Nudging() {
+			t.Errorf("door %s nudging %t before the limit, want held open", e.Door(Front), e.Nudging())
+		}
+	})
+	if err := sim.RunFor(context.Background(), time.Minute); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+
+	want := []string{
+		"100ms Opening", "1.1s Open", "4.1s Closing",
+		"4.5s Opening", "5.5s Open", "8.5s Closing", "9.5s Close",
+		"20s Opening", "21s Open",
+		"32s nudging=true", "32s Closing", "34s Close", "34s nudging=false",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("door events =\n%v\nwant\n%v", got, want)
+	}
+	if !e.Obstructed() {
+		t.Error("Obstructed() = false after nudging, want the sensor still blocked")
+	}
+}
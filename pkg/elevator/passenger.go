@@ -0,0 +1,224 @@
+package elevator
+
+import "time"
+
+// Passenger lifecycle event types.
+const (
+	EventPassengerWaiting  EventType = "PassengerWaiting"
+	EventPassengerBoarded  EventType = "PassengerBoarded"
+	EventPassengerAlighted EventType = "PassengerAlighted"
+	// EventPassengerEvacuated reports a rider put out of the car before its
+	// destination, at the fire recall floor or a rescue landing. The
+	// passenger's AlightedAt is when it left the car; it was not delivered.
+	EventPassengerEvacuated EventType = "PassengerEvacuated"
+)
+
+// Passenger is a person travelling from Origin to Destination.
+// Passenger는 출발 층에서 목적 층으로 이동하는 승객입니다.
+type Passenger struct {
+	ID          string
+	Origin      int       // 출발 층
+	Destination int       // 목적 층
+	Mass        int       // 무게 kg
+	ArrivedAt   time.Time // 승강장 도착(호출) 시각
+	BoardedAt   time.Time // 탑승 시각
+	AlightedAt  time.Time // 하차 시각
+}
+
+// Direction returns the direction of travel the passenger requests.
+func (p Passenger) Direction() Direction {
+	return directionTo(p.Origin, p.Destination)
+}
+
+// WaitTime is the time from arrival at the landing to boarding.
+func (p Passenger) WaitTime() time.Duration {
+	if p.BoardedAt.IsZero() {
+		return 0
+	}
+	return p.BoardedAt.Sub(p.ArrivedAt)
+}
+
+// RideTime is the time from boarding to alighting.
+func (p Passenger) RideTime() time.Duration {
+	if p.BoardedAt.IsZero() || p.AlightedAt.IsZero() {
+		return 0
+	}
+	return p.AlightedAt.Sub(p.BoardedAt)
+}
+
+// JourneyTime is the time from arrival at the landing to alighting.
+func (p Passenger) JourneyTime() time.Duration {
+	if p.AlightedAt.IsZero() {
+		return 0
+	}
+	return p.AlightedAt.Sub(p.ArrivedAt)
+}
+
+// PassengerPayload carries detail for passenger events.
+// PassengerPayload는 승객 이벤트의 세부 정보를 담고 있습니다.
+type PassengerPayload struct {
+	Passenger Passenger
+	Floor     int
+}
+
+// AddPassenger puts p on its origin landing and registers the hall call.
+// A zero ArrivedAt is set to the current time. If the doors are already open at
+// the origin in the passenger's direction, the passenger boards immediately.
+func (e *Elevator) AddPassenger(p *Passenger) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.rejected("AddPassenger", p.Origin, &err)
+
+	if err := e.validatePassenger(p); err != nil {
+		return err
+	}
+	if p.ArrivedAt.IsZero() {
+		p.ArrivedAt = e.clock.Now()
+	}
+
+	e.waiting[p.Origin] = append(e.waiting[p.Origin], p)
+	e.publishEvent(EventPassengerWaiting, PassengerPayload{Passenger: *p, Floor: p.Origin})
+
+	if p.Origin == e.Logic.Floor && e.doorState() == DoorOpen {
+		e.boardPassengers(p.Origin)
+		if p.BoardedAt.IsZero() {
+			e.registerHallCall(p)
+		}
+		return nil
+	}
+	e.registerHallCall(p)
+	return nil
+}
+
+// Waiting returns copies of the passengers waiting on the landings.
+func (e *Elevator) Waiting() []Passenger {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	var out []Passenger
+	for f := e.Config.MinFloor; f <= e.Config.MaxFloor; f++ {
+		for _, p := range e.waiting[f] {
+			out = append(out, *p)
+		}
+	}
+	return out
+}
+
+// Riders returns copies of the passengers inside the car.
+func (e *Elevator) Riders() []Passenger {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	out := make([]Passenger, 0, len(e.riders))
+	for _, p := range e.riders {
+		out = append(out, *p)
+	}
+	return out
+}
+
+func (e *Elevator) validatePassenger(p *Passenger) error {
+	for _, f := range []int{p.Origin, p.Destination} {
+		if f < e.Config.MinFloor || f > e.Config.MaxFloor {
+			return errorf(ErrOutOfRange, "floor %d out of range", f)
+		}
+		if cfg, ok := e.Logic.Config.FloorConfigs[f]; ok && !cfg.IsAccessible {
+			return errorf(ErrInaccessible, "floor %d is inaccessible", f)
+		}
+	}
+	if p.Origin == p.Destination {
+		return errorf(ErrInvalid, "passenger %s has the same origin and destination %d", p.ID, p.Origin)
+	}
+	if e.Config.MaxWeight > 0 && p.Mass > e.Config.MaxWeight {
+		return errorf(ErrOverload, "passenger %s of %d kg exceeds the capacity of %d kg", p.ID, p.Mass, e.Config.MaxWeight)
+	}
+	return nil
+}
+
+func (e *Elevator) registerHallCall(p *Passenger) {
+	switch e.Mode {
+	case ModeManual, ModeMoving, ModeFireRecall, ModeFireService:
+		return // hall calls are cancelled on inspection, independent and fire service
+	}
+	if e.stuckButton(p.Origin, HallCallType(p.Direction())) {
+		return // the stuck button is ignored
+	}
+	if err := e.Logic.AddCall(p.Origin, HallCallType(p.Direction())); err != nil {
+		e.logger.Warn("Passenger hall call rejected", "passenger", p.ID, "err", err)
+	}
+}
+
+// exchangePassengers lets riders out and waiting passengers in at floor.
+// Called when the doors have fully opened.
+func (e *Elevator) exchangePassengers(floor int) {
+	e.alightPassengers(floor)
+	e.boardPassengers(floor)
+}
+
+func (e *Elevator) alightPassengers(floor int) {
+	now := e.clock.Now()
+	remaining := e.riders[:0]
+	for _, p := range e.riders {
+		if p.Destination != floor {
+			remaining = append(remaining, p)
+			continue
+		}
+		p.AlightedAt = now
+		e.Logic.Weight -= p.Mass
+		e.publishEvent(EventPassengerAlighted, PassengerPayload{Passenger: *p, Floor: floor})
+	}
+	e.riders = remaining
+}
+
+// boardPassengers boards waiting passengers whose direction was served on
+// this stop (all of them when the stop served DirNone) while capacity allows.
+func (e *Elevator) boardPassengers(floor int) {
+	now := e.clock.Now()
+	var left []*Passenger
+	for _, p := range e.waiting[floor] {
+		if e.servedDir != DirNone && p.Direction() != e.servedDir {
+			left = append(left, p)
+			continue
+		}
+		if e.Config.MaxWeight > 0 && e.Logic.Weight+p.Mass > e.Config.MaxWeight {
+			left = append(left, p)
+			continue
+		}
+		p.BoardedAt = now
+		e.Logic.Weight += p.Mass
+		e.riders = append(e.riders, p)
+		if err := e.Logic.AddCall(p.Destination, CallCar); err != nil {
+			e.logger.Warn("Passenger car call rejected", "passenger", p.ID, "err", err)
+		}
+		e.publishEvent(EventPassengerBoarded, PassengerPayload{Passenger: *p, Floor: floor})
+	}
+	e.waiting[floor] = left
+}
+
+// reregisterWaiting presses the hall buttons again for passengers left behind
+// at floor (e.g. because the car was full) once the car departs.
+func (e *Elevator) reregisterWaiting(floor int) {
+	for _, p := range e.waiting[floor] {
+		if !e.Logic.callMap(HallCallType(p.Direction()))[floor] {
+			e.registerHallCall(p)
+		}
+	}
+}
+
+// reregisterAll registers the hall calls of every waiting passenger again,
+// after a mode that cancelled them.
+func (e *Elevator) reregisterAll() {
+	for f := e.Config.MinFloor; f <= e.Config.MaxFloor; f++ {
+		e.reregisterWaiting(f)
+	}
+}
+
+// evacuate lets every rider out at floor; riders whose destination it is
+// alight as usual, the others are evacuated.
+func (e *Elevator) evacuate(floor int) {
+	e.alightPassengers(floor)
+	now := e.clock.Now()
+	for _, p := range e.riders {
+		p.AlightedAt = now
+		e.Logic.Weight -= p.Mass
+		e.publishEvent(EventPassengerEvacuated, PassengerPayload{Passenger: *p, Floor: floor})
+	}
+	e.riders = nil
+}
@@ -0,0 +1,126 @@
+package elevator
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestElevator_PassengerTrip(t *testing.T) {
+	sim := NewSimulation(testEpoch)
+	defer sim.Close()
+
+	e, err := sim.NewElevator(Config{
+		ID:           "P",
+		MinFloor:     1,
+		MaxFloor:     10,
+		InitialFloor: 3,
+		TravelTime:   time.Second,
+		DoorSpeed:    time.Second,
+		DoorOpenTime: 3 * time.Second,
+		MaxWeight:    100,
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+
+	alice := &Passenger{ID: "alice", Origin: 1, Destination: 5, Mass: 70}
+	bob := &Passenger{ID: "bob", Origin: 1, Destination: 4, Mass: 70} // does not fit with alice
+	carol := &Passenger{ID: "carol", Origin: 1, Destination: 1}
+	if err := e.AddPassenger(alice); err != nil {
+		t.Fatalf("AddPassenger(alice): %v", err)
+	}
+	if err := e.AddPassenger(bob); err != nil {
+		t.Fatalf("AddPassenger(bob): %v", err)
+	}
+	if err := e.AddPassenger(carol); err == nil {
+		t.Error("Expected error for passenger with same origin and destination")
+	}
+
+	var weights []int
+	e.OnEvent(func(ev Event) {
+		if ev.Type == EventPassengerBoarded || ev.Type == EventPassengerAlighted {
+			weights = append(weights, e.Logic.Weight)
+		}
+	})
+
+	if err := sim.RunFor(context.Background(), 2*time.Minute); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+
+	// Car 3 -> 1: 2 floors + door opening = 100ms tick + 2s + 1s.
+	if got, want := alice.WaitTime(), 3100*time.Millisecond; got != want {
+		t.Errorf("alice wait = %v, want %v", got, want)
+	}
+	// 1 -> 5: 3s hold + 1s closing + 4 floors + 1s opening (the tick coincides with the close).
+	if got, want := alice.RideTime(), 9*time.Second; got != want {
+		t.Errorf("alice ride = %v, want %v", got, want)
+	}
+	if alice.JourneyTime() != alice.WaitTime()+alice.RideTime() {
+		t.Errorf("alice journey %v != wait + ride", alice.JourneyTime())
+	}
+
+	// Bob was left behind by the full car and served on a later trip.
+	if bob.BoardedAt.IsZero() || !bob.BoardedAt.After(alice.AlightedAt) {
+		t.Errorf("bob boarded at %v, want after alice alighted at %v", bob.BoardedAt, alice.AlightedAt)
+	}
+	if bob.AlightedAt.IsZero() {
+		t.Error("bob never alighted")
+	}
+
+	want := []int{70, 0, 70, 0}
+	if len(weights) != len(want) {
+		t.Fatalf("weights %v, want %v", weights, want)
+	}
+	for i := range want {
+		if weights[i] != want[i] {
+			t.Errorf("weights %v, want %v", weights, want)
+			break
+		}
+	}
+	if e.Weight() != 0 || len(e.Riders()) != 0 || len(e.Waiting()) != 0 {
+		t.Errorf("car not empty: weight %d riders %d waiting %d", e.Weight(), len(e.Riders()), len(e.Waiting()))
+	}
+}
+
+func TestElevator_AddPassengerRearDoor(t *testing.T) {
+	sim := NewSimulation(testEpoch)
+	defer sim.Close()
+
+	e, err := sim.NewElevator(Config{
+		ID:           "P",
+		MinFloor:     1,
+		MaxFloor:     10,
+		InitialFloor: 3,
+		TravelTime:   time.Second,
+		DoorSpeed:    time.Second,
+		DoorOpenTime: 3 * time.Second,
+		FloorConfigs: map[int]FloorConfig{3: {FloorNumber: 3, IsAccessible: true, OpenDoorSide: Rear}},
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+
+	if err := e.AddCall(3, CallHallUp); err != nil {
+		t.Fatalf("AddCall: %v", err)
+	}
+	if err := sim.RunFor(context.Background(), 2*time.Second); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+	if e.Logic.Doors[Front] != DoorClose || e.Logic.Doors[Rear] != DoorOpen {
+		t.Fatalf("doors front %s rear %s, want rear open only", e.Logic.Doors[Front], e.Logic.Doors[Rear])
+	}
+
+	p := &Passenger{ID: "dave", Origin: 3, Destination: 6}
+	if err := e.AddPassenger(p); err != nil {
+		t.Fatalf("AddPassenger: %v", err)
+	}
+	if p.BoardedAt.IsZero() {
+		t.Error("passenger did not board through the open rear door")
+	}
+	if e.Logic.HallUpCalls[3] {
+		t.Error("hall call registered at the car's floor")
+	}
+}
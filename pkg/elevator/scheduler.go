@@ -0,0 +1,207 @@
+package elevator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Scheduler decides where an elevator should head next.
+// Scheduler는 엘리베이터가 다음에 향할 목표 층을 결정하는 배차 전략입니다.
+//
+// NextTarget is only consulted while calls are pending and the doors are closed.
+// When the returned target equals l.Floor the car stops there, and dir names the
+// hall direction being answered (DirNone answers every call at that floor).
+type Scheduler interface {
+	Name() string
+	NextTarget(l *ElevatorLogic) (target int, dir Direction, ok bool)
+}
+
+// Built-in scheduler names.
+const (
+	SchedulerCollective = "collective"
+	SchedulerSCAN       = "scan"
+	SchedulerLOOK       = "look"
+	SchedulerFCFS       = "fcfs"
+	SchedulerSSTF       = "sstf"
+)
+
+var schedulers = map[string]Scheduler{
+	SchedulerCollective: CollectiveScheduler{},
+	SchedulerSCAN:       SCANScheduler{},
+	SchedulerLOOK:       LOOKScheduler{},
+	SchedulerFCFS:       FCFSScheduler{},
+	SchedulerSSTF:       SSTFScheduler{},
+}
+
+// NewScheduler returns the built-in scheduler registered under name.
+// An empty name selects the default collective selective strategy.
+func NewScheduler(name string) (Scheduler, error) {
+	if name == "" {
+		return CollectiveScheduler{}, nil
+	}
+	s, ok := schedulers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown scheduler %q", name)
+	}
+	return s, nil
+}
+
+// SchedulerNames lists the built-in scheduler names in sorted order.
+func SchedulerNames() []string {
+	names := make([]string, 0, len(schedulers))
+	for name := range schedulers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CollectiveScheduler implements collective selective control.
+// Hall calls are answered only in their requested direction, except at the
+// reversal point where no calls remain further ahead.
+type CollectiveScheduler struct{}
+
+func (CollectiveScheduler) Name() string { return SchedulerCollective }
+
+func (CollectiveScheduler) NextTarget(l *ElevatorLogic) (int, Direction, bool) {
+	// Phase 0: Stop at the current floor if a call here is answerable.
+	if dir, ok := collectiveStop(l, l.Floor); ok {
+		return l.Floor, dir, true
+	}
+
+	// Phase 1: Current Direction Scan
+	if f, ok := sweepTarget(l); ok {
+		return f, l.Direction, true
+	}
+
+	// Phase 2: Nearest Call (Direction Reversal or Idle)
+	target, found := l.nearestAnyCall()
+	return target, directionTo(l.Floor, target), found
+}
+
+// collectiveStop decides whether a car travelling in l.Direction should stop at
+// floor, and which hall direction it answers there.
+func collectiveStop(l *ElevatorLogic, floor int) (Direction, bool) {
+	switch l.Direction {
+	case DirUp:
+		if l.CarCalls[floor] || l.HallUpCalls[floor] {
+			return DirUp, true
+		}
+		if l.HallDownCalls[floor] && !l.hasCallBeyond(floor, DirUp) {
+			return DirDown, true
+		}
+	case DirDown:
+		if l.CarCalls[floor] || l.HallDownCalls[floor] {
+			return DirDown, true
+		}
+		if l.HallUpCalls[floor] && !l.hasCallBeyond(floor, DirDown) {
+			return DirUp, true
+		}
+	default:
+		if l.HallUpCalls[floor] {
+			return DirUp, true
+		}
+		if l.HallDownCalls[floor] {
+			return DirDown, true
+		}
+		if l.CarCalls[floor] {
+			return DirNone, true
+		}
+	}
+	return DirNone, false
+}
+
+// LOOKScheduler sweeps in the current direction, stopping at every call
+// regardless of its hall direction, and reverses at the last call.
+type LOOKScheduler struct{}
+
+func (LOOKScheduler) Name() string { return SchedulerLOOK }
+
+func (LOOKScheduler) NextTarget(l *ElevatorLogic) (int, Direction, bool) {
+	if l.HasCallAt(l.Floor) {
+		return l.Floor, DirNone, true
+	}
+	if f, ok := sweepTarget(l); ok {
+		return f, l.Direction, true
+	}
+	target, found := l.nearestAnyCall()
+	return target, directionTo(l.Floor, target), found
+}
+
+// SCANScheduler behaves like LOOK but always runs to the terminal floor
+// before reversing, as long as calls remain behind the car.
+type SCANScheduler struct{}
+
+func (SCANScheduler) Name() string { return SchedulerSCAN }
+
+func (SCANScheduler) NextTarget(l *ElevatorLogic) (int, Direction, bool) {
+	if l.HasCallAt(l.Floor) {
+		return l.Floor, DirNone, true
+	}
+	if f, ok := sweepTarget(l); ok {
+		return f, l.Direction, true
+	}
+	switch {
+	case l.Direction == DirUp && l.Floor < l.Config.MaxFloor:
+		return l.Config.MaxFloor, DirUp, true
+	case l.Direction == DirDown && l.Floor > l.Config.MinFloor:
+		return l.Config.MinFloor, DirDown, true
+	}
+	target, found := l.nearestAnyCall()
+	return target, directionTo(l.Floor, target), found
+}
+
+// FCFSScheduler serves calls strictly in the order they were registered.
+type FCFSScheduler struct{}
+
+func (FCFSScheduler) Name() string { return SchedulerFCFS }
+
+func (FCFSScheduler) NextTarget(l *ElevatorLogic) (int, Direction, bool) {
+	pending := l.PendingCalls()
+	if len(pending) == 0 {
+		return 0, DirNone, false
+	}
+	target := pending[0].Floor
+	if target == l.Floor {
+		return target, DirNone, true
+	}
+	return target, directionTo(l.Floor, target), true
+}
+
+// SSTFScheduler (shortest seek time first) always heads for the nearest call.
+type SSTFScheduler struct{}
+
+func (SSTFScheduler) Name() string { return SchedulerSSTF }
+
+func (SSTFScheduler) NextTarget(l *ElevatorLogic) (int, Direction, bool) {
+	target, found := l.nearestAnyCall()
+	if !found {
+		return 0, DirNone, false
+	}
+	if target == l.Floor {
+		return target, DirNone, true
+	}
+	return target, directionTo(l.Floor, target), true
+}
+
+// sweepTarget returns the nearest call ahead of the car in its current direction.
+func sweepTarget(l *ElevatorLogic) (int, bool) {
+	switch l.Direction {
+	case DirUp:
+		return l.nearestCall(l.Floor+1, l.Config.MaxFloor)
+	case DirDown:
+		return l.nearestCall(l.Floor-1, l.Config.MinFloor)
+	}
+	return 0, false
+}
+
+func directionTo(from, to int) Direction {
+	switch {
+	case to > from:
+		return DirUp
+	case to < from:
+		return DirDown
+	}
+	return DirNone
+}
@@ -0,0 +1,328 @@
+package elevator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SnapshotVersion is the format version written in snapshots.
+const SnapshotVersion = 1
+
+// Timer kinds in a snapshot.
+const (
+	TimerTick   = "tick"
+	TimerDoor   = "door"
+	TimerTravel = "travel"
+)
+
+// Snapshot is the complete runtime state of an Elevator.
+// Snapshot은 엘리베이터의 런타임 상태 전체를 직렬화할 수 있는 형태로 담습니다.
+//
+// Timers are stored as the time remaining at TakenAt, so a snapshot can be
+// restored on any clock. Passenger timestamps are absolute; restore into a
+// clock reading TakenAt to keep wait and ride times meaningful.
+type Snapshot struct {
+	Version int
+	ID      string
+	TakenAt time.Time
+
+	Floor     int
+	Direction Direction
+	Doors     map[DoorSide]DoorState
+	Weight    int
+	Calls     []Call // 등록 순서
+
+	Mode               OperationMode
+	OpenButtonPressed  bool
+	CloseButtonPressed bool
+	OpenWaitTime       time.Duration
+	ServedDir          Direction
+	SmokeDetectors     []int // 작동 중인 연기 감지기 층
+	RecallFloor        int   // Phase I 귀환 층
+	Emergency          EmergencyPhase
+	Obstructed         bool          // 문 감지기 차단 중
+	ObstructedFor      time.Duration // 차단 지속 시간
+	Nudging            bool
+	Faults             []Fault // 주입된 고장 (종류 순)
+	Correction         bool    // 보정 운전 대기
+
+	Run      *RunSnapshot    // 주행 중이 아니면 nil
+	Jog      *JogSnapshot    // 점검 운전 이동 중이 아니면 nil
+	OffLevel float64         // 층 사이 정지 위치 (Floor 기준 m)
+	Timers   []TimerSnapshot // 예약된 타이머 (예약 순서)
+	Waiting  []Passenger
+	Riders   []Passenger
+}
+
+// RunSnapshot is the run in progress when a snapshot was taken.
+type RunSnapshot struct {
+	From, To int
+	Dir      Direction
+	Passed   int           // 지나온 층 수
+	Elapsed  time.Duration // 출발 후 경과 시간
+}
+
+// JogSnapshot is the inspection movement in progress when a snapshot was taken.
+type JogSnapshot struct {
+	Dir          Direction
+	From         float64 // 현재 구간 출발 위치 m
+	Next, Target int
+	Elapsed      time.Duration // 현재 구간 출발 후 경과 시간
+}
+
+// TimerSnapshot is a pending engine timer.
+type TimerSnapshot struct {
+	Kind      string
+	Remaining time.Duration
+}
+
+// armedTimer records when an engine timer fires and in which order it was
+// armed, so Restore re-arms timers in that order and ties resolve as before.
+type armedTimer struct {
+	deadline time.Time
+	seq      uint64 // 0: not armed
+}
+
+func (e *Elevator) armed(d time.Duration) armedTimer {
+	e.armSeq++
+	return armedTimer{deadline: e.clock.Now().Add(d), seq: e.armSeq}
+}
+
+// Snapshot captures the runtime state of the car.
+func (e *Elevator) Snapshot() Snapshot {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	now := e.clock.Now()
+	s := Snapshot{
+		Version:            SnapshotVersion,
+		ID:                 e.Config.ID,
+		TakenAt:            now,
+		Floor:              e.Logic.Floor,
+		Direction:          e.Logic.Direction,
+		Doors:              make(map[DoorSide]DoorState, len(e.Logic.Doors)),
+		Weight:             e.Logic.Weight,
+		Calls:              e.Logic.PendingCalls(),
+		Mode:               e.Mode,
+		OpenButtonPressed:  e.isOpenButtonPressed,
+		CloseButtonPressed: e.isCloseButtonPressed,
+		OpenWaitTime:       e.openWaitTime,
+		ServedDir:          e.servedDir,
+		SmokeDetectors:     sortedFloors(e.smoke),
+		RecallFloor:        e.recallFloor,
+		OffLevel:           e.offLevel,
+		Emergency:          e.emergency,
+		Obstructed:         e.obstructed,
+		Nudging:            e.nudging,
+		Faults:             e.activeFaults(),
+		Correction:         e.correction,
+	}
+	if e.obstructed {
+		s.ObstructedFor = now.Sub(e.obstructedAt)
+	}
+	for side, state := range e.Logic.Doors {
+		s.Doors[side] = state
+	}
+	if r := e.run; r != nil {
+		s.Run = &RunSnapshot{From: r.from, To: r.to, Dir: r.dir, Passed: r.passed, Elapsed: now.Sub(r.start)}
+	}
+	if j := e.jog; j != nil {
+		s.Jog = &JogSnapshot{Dir: j.dir, From: j.from, Next: j.next, Target: j.target, Elapsed: now.Sub(j.start)}
+	}
+
+	timers := []struct {
+		kind string
+		armedTimer
+	}{{TimerTick, e.tickArmed}, {TimerDoor, e.doorArmed}, {TimerTravel, e.travelArmed}}
+	sort.Slice(timers, func(i, j int) bool { return timers[i].seq < timers[j].seq })
+	for _, t := range timers {
+		if t.seq != 0 {
+			s.Timers = append(s.Timers, TimerSnapshot{Kind: t.kind, Remaining: t.deadline.Sub(now)})
+		}
+	}
+
+	for f := e.Config.MinFloor; f <= e.Config.MaxFloor; f++ {
+		for _, p := range e.waiting[f] {
+			s.Waiting = append(s.Waiting, *p)
+		}
+	}
+	for _, p := range e.riders {
+		s.Riders = append(s.Riders, *p)
+	}
+	return s
+}
+
+// Restore replaces the runtime state of the car with s and re-arms the timers
+// it recorded. The car keeps its configuration; s must fit its floor range.
+// No events are published. If the engine is not running yet, the tick is armed
+// when it starts.
+func (e *Elevator) Restore(s Snapshot) error {
+	if s.Version > SnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", s.Version)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.validateSnapshot(s); err != nil {
+		return fmt.Errorf("invalid snapshot for %s: %w", e.Config.ID, err)
+	}
+
+	logic := NewElevatorLogic(e.Logic.Config)
+	logic.Floor = s.Floor
+	logic.Direction = s.Direction
+	for side, state := range s.Doors {
+		logic.Doors[side] = state
+	}
+	logic.Weight = s.Weight
+	for _, c := range s.Calls {
+		if err := logic.AddCall(c.Floor, c.Type); err != nil {
+			return fmt.Errorf("invalid snapshot for %s: %w", e.Config.ID, err)
+		}
+	}
+
+	e.stopDoorTimer()
+	e.stopTravelTimer()
+	e.Logic = logic
+	e.Mode = s.Mode
+	e.isOpenButtonPressed = s.OpenButtonPressed
+	e.isCloseButtonPressed = s.CloseButtonPressed
+	e.recallFloor = s.RecallFloor
+	e.emergency = s.Emergency
+	e.obstructed, e.nudging = s.Obstructed, s.Nudging
+	e.faults = make(map[FaultKind]Fault)
+	for _, f := range s.Faults {
+		e.faults[f.Kind] = f
+	}
+	e.correction = s.Correction
+	e.smoke = make(map[int]bool)
+	for _, f := range s.SmokeDetectors {
+		e.smoke[f] = true
+	}
+	e.openWaitTime = s.OpenWaitTime
+	e.servedDir = s.ServedDir
+
+	e.waiting = make(map[int][]*Passenger)
+	for _, p := range s.Waiting {
+		e.waiting[p.Origin] = append(e.waiting[p.Origin], &p)
+	}
+	e.riders = nil
+	for _, p := range s.Riders {
+		e.riders = append(e.riders, &p)
+	}
+
+	e.run, e.jog, e.isMoving = nil, nil, false
+	e.offLevel = s.OffLevel
+	now := e.clock.Now()
+	e.obstructedAt = now.Add(-s.ObstructedFor)
+	if r := s.Run; r != nil {
+		e.run = e.planRun(now.Add(-r.Elapsed), r.From, r.To, r.Dir)
+		e.run.passed = r.Passed
+		e.isMoving = true
+	}
+	if j := s.Jog; j != nil {
+		e.jog = &jogRun{dir: j.Dir, start: now.Add(-j.Elapsed), from: j.From, next: j.Next, target: j.Target}
+		e.isMoving = true
+	}
+
+	for _, t := range s.Timers {
+		switch t.Kind {
+		case TimerTick:
+			if e.running {
+				e.armTick(t.Remaining)
+			}
+		case TimerDoor:
+			e.armDoorTimer(t.Remaining)
+		case TimerTravel:
+			e.armTravelTimer(t.Remaining)
+		}
+	}
+	e.logger.Info("State restored", "floor", s.Floor, "mode", s.Mode, "taken_at", s.TakenAt)
+	return nil
+}
+
+func (e *Elevator) validateSnapshot(s Snapshot) error {
+	inRange := func(f int) bool { return f >= e.Config.MinFloor && f <= e.Config.MaxFloor }
+	if !inRange(s.Floor) {
+		return fmt.Errorf("floor %d out of range", s.Floor)
+	}
+	if !s.Mode.Valid() {
+		return fmt.Errorf("unknown mode %d", s.Mode)
+	}
+	if !s.Emergency.valid() {
+		return fmt.Errorf("unknown emergency phase %q", s.Emergency)
+	}
+	if r := s.Run; r != nil {
+		if !inRange(r.From) || !inRange(r.To) || r.From == r.To || r.Dir != directionTo(r.From, r.To) {
+			return fmt.Errorf("invalid run %d -> %d %s", r.From, r.To, r.Dir)
+		}
+		if r.Passed < 0 || r.Passed >= abs(r.To-r.From) {
+			return fmt.Errorf("invalid run progress %d", r.Passed)
+		}
+	}
+	if j := s.Jog; j != nil {
+		if s.Run != nil || (j.Dir != DirUp && j.Dir != DirDown) || !inRange(j.Next) || !inRange(j.Target) {
+			return fmt.Errorf("invalid jog to %d %s", j.Target, j.Dir)
+		}
+	}
+	for _, f := range s.Faults {
+		if !f.Kind.valid() {
+			return fmt.Errorf("unknown fault %q", f.Kind)
+		}
+	}
+	for _, f := range s.SmokeDetectors {
+		if !inRange(f) {
+			return fmt.Errorf("smoke detector floor %d out of range", f)
+		}
+	}
+	for _, t := range s.Timers {
+		switch t.Kind {
+		case TimerTick, TimerDoor, TimerTravel:
+		default:
+			return fmt.Errorf("unknown timer %q", t.Kind)
+		}
+	}
+	for _, p := range append(append([]Passenger(nil), s.Waiting...), s.Riders...) {
+		if !inRange(p.Origin) || !inRange(p.Destination) {
+			return fmt.Errorf("passenger %s out of range", p.ID)
+		}
+	}
+	return nil
+}
+
+// GroupSnapshot is the runtime state of every car of a Group.
+// GroupSnapshot은 그룹에 속한 모든 카의 런타임 상태입니다.
+type GroupSnapshot struct {
+	ID      string
+	TakenAt time.Time
+	Cars    []Snapshot
+}
+
+// Snapshot captures the runtime state of every car.
+func (g *Group) Snapshot() GroupSnapshot {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	s := GroupSnapshot{ID: g.ID}
+	for _, car := range g.cars {
+		s.Cars = append(s.Cars, car.Snapshot())
+	}
+	if len(s.Cars) > 0 {
+		s.TakenAt = s.Cars[0].TakenAt
+	}
+	return s
+}
+
+// Restore restores every car from s. s must hold one snapshot per car.
+func (g *Group) Restore(s GroupSnapshot) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(s.Cars) != len(g.cars) {
+		return fmt.Errorf("snapshot has %d cars, group has %d", len(s.Cars), len(g.cars))
+	}
+	for i, car := range g.cars {
+		if err := car.Restore(s.Cars[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
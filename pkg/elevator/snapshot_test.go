@@ -0,0 +1,187 @@
+package elevator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestElevator_SnapshotRestore checks that a car restored from a snapshot
+// continues exactly like the original, whatever it was doing when the
+// snapshot was taken.
+func TestElevator_SnapshotRestore(t *testing.T) {
+	cfg := Config{
+		ID: "S", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+		TravelTime: time.Second, TravelTimeEdge: 1500 * time.Millisecond,
+		DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second, MaxWeight: 500,
+		Motion: &MotionProfile{RatedSpeed: 2, Acceleration: 1, Jerk: 2},
+	}
+	quiet := WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	const end = 90 * time.Second
+
+	// record formats every event after the snapshot time.
+	record := func(e *Elevator, from time.Time) *[]string {
+		var out []string
+		e.OnEvent(func(ev Event) {
+			if ev.Timestamp.After(from) {
+				out = append(out, fmt.Sprintf("%v %s %+v", ev.Timestamp.Sub(testEpoch), ev.Type, ev.Payload))
+			}
+		})
+		return &out
+	}
+
+	// script schedules the inputs due after from on clock.
+	script := func(clock *ManualClock, e *Elevator, from time.Time) {
+		inputs := []struct {
+			at time.Duration
+			f  func()
+		}{
+			{0, func() {
+				_ = e.AddCall(7, CallCar)
+				_ = e.AddPassenger(&Passenger{ID: "a", Origin: 9, Destination: 2, Mass: 80})
+			}},
+			{11 * time.Second, e.PressOpenButton},
+			{14 * time.Second, e.ReleaseOpenButton},
+			{20 * time.Second, func() { _ = e.AddCall(5, CallHallUp) }},
+		}
+		for _, in := range inputs {
+			if at := testEpoch.Add(in.at); !at.Before(from) {
+				clock.AfterFunc(at.Sub(clock.Now()), in.f)
+			}
+		}
+	}
+
+	for _, at := range []time.Duration{
+		2 * time.Second,         // moving to floor 7
+		8200 * time.Millisecond, // doors opening at 7
+		12 * time.Second,        // doors open, button held
+		30 * time.Second,        // moving down with riders
+		50 * time.Second,        // idle
+	} {
+		t.Run(at.String(), func(t *testing.T) {
+			ctx := context.Background()
+			sim := NewSimulation(testEpoch)
+			defer sim.Close()
+			e, err := sim.NewElevator(cfg, quiet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			script(sim.Clock(), e, testEpoch)
+
+			if err := sim.RunFor(ctx, at); err != nil {
+				t.Fatal(err)
+			}
+			snap := e.Snapshot()
+			data, err := json.Marshal(snap)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := record(e, snap.TakenAt)
+			if err := sim.RunUntil(ctx, testEpoch.Add(end)); err != nil {
+				t.Fatal(err)
+			}
+
+			var decoded Snapshot
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded.Calls, snap.Calls) || !reflect.DeepEqual(decoded.Doors, snap.Doors) {
+				t.Fatalf("JSON round trip changed the snapshot: %+v vs %+v", decoded, snap)
+			}
+
+			sim2 := NewSimulation(decoded.TakenAt)
+			defer sim2.Close()
+			e2, err := sim2.NewElevator(cfg, quiet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Inputs are scheduled before the restored timers, as in the original run.
+			script(sim2.Clock(), e2, snap.TakenAt.Add(time.Nanosecond))
+			if err := e2.Restore(decoded); err != nil {
+				t.Fatalf("Restore: %v", err)
+			}
+			got := record(e2, snap.TakenAt)
+			if err := sim2.RunUntil(ctx, testEpoch.Add(end)); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(*want) == 0 && at < 50*time.Second {
+				t.Fatal("no events after the snapshot")
+			}
+			for i := 0; i < len(*got) || i < len(*want); i++ {
+				if i >= len(*got) || i >= len(*want) || (*got)[i] != (*want)[i] {
+					t.Fatalf("restored run differs at event %d:\n got %q\nwant %q", i, tail(*got, i), tail(*want, i))
+				}
+			}
+		})
+	}
+}
+
+// TestElevator_RestoreRearmsTick checks that a tick already fired when
+// Restore re-arms the tick timer (blocked on the lock under RealClock) does
+// not start a second tick chain.
+func TestElevator_RestoreRearmsTick(t *testing.T) {
+	sim := NewSimulation(testEpoch)
+	defer sim.Close()
+	e, err := sim.NewElevator(Config{
+		ID: "S", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+		TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second,
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.RunFor(context.Background(), time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	e.mu.RLock()
+	stale := e.tickGen
+	e.mu.RUnlock()
+	if err := e.Restore(e.Snapshot()); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	pending, armed := sim.Clock().Pending(), e.tickArmed
+	e.onTick(stale)
+	if got := sim.Clock().Pending(); got != pending {
+		t.Errorf("stale tick left %d pending timers, want %d", got, pending)
+	}
+	if e.tickArmed != armed {
+		t.Errorf("stale tick re-armed the tick: %+v, want %+v", e.tickArmed, armed)
+	}
+}
+
+func TestElevator_RestoreInvalid(t *testing.T) {
+	e, err := New(Config{ID: "S", MinFloor: 1, MaxFloor: 5, InitialFloor: 1}, WithEventBuffer(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		snap Snapshot
+	}{
+		{"floor out of range", Snapshot{Floor: 9}},
+		{"unknown mode", Snapshot{Floor: 1, Mode: 7}},
+		{"bad run", Snapshot{Floor: 1, Run: &RunSnapshot{From: 1, To: 3, Dir: DirDown}}},
+		{"bad call", Snapshot{Floor: 1, Calls: []Call{{Floor: 5, Type: CallHallUp}}}},
+		{"newer version", Snapshot{Version: SnapshotVersion + 1, Floor: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := e.Restore(tt.snap); err == nil {
+				t.Error("Restore() succeeded, want error")
+			}
+		})
+	}
+}
+
+func tail(events []string, i int) []string {
+	if i >= len(events) {
+		return nil
+	}
+	return events[i:min(i+3, len(events))]
+}
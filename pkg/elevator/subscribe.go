@@ -0,0 +1,240 @@
+package elevator
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultSubscriptionBuffer is the buffer of a subscription when
+// SubscribeOptions.Buffer is 0.
+const DefaultSubscriptionBuffer = 256
+
+// Backpressure decides what a subscription does with an event when its
+// buffer is full.
+// Backpressure는 구독 버퍼가 가득 찼을 때 이벤트 처리 방식입니다.
+type Backpressure string
+
+const (
+	DropOldest     Backpressure = "DropOldest"     // 가장 오래된 이벤트를 버리고 새 이벤트를 넣음 (기본)
+	DropNewest     Backpressure = "DropNewest"     // 새 이벤트를 버림
+	Block          Backpressure = "Block"          // 자리가 날 때까지 엔진이 대기
+	CoalesceLatest Backpressure = "CoalesceLatest" // 같은 상태의 이전 이벤트를 최신 이벤트로 대체
+)
+
+func (b Backpressure) valid() bool {
+	switch b {
+	case DropOldest, DropNewest, Block, CoalesceLatest:
+		return true
+	}
+	return false
+}
+
+// SubscribeOptions configures a subscription.
+// SubscribeOptions는 이벤트 구독 설정입니다.
+type SubscribeOptions struct {
+	Types  []EventType  // 받을 이벤트 종류 (비어 있으면 전부)
+	Buffer int          // 채널 버퍼 크기 (0이면 DefaultSubscriptionBuffer)
+	Policy Backpressure // 버퍼가 가득 찼을 때의 처리 (비어 있으면 DropOldest)
+}
+
+// Subscription is an event stream of its own for one consumer.
+// Subscription은 한 소비자 전용 이벤트 스트림입니다.
+//
+// With CoalesceLatest, a full buffer keeps only the latest event of each
+// state (floor, direction, mode, position, each door, obstruction, nudging),
+// so a slow consumer still sees where the car ended up; other events are
+// dropped oldest first. With Block, the engine waits for the consumer: the
+// consumer must keep receiving and must not call into the Elevator while the
+// buffer is full, or both stall.
+type Subscription struct {
+	stream[Event]
+}
+
+// stream is the buffered channel of a subscription and its backpressure
+// state, shared by car and group subscriptions.
+type stream[T any] struct {
+	ch      chan T
+	accept  func(T) bool        // 필터 (nil이면 전부)
+	key     func(T) (any, bool) // CoalesceLatest에서 같은 상태로 볼 키
+	policy  Backpressure
+	done    chan struct{}
+	stop    sync.Once
+	dropped atomic.Uint64
+}
+
+// Events returns the stream of the subscription. It is closed by Unsubscribe.
+func (s *stream[T]) Events() <-chan T {
+	return s.ch
+}
+
+// Dropped returns how many events the subscription discarded or coalesced
+// because its buffer was full.
+func (s *stream[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// normalize validates opts and fills in the defaults.
+func (opts SubscribeOptions) normalize() (SubscribeOptions, error) {
+	if opts.Policy == "" {
+		opts.Policy = DropOldest
+	}
+	if !opts.Policy.valid() {
+		return opts, errorf(ErrInvalid, "unknown backpressure policy %q", opts.Policy)
+	}
+	if opts.Buffer < 0 {
+		return opts, errorf(ErrInvalid, "negative subscription buffer %d", opts.Buffer)
+	}
+	if opts.Buffer == 0 {
+		opts.Buffer = DefaultSubscriptionBuffer
+	}
+	return opts, nil
+}
+
+// Subscribe opens a new event stream. Every subscription receives every
+// published event it selects, independently of the others and of OnEvent.
+func (e *Elevator) Subscribe(opts SubscribeOptions) (*Subscription, error) {
+	opts, err := opts.normalize()
+	if err != nil {
+		return nil, err
+	}
+	s := newSubscription(opts)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.subs = append(e.subs, s)
+	return s, nil
+}
+
+func newSubscription(opts SubscribeOptions) *Subscription {
+	types := typeSet(opts.Types)
+	s := &Subscription{}
+	s.init(opts, func(ev Event) bool { return types == nil || types[ev.Type] }, func(ev Event) (any, bool) {
+		return coalesceKey(ev)
+	})
+	return s
+}
+
+func typeSet(types []EventType) map[EventType]bool {
+	if len(types) == 0 {
+		return nil
+	}
+	set := make(map[EventType]bool, len(types))
+	for _, t := range types {
+		set[t] = true
+	}
+	return set
+}
+
+func (s *stream[T]) init(opts SubscribeOptions, accept func(T) bool, key func(T) (any, bool)) {
+	s.ch = make(chan T, opts.Buffer)
+	s.accept = accept
+	s.key = key
+	s.policy = opts.Policy
+	s.done = make(chan struct{})
+}
+
+// cancel releases a publisher blocked on s. It must be called before taking
+// the lock the publisher holds.
+func (s *stream[T]) cancel() {
+	s.stop.Do(func() { close(s.done) })
+}
+
+// Unsubscribe ends the subscription and closes its stream. Events still
+// buffered can be received before the close.
+func (e *Elevator) Unsubscribe(s *Subscription) {
+	s.cancel()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, sub := range e.subs {
+		if sub == s {
+			e.subs = append(e.subs[:i], e.subs[i+1:]...)
+			close(s.ch)
+			return
+		}
+	}
+}
+
+// deliver hands ev to the subscription according to its policy and returns
+// how many events it discarded. The caller holds the lock guarding the
+// subscription list, so it is the only sender on s.ch.
+func (s *stream[T]) deliver(ev T) int {
+	if s.accept != nil && !s.accept(ev) {
+		return 0
+	}
+	select {
+	case s.ch <- ev:
+		return 0
+	default:
+	}
+
+	n := 1
+	switch s.policy {
+	case Block:
+		select {
+		case s.ch <- ev:
+			n = 0
+		case <-s.done:
+		}
+	case DropOldest:
+		select {
+		case <-s.ch:
+		default: // the consumer made room meanwhile
+			n = 0
+		}
+		s.ch <- ev
+	case CoalesceLatest:
+		n = s.coalesce(ev)
+	}
+	s.dropped.Add(uint64(n))
+	return n
+}
+
+// coalesce replaces the buffered events of the same state as ev by ev, or
+// drops the oldest event if there is none, and returns how many it discarded.
+func (s *stream[T]) coalesce(ev T) int {
+	key, state := s.key(ev)
+	queued := make([]T, 0, cap(s.ch))
+drain:
+	for {
+		select {
+		case q := <-s.ch:
+			queued = append(queued, q)
+		default:
+			break drain
+		}
+	}
+
+	kept := queued[:0]
+	for _, q := range queued {
+		if k, ok := s.key(q); !(state && ok && k == key) {
+			kept = append(kept, q)
+		}
+	}
+	if len(kept) == cap(s.ch) {
+		kept = kept[1:]
+	}
+	for _, q := range kept {
+		s.ch <- q
+	}
+	s.ch <- ev
+	return len(queued) - len(kept)
+}
+
+// stateKey identifies the state an event reports.
+type stateKey struct {
+	Type EventType
+	Side DoorSide
+}
+
+// coalesceKey returns the state ev reports, if it reports one.
+func coalesceKey(ev Event) (stateKey, bool) {
+	switch ev.Type {
+	case EventFloorChange, EventDirectionChange, EventModeChange, EventPosition, EventObstruction, EventNudging:
+		return stateKey{Type: ev.Type}, true
+	case EventDoorChange:
+		if p, ok := ev.Payload.(DoorChangePayload); ok {
+			return stateKey{Type: ev.Type, Side: p.Side}, true
+		}
+	}
+	return stateKey{}, false
+}
@@ -0,0 +1,115 @@
+package elevator
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestElevator_Subscribe(t *testing.T) {
+	sim := NewSimulation(testEpoch)
+	defer sim.Close()
+	e, err := sim.NewElevator(Config{
+		ID: "S", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+		TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second,
+	}, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+	if _, err := e.Subscribe(SubscribeOptions{Policy: "Sometimes"}); err == nil {
+		t.Error("Subscribe with an unknown policy succeeded, want error")
+	}
+
+	var all []Event
+	e.OnEvent(func(ev Event) { all = append(all, ev) })
+	subscribe := func(opts SubscribeOptions) *Subscription {
+		s, err := e.Subscribe(opts)
+		if err != nil {
+			t.Fatalf("Subscribe(%+v): %v", opts, err)
+		}
+		return s
+	}
+	const buffer = 4
+	oldest := subscribe(SubscribeOptions{Buffer: buffer})
+	newest := subscribe(SubscribeOptions{Buffer: buffer, Policy: DropNewest})
+	latest := subscribe(SubscribeOptions{Buffer: buffer, Policy: CoalesceLatest})
+	floors := subscribe(SubscribeOptions{Types: []EventType{EventFloorChange}, Policy: DropNewest})
+	blocking := subscribe(SubscribeOptions{Buffer: 1, Policy: Block})
+
+	// Only the blocking subscription is read while the car runs.
+	var blocked []Event
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for ev := range blocking.Events() {
+			blocked = append(blocked, ev)
+		}
+	}()
+
+	sim.At(0, func() { _ = e.AddCall(4, CallCar) })
+	if err := sim.RunFor(context.Background(), time.Minute); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+	for _, s := range []*Subscription{oldest, newest, latest, floors, blocking} {
+		e.Unsubscribe(s)
+	}
+	<-done
+
+	drain := func(s *Subscription) []Event {
+		var out []Event
+		for ev := range s.Events() {
+			out = append(out, ev)
+		}
+		return out
+	}
+	var allFloors []Event
+	for _, ev := range all {
+		if ev.Type == EventFloorChange {
+			allFloors = append(allFloors, ev)
+		}
+	}
+	n := len(all)
+
+	tests := []struct {
+		name    string
+		sub     *Subscription
+		got     []Event
+		want    []Event
+		dropped uint64
+	}{
+		{"drop oldest keeps the last events", oldest, drain(oldest), all[n-buffer:], uint64(n - buffer)},
+		{"drop newest keeps the first events", newest, drain(newest), all[:buffer], uint64(n - buffer)},
+		{"type filter", floors, drain(floors), allFloors, 0},
+		{"block delivers every event", blocking, blocked, all, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("events = %v, want %v", tt.got, tt.want)
+			}
+			if d := tt.sub.Dropped(); d != tt.dropped {
+				t.Errorf("Dropped() = %d, want %d", d, tt.dropped)
+			}
+		})
+	}
+
+	// Coalescing keeps the latest event of each state, in publish order.
+	got := drain(latest)
+	seen := make(map[stateKey]bool)
+	for _, ev := range got {
+		k, ok := coalesceKey(ev)
+		if ok && seen[k] {
+			t.Errorf("coalesced stream has two %v events: %v", k, got)
+		}
+		seen[k] = true
+	}
+	if last := got[len(got)-1]; !reflect.DeepEqual(last, all[n-1]) {
+		t.Errorf("last coalesced event = %v, want %v", last, all[n-1])
+	}
+	if d := latest.Dropped(); d != uint64(n-len(got)) {
+		t.Errorf("coalesced Dropped() = %d, want %d", d, n-len(got))
+	}
+}
@@ -0,0 +1,106 @@
+// Package faults schedules equipment failures into running elevators.
+// 이 패키지는 정해진 일정에 따라 엘리베이터에 장치 고장을 주입하고 해제합니다.
+//
+// Faults are injected on demand with Elevator.InjectFault and ClearFault; a
+// Plan injects them at fixed offsets, so a controller can be tested against
+// the same failures run after run.
+package faults
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+// Target receives injected faults. *elevator.Elevator satisfies it.
+type Target interface {
+	InjectFault(f elevator.Fault) error
+	ClearFault(kind elevator.FaultKind) error
+}
+
+// Injection is a fault injected at a point in time.
+// Injection은 특정 시각에 주입되는 고장입니다.
+type Injection struct {
+	At       time.Duration // 계획 시작 기준 주입 시각
+	Duration time.Duration // 고장 지속 시간 (0이면 직접 해제할 때까지 유지)
+	Fault    elevator.Fault
+}
+
+// Plan is a list of injections.
+type Plan []Injection
+
+// Validate checks the timing of every injection. The fault itself is checked
+// by the target when it is injected.
+func (p Plan) Validate() error {
+	for i, in := range p {
+		if in.At < 0 || in.Duration < 0 {
+			return fmt.Errorf("invalid injection %d: negative time", i)
+		}
+		if in.Fault.Kind == "" {
+			return fmt.Errorf("invalid injection %d: no fault", i)
+		}
+	}
+	return nil
+}
+
+// Schedule is a plan attached to a clock; see Attach.
+type Schedule struct {
+	mu      sync.Mutex
+	timers  []elevator.Timer
+	stopped bool
+}
+
+// Stop cancels the pending injections and clears. Faults already injected
+// stay active. It is safe to call more than once.
+func (s *Schedule) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	for _, t := range s.timers {
+		t.Stop()
+	}
+	s.timers = nil
+}
+
+// Attach schedules the injections of plan on clock, at offsets from now, and
+// clears each fault after its Duration. Injections at the same offset run in
+// plan order. Errors from the target are passed to onError if it is non-nil.
+func Attach(clock elevator.Clock, target Target, plan Plan, onError func(Injection, error)) (*Schedule, error) {
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+	report := func(in Injection, err error) {
+		if err != nil && onError != nil {
+			onError(in, err)
+		}
+	}
+
+	// Order by time so ties keep plan order on clocks that fire timers in
+	// the order they were armed.
+	plan = append(Plan(nil), plan...)
+	sort.SliceStable(plan, func(i, j int) bool { return plan[i].At < plan[j].At })
+
+	s := &Schedule{}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, in := range plan {
+		s.timers = append(s.timers, clock.AfterFunc(in.At, func() {
+			report(in, target.InjectFault(in.Fault))
+			if in.Duration == 0 {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.stopped {
+				return
+			}
+			s.timers = append(s.timers, clock.AfterFunc(in.Duration, func() {
+				report(in, target.ClearFault(in.Fault.Kind))
+			}))
+		}))
+	}
+	return s, nil
+}
@@ -0,0 +1,93 @@
+package faults
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+var epoch = time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+func TestPlan_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		plan Plan
+	}{
+		{"negative time", Plan{{At: -time.Second, Fault: elevator.Fault{Kind: elevator.FaultDriveTrip}}}},
+		{"negative duration", Plan{{Duration: -time.Second, Fault: elevator.Fault{Kind: elevator.FaultDriveTrip}}}},
+		{"no fault", Plan{{At: time.Second}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.plan.Validate(); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestAttach(t *testing.T) {
+	sim := elevator.NewSimulation(epoch)
+	defer sim.Close()
+	e, err := sim.NewElevator(elevator.Config{
+		ID: "E1", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+		DoorSpeed: time.Second, DoorOpenTime: 2 * time.Second, TravelTime: time.Second,
+	}, elevator.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+
+	var got []string
+	e.OnEvent(func(ev elevator.Event) {
+		switch ev.Type {
+		case elevator.EventError, elevator.EventFaultCleared:
+			p := ev.Payload.(elevator.ErrorPayload)
+			if p.Fault == nil {
+				break // a rejected injection
+			}
+			got = append(got, ev.Timestamp.Sub(epoch).String()+" "+string(ev.Type)+" "+string(p.Fault.Kind))
+		}
+	})
+
+	var rejected []elevator.FaultKind
+	plan := Plan{
+		{At: 10 * time.Second, Duration: 5 * time.Second, Fault: elevator.Fault{Kind: elevator.FaultDriveTrip}},
+		{At: 2 * time.Second, Fault: elevator.Fault{Kind: elevator.FaultStuckHallButton, Floor: 3, Dir: elevator.DirUp}},
+		{At: 12 * time.Second, Fault: elevator.Fault{Kind: elevator.FaultDriveTrip}}, // already active
+		{At: 20 * time.Second, Fault: elevator.Fault{Kind: elevator.FaultDoorJam}},   // after Stop
+	}
+	s, err := Attach(sim.Clock(), e, plan, func(in Injection, err error) {
+		rejected = append(rejected, in.Fault.Kind)
+	})
+	if err != nil {
+		t.Fatalf("Attach: %v", err)
+	}
+	if err := sim.RunFor(context.Background(), 18*time.Second); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+	s.Stop()
+	if err := sim.RunFor(context.Background(), 10*time.Second); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+
+	want := []string{"2s Error StuckHallButton", "10s Error DriveTrip", "15s FaultCleared DriveTrip"}
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("events = %v, want %v", got, want)
+			break
+		}
+	}
+	if len(rejected) != 1 || rejected[0] != elevator.FaultDriveTrip {
+		t.Errorf("rejected = %v, want the second DriveTrip", rejected)
+	}
+	if f := e.ActiveFaults(); len(f) != 1 || f[0].Kind != elevator.FaultStuckHallButton {
+		t.Errorf("ActiveFaults = %v, want the stuck button only", f)
+	}
+}
@@ -0,0 +1,230 @@
+package grpcserver
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "go-elevator-simulator/api/elevator/v1"
+	"go-elevator-simulator/pkg/elevator"
+)
+
+// callTypes maps call types to the engine; an unspecified type is a car call.
+var callTypes = map[pb.CallType]elevator.CallType{
+	pb.CallType_CALL_TYPE_UNSPECIFIED: elevator.CallCar,
+	pb.CallType_CALL_TYPE_CAR:         elevator.CallCar,
+	pb.CallType_CALL_TYPE_HALL_UP:     elevator.CallHallUp,
+	pb.CallType_CALL_TYPE_HALL_DOWN:   elevator.CallHallDown,
+}
+
+// hallDirections maps hall call types to the group's hall call directions.
+var hallDirections = map[elevator.CallType]elevator.Direction{
+	elevator.CallHallUp:   elevator.DirUp,
+	elevator.CallHallDown: elevator.DirDown,
+}
+
+var directions = map[elevator.Direction]pb.Direction{
+	elevator.DirUp:   pb.Direction_DIRECTION_UP,
+	elevator.DirDown: pb.Direction_DIRECTION_DOWN,
+	elevator.DirNone: pb.Direction_DIRECTION_NONE,
+}
+
+var doorStates = map[elevator.DoorState]pb.DoorState{
+	elevator.DoorOpen:    pb.DoorState_DOOR_STATE_OPEN,
+	elevator.DoorOpening: pb.DoorState_DOOR_STATE_OPENING,
+	elevator.DoorClosing: pb.DoorState_DOOR_STATE_CLOSING,
+	elevator.DoorClose:   pb.DoorState_DOOR_STATE_CLOSE,
+}
+
+var emergencyPhases = map[elevator.EmergencyPhase]pb.EmergencyPhase{
+	elevator.EmergencyLatched: pb.EmergencyPhase_EMERGENCY_PHASE_LATCHED,
+	elevator.EmergencyReset:   pb.EmergencyPhase_EMERGENCY_PHASE_RESET,
+	elevator.EmergencyRescue:  pb.EmergencyPhase_EMERGENCY_PHASE_RESCUE,
+	elevator.EmergencyRescued: pb.EmergencyPhase_EMERGENCY_PHASE_RESCUED,
+}
+
+var faultKinds = map[elevator.FaultKind]pb.FaultKind{
+	elevator.FaultDoorJam:         pb.FaultKind_FAULT_KIND_DOOR_JAM,
+	elevator.FaultDoorLock:        pb.FaultKind_FAULT_KIND_DOOR_LOCK,
+	elevator.FaultDriveTrip:       pb.FaultKind_FAULT_KIND_DRIVE_TRIP,
+	elevator.FaultLeveling:        pb.FaultKind_FAULT_KIND_LEVELING,
+	elevator.FaultOverspeed:       pb.FaultKind_FAULT_KIND_OVERSPEED,
+	elevator.FaultPositionSensor:  pb.FaultKind_FAULT_KIND_POSITION_SENSOR,
+	elevator.FaultStuckHallButton: pb.FaultKind_FAULT_KIND_STUCK_HALL_BUTTON,
+}
+
+var policies = map[pb.Backpressure]elevator.Backpressure{
+	pb.Backpressure_BACKPRESSURE_UNSPECIFIED:     elevator.DropOldest,
+	pb.Backpressure_BACKPRESSURE_DROP_NEWEST:     elevator.DropNewest,
+	pb.Backpressure_BACKPRESSURE_BLOCK:           elevator.Block,
+	pb.Backpressure_BACKPRESSURE_COALESCE_LATEST: elevator.CoalesceLatest,
+}
+
+// fromProto looks up the engine value of v in a map from engine to protobuf
+// values. Values without an engine counterpart map to the zero value, which
+// the engine rejects as invalid.
+func fromProto[E comparable, P comparable](m map[E]P, v P) E {
+	for e, p := range m {
+		if p == v {
+			return e
+		}
+	}
+	var zero E
+	return zero
+}
+
+// mode converts an operation mode. The protobuf enum is shifted by one to
+// keep 0 for an unspecified mode.
+func mode(m elevator.OperationMode) pb.OperationMode {
+	return pb.OperationMode(m + 1)
+}
+
+func modeFromProto(m pb.OperationMode) elevator.OperationMode {
+	return elevator.OperationMode(m - 1)
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func timeFromProto(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
+
+func fault(f elevator.Fault) *pb.Fault {
+	return &pb.Fault{Kind: faultKinds[f.Kind], Floor: int32(f.Floor), Direction: directions[f.Dir], Offset: f.Offset}
+}
+
+func faultFromProto(f *pb.Fault) elevator.Fault {
+	if f == nil {
+		return elevator.Fault{}
+	}
+	return elevator.Fault{
+		Kind:   fromProto(faultKinds, f.Kind),
+		Floor:  int(f.Floor),
+		Dir:    fromProto(directions, f.Direction),
+		Offset: f.Offset,
+	}
+}
+
+func passenger(p elevator.Passenger) *pb.Passenger {
+	return &pb.Passenger{
+		Id:          p.ID,
+		Origin:      int32(p.Origin),
+		Destination: int32(p.Destination),
+		Mass:        int32(p.Mass),
+		ArrivedAt:   timestamp(p.ArrivedAt),
+		BoardedAt:   timestamp(p.BoardedAt),
+		AlightedAt:  timestamp(p.AlightedAt),
+	}
+}
+
+func passengerFromProto(p *pb.Passenger) *elevator.Passenger {
+	return &elevator.Passenger{
+		ID:          p.GetId(),
+		Origin:      int(p.GetOrigin()),
+		Destination: int(p.GetDestination()),
+		Mass:        int(p.GetMass()),
+		ArrivedAt:   timeFromProto(p.GetArrivedAt()),
+	}
+}
+
+func ints(s []int) []int32 {
+	out := make([]int32, len(s))
+	for i, v := range s {
+		out[i] = int32(v)
+	}
+	return out
+}
+
+// carState returns the state of car i.
+func carState(i int, car *elevator.Elevator) *pb.CarState {
+	floor, direction, doors, weight := car.CurrentState()
+	calls := car.CallFloors()
+	pos := car.Position()
+	st := &pb.CarState{
+		Car:       int32(i),
+		Id:        car.Config.ID,
+		Floor:     int32(floor),
+		Direction: directions[direction],
+		Doors: &pb.Doors{
+			Front: doorStates[doors[elevator.Front]],
+			Rear:  doorStates[doors[elevator.Rear]],
+		},
+		Mode:          mode(car.CurrentMode()),
+		CarCalls:      ints(calls.Car),
+		HallUpCalls:   ints(calls.HallUp),
+		HallDownCalls: ints(calls.HallDown),
+		Weight:        int32(weight),
+		Position:      pos.Position,
+		Speed:         pos.Speed,
+		Level:         pos.Level,
+		Emergency:     emergencyPhases[car.EmergencyPhase()],
+		Obstructed:    car.Obstructed(),
+		Nudging:       car.Nudging(),
+	}
+	for _, f := range car.ActiveFaults() {
+		st.Faults = append(st.Faults, fault(f))
+	}
+	return st
+}
+
+// groupState returns the state of every car of g and of the group.
+func groupState(g *elevator.Group) *pb.GroupState {
+	cars := g.Cars()
+	hall := g.HallCalls()
+	st := &pb.GroupState{
+		HallUpCalls:    ints(hall.HallUp),
+		HallDownCalls:  ints(hall.HallDown),
+		MaxWeight:      int32(cars[0].Config.MaxWeight),
+		SmokeDetectors: ints(cars[0].SmokeDetectors()),
+	}
+	for i, car := range cars {
+		st.Cars = append(st.Cars, carState(i, car))
+	}
+	return st
+}
+
+// event converts an event of car i. Payloads of unknown types are left out.
+func event(i int, ev elevator.Event) *pb.Event {
+	out := &pb.Event{Car: int32(i), Type: string(ev.Type), Time: timestamp(ev.Timestamp)}
+	switch p := ev.Payload.(type) {
+	case int:
+		out.Payload = &pb.Event_Floor{Floor: int32(p)}
+	case elevator.Direction:
+		out.Payload = &pb.Event_Direction{Direction: directions[p]}
+	case elevator.OperationMode:
+		out.Payload = &pb.Event_Mode{Mode: mode(p)}
+	case elevator.DoorChangePayload:
+		out.Payload = &pb.Event_Door{Door: &pb.DoorChange{Side: pb.DoorSide(p.Side), State: doorStates[p.State]}}
+	case elevator.ArrivedPayload:
+		out.Payload = &pb.Event_Arrival{Arrival: &pb.Arrival{Floor: int32(p.Floor), OpenDoorSide: pb.DoorSide(p.OpenDoorSide)}}
+	case elevator.PositionPayload:
+		out.Payload = &pb.Event_Position{Position: &pb.Position{Position: p.Position, Speed: p.Speed, Level: p.Level}}
+	case elevator.ErrorPayload:
+		detail := &pb.ErrorDetail{Code: string(p.Code), Op: p.Op, Floor: int32(p.Floor), Message: p.Message}
+		if p.Fault != nil {
+			detail.Fault = fault(*p.Fault)
+		}
+		out.Payload = &pb.Event_Error{Error: detail}
+	case elevator.PassengerPayload:
+		out.Payload = &pb.Event_Passenger{Passenger: &pb.PassengerMove{Passenger: passenger(p.Passenger), Floor: int32(p.Floor)}}
+	case elevator.EmergencyPayload:
+		out.Payload = &pb.Event_Emergency{Emergency: &pb.Emergency{Floor: int32(p.Floor), Level: p.Level}}
+	case elevator.SmokeDetectorPayload:
+		out.Payload = &pb.Event_SmokeDetector{SmokeDetector: &pb.SmokeDetector{Floor: int32(p.Floor), Active: p.Active}}
+	case elevator.RecallPayload:
+		out.Payload = &pb.Event_Recall{Recall: &pb.Recall{Floor: int32(p.Floor), Alternate: p.Alternate}}
+	case elevator.ObstructionPayload:
+		out.Payload = &pb.Event_Obstruction{Obstruction: &pb.Obstruction{Blocked: p.Blocked}}
+	case elevator.NudgingPayload:
+		out.Payload = &pb.Event_Nudging{Nudging: &pb.Nudging{Active: p.Active}}
+	}
+	return out
+}
@@ -0,0 +1,283 @@
+// Package grpcserver serves an elevator group over gRPC.
+// 이 패키지는 엘리베이터 군을 gRPC 서비스(api/elevator/v1)로 제공합니다.
+//
+// A program embedding the simulator, such as a building-management backend
+// using it as a digital twin, registers a Server on its own grpc.Server:
+//
+//	g, _ := elevator.NewGroup(cfg)
+//	go g.Run(ctx)
+//	s := grpc.NewServer()
+//	grpcserver.New(g).Register(s)
+//
+// Commands answer with the state of the car after the command. A rejected
+// command fails with a status whose code follows the engine error code and
+// whose ErrorInfo detail carries the engine code as its reason.
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go-elevator-simulator/api/elevator/v1"
+	"go-elevator-simulator/pkg/elevator"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail of a rejected command.
+const ErrorDomain = "elevator"
+
+// Server implements ElevatorService for one elevator group.
+// Server는 엘리베이터 군 하나에 대한 ElevatorService 구현입니다.
+type Server struct {
+	pb.UnimplementedElevatorServiceServer
+
+	group *elevator.Group
+}
+
+// New returns a server controlling g. The caller runs g.
+func New(g *elevator.Group) *Server {
+	return &Server{group: g}
+}
+
+// Register registers the service on s.
+func (s *Server) Register(r grpc.ServiceRegistrar) {
+	pb.RegisterElevatorServiceServer(r, s)
+}
+
+func (s *Server) GetState(ctx context.Context, req *pb.GetStateRequest) (*pb.GroupState, error) {
+	return groupState(s.group), nil
+}
+
+func (s *Server) AddCall(ctx context.Context, req *pb.AddCallRequest) (*pb.CarState, error) {
+	t := callTypes[req.CallType]
+	if dir, ok := hallDirections[t]; ok {
+		i, err := s.group.AddHallCall(int(req.Floor), dir)
+		if err != nil {
+			return nil, statusError(err)
+		}
+		return s.state(int32(i))
+	}
+	return s.command(req.Car, func(car *elevator.Elevator) error {
+		return car.AddCall(int(req.Floor), t)
+	})
+}
+
+func (s *Server) RemoveCall(ctx context.Context, req *pb.RemoveCallRequest) (*pb.CarState, error) {
+	t := callTypes[req.CallType]
+	if dir, ok := hallDirections[t]; ok {
+		s.group.RemoveHallCall(int(req.Floor), dir)
+		return s.state(req.Car)
+	}
+	return s.do(req.Car, func(car *elevator.Elevator) { car.RemoveCall(int(req.Floor), t) })
+}
+
+func (s *Server) ClearCalls(ctx context.Context, req *pb.CarRequest) (*pb.CarState, error) {
+	return s.do(req.Car, (*elevator.Elevator).ClearCalls)
+}
+
+func (s *Server) AddPassenger(ctx context.Context, req *pb.AddPassengerRequest) (*pb.CarState, error) {
+	if req.Passenger == nil {
+		return nil, status.Error(codes.InvalidArgument, "passenger is required")
+	}
+	i, err := s.group.AddPassenger(passengerFromProto(req.Passenger))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return s.state(int32(i))
+}
+
+func (s *Server) AddWeight(ctx context.Context, req *pb.AddWeightRequest) (*pb.CarState, error) {
+	return s.do(req.Car, func(car *elevator.Elevator) { car.AddWeight(int(req.Weight)) })
+}
+
+func (s *Server) PressOpenButton(ctx context.Context, req *pb.CarRequest) (*pb.CarState, error) {
+	return s.do(req.Car, (*elevator.Elevator).PressOpenButton)
+}
+
+func (s *Server) ReleaseOpenButton(ctx context.Context, req *pb.CarRequest) (*pb.CarState, error) {
+	return s.do(req.Car, (*elevator.Elevator).ReleaseOpenButton)
+}
+
+func (s *Server) PressCloseButton(ctx context.Context, req *pb.CarRequest) (*pb.CarState, error) {
+	return s.do(req.Car, (*elevator.Elevator).PressCloseButton)
+}
+
+func (s *Server) ReleaseCloseButton(ctx context.Context, req *pb.CarRequest) (*pb.CarState, error) {
+	return s.do(req.Car, (*elevator.Elevator).ReleaseCloseButton)
+}
+
+func (s *Server) SetObstruction(ctx context.Context, req *pb.SetObstructionRequest) (*pb.CarState, error) {
+	return s.do(req.Car, func(car *elevator.Elevator) { car.SetObstruction(req.Blocked) })
+}
+
+func (s *Server) SetMode(ctx context.Context, req *pb.SetModeRequest) (*pb.CarState, error) {
+	return s.command(req.Car, func(car *elevator.Elevator) error {
+		return car.SetMode(modeFromProto(req.Mode))
+	})
+}
+
+func (s *Server) Reset(ctx context.Context, req *pb.CarRequest) (*pb.CarState, error) {
+	return s.do(req.Car, (*elevator.Elevator).Reset)
+}
+
+func (s *Server) ResetEmergency(ctx context.Context, req *pb.CarRequest) (*pb.CarState, error) {
+	return s.command(req.Car, (*elevator.Elevator).ResetEmergency)
+}
+
+func (s *Server) Rescue(ctx context.Context, req *pb.CarRequest) (*pb.CarState, error) {
+	return s.command(req.Car, (*elevator.Elevator).Rescue)
+}
+
+func (s *Server) PressJog(ctx context.Context, req *pb.PressJogRequest) (*pb.CarState, error) {
+	return s.command(req.Car, func(car *elevator.Elevator) error {
+		return car.PressJog(fromProto(directions, req.Direction))
+	})
+}
+
+func (s *Server) ReleaseJog(ctx context.Context, req *pb.CarRequest) (*pb.CarState, error) {
+	return s.do(req.Car, (*elevator.Elevator).ReleaseJog)
+}
+
+func (s *Server) InjectFault(ctx context.Context, req *pb.InjectFaultRequest) (*pb.CarState, error) {
+	return s.command(req.Car, func(car *elevator.Elevator) error {
+		return car.InjectFault(faultFromProto(req.Fault))
+	})
+}
+
+func (s *Server) ClearFault(ctx context.Context, req *pb.ClearFaultRequest) (*pb.CarState, error) {
+	return s.command(req.Car, func(car *elevator.Elevator) error {
+		return car.ClearFault(fromProto(faultKinds, req.Kind))
+	})
+}
+
+func (s *Server) SetSmokeDetector(ctx context.Context, req *pb.SetSmokeDetectorRequest) (*pb.GroupState, error) {
+	if err := s.group.SetSmokeDetector(int(req.Floor), req.Active); err != nil {
+		return nil, statusError(err)
+	}
+	return groupState(s.group), nil
+}
+
+// WatchEvents subscribes to every car requested and streams their events
+// until the client goes away. Each car has its own subscription, so the
+// backpressure policy applies per car. The response header is sent once the
+// subscriptions are open.
+func (s *Server) WatchEvents(req *pb.WatchEventsRequest, stream grpc.ServerStreamingServer[pb.Event]) error {
+	opts := elevator.SubscribeOptions{Buffer: int(req.Buffer), Policy: policies[req.Backpressure]}
+	if opts.Policy == "" {
+		return status.Errorf(codes.InvalidArgument, "unknown backpressure %v", req.Backpressure)
+	}
+	for _, t := range req.Types {
+		opts.Types = append(opts.Types, elevator.EventType(t))
+	}
+
+	cars := make(map[int]*elevator.Elevator)
+	if len(req.Cars) == 0 {
+		for i, car := range s.group.Cars() {
+			cars[i] = car
+		}
+	}
+	for _, i := range req.Cars {
+		car, err := s.group.Car(int(i))
+		if err != nil {
+			return statusError(err)
+		}
+		cars[int(i)] = car
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	out := make(chan *pb.Event)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+	for i, car := range cars {
+		sub, err := car.Subscribe(opts)
+		if err != nil {
+			return statusError(err)
+		}
+		defer car.Unsubscribe(sub)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ev := range sub.Events() {
+				select {
+				case out <- event(i, ev):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	// The header tells the client that every later event will be streamed.
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+	slog.Info("Event stream opened", "cars", len(cars), "policy", opts.Policy)
+	defer slog.Info("Event stream closed", "cars", len(cars))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev := <-out:
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// command runs fn on car i and returns the car state, or the error of fn as
+// a status.
+func (s *Server) command(i int32, fn func(*elevator.Elevator) error) (*pb.CarState, error) {
+	car, err := s.group.Car(int(i))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if err := fn(car); err != nil {
+		return nil, statusError(err)
+	}
+	return carState(int(i), car), nil
+}
+
+// do runs a command that cannot fail on car i.
+func (s *Server) do(i int32, fn func(*elevator.Elevator)) (*pb.CarState, error) {
+	return s.command(i, func(car *elevator.Elevator) error {
+		fn(car)
+		return nil
+	})
+}
+
+func (s *Server) state(i int32) (*pb.CarState, error) {
+	return s.do(i, func(*elevator.Elevator) {})
+}
+
+// statusCodes maps engine error codes to status codes.
+var statusCodes = map[elevator.ErrorCode]codes.Code{
+	elevator.ErrOutOfRange:   codes.OutOfRange,
+	elevator.ErrInaccessible: codes.InvalidArgument,
+	elevator.ErrInvalid:      codes.InvalidArgument,
+	elevator.ErrModeForbids:  codes.FailedPrecondition,
+	elevator.ErrState:        codes.FailedPrecondition,
+	elevator.ErrFault:        codes.FailedPrecondition,
+	elevator.ErrOverload:     codes.FailedPrecondition,
+	elevator.ErrUnavailable:  codes.Unavailable,
+}
+
+// statusError converts an engine error to a status carrying its error code.
+func statusError(err error) error {
+	code := elevator.ErrorCodeOf(err)
+	c, ok := statusCodes[code]
+	if !ok {
+		return status.Error(codes.Unknown, err.Error())
+	}
+	st, derr := status.New(c, err.Error()).WithDetails(&errdetails.ErrorInfo{Reason: string(code), Domain: ErrorDomain})
+	if derr != nil {
+		return status.Error(c, err.Error())
+	}
+	return st.Err()
+}
@@ -0,0 +1,166 @@
+package grpcserver
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	pb "go-elevator-simulator/api/elevator/v1"
+	"go-elevator-simulator/pkg/elevator"
+)
+
+var epoch = time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+// serve starts a server for a two-car group on a simulated clock and returns
+// a client connected to it.
+func serve(t *testing.T) (*elevator.Simulation, pb.ElevatorServiceClient) {
+	t.Helper()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	sim := elevator.NewSimulation(epoch)
+	t.Cleanup(sim.Close)
+	g, err := sim.NewGroup(elevator.GroupConfig{
+		ID: "G", Cars: 2,
+		Car: elevator.Config{
+			MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+			TravelTime: time.Second, DoorSpeed: time.Second, DoorOpenTime: 3 * time.Second,
+		},
+	}, elevator.WithLogger(slog.Default()))
+	if err != nil {
+		t.Fatalf("NewGroup: %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	New(g).Register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return sim, pb.NewElevatorServiceClient(conn)
+}
+
+func TestServer_Commands(t *testing.T) {
+	_, client := serve(t)
+	ctx := context.Background()
+
+	st, err := client.AddCall(ctx, &pb.AddCallRequest{Car: 1, Floor: 5, CallType: pb.CallType_CALL_TYPE_CAR})
+	if err != nil {
+		t.Fatalf("AddCall: %v", err)
+	}
+	if st.Car != 1 || !reflect.DeepEqual(st.CarCalls, []int32{5}) {
+		t.Errorf("AddCall state = car %d calls %v, want car 1 calls [5]", st.Car, st.CarCalls)
+	}
+	st, err = client.SetMode(ctx, &pb.SetModeRequest{Car: 0, Mode: pb.OperationMode_OPERATION_MODE_MANUAL})
+	if err != nil {
+		t.Fatalf("SetMode: %v", err)
+	}
+	if st.Mode != pb.OperationMode_OPERATION_MODE_MANUAL {
+		t.Errorf("mode = %v, want MANUAL", st.Mode)
+	}
+	group, err := client.GetState(ctx, &pb.GetStateRequest{})
+	if err != nil {
+		t.Fatalf("GetState: %v", err)
+	}
+	if len(group.Cars) != 2 || group.Cars[0].Id != "G-1" || group.Cars[1].Floor != 1 {
+		t.Errorf("GetState = %v", group)
+	}
+
+	tests := []struct {
+		name   string
+		call   func() error
+		code   codes.Code
+		reason string
+	}{
+		{"floor out of range", func() error {
+			_, err := client.AddCall(ctx, &pb.AddCallRequest{Car: 1, Floor: 99})
+			return err
+		}, codes.OutOfRange, "OutOfRange"},
+		{"car out of range", func() error {
+			_, err := client.PressOpenButton(ctx, &pb.CarRequest{Car: 2})
+			return err
+		}, codes.OutOfRange, "OutOfRange"},
+		{"unspecified mode", func() error {
+			_, err := client.SetMode(ctx, &pb.SetModeRequest{Car: 1})
+			return err
+		}, codes.InvalidArgument, "Invalid"},
+		{"jog outside inspection", func() error {
+			_, err := client.PressJog(ctx, &pb.PressJogRequest{Car: 1, Direction: pb.Direction_DIRECTION_UP})
+			return err
+		}, codes.FailedPrecondition, "ModeForbids"},
+		{"no emergency to reset", func() error {
+			_, err := client.ResetEmergency(ctx, &pb.CarRequest{Car: 1})
+			return err
+		}, codes.FailedPrecondition, "ModeForbids"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(tt.call())
+			if st.Code() != tt.code {
+				t.Errorf("code = %v (%s), want %v", st.Code(), st.Message(), tt.code)
+			}
+			var reason string
+			for _, d := range st.Details() {
+				if info, ok := d.(*errdetails.ErrorInfo); ok {
+					reason = info.Reason
+				}
+			}
+			if reason != tt.reason {
+				t.Errorf("ErrorInfo reason = %q, want %q", reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestServer_WatchEvents(t *testing.T) {
+	sim, client := serve(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.WatchEvents(ctx, &pb.WatchEventsRequest{Cars: []int32{0}, Types: []string{"FloorChange", "Arrived"}})
+	if err != nil {
+		t.Fatalf("WatchEvents: %v", err)
+	}
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("Header: %v", err)
+	}
+	if _, err := client.AddCall(ctx, &pb.AddCallRequest{Car: 0, Floor: 3}); err != nil {
+		t.Fatalf("AddCall: %v", err)
+	}
+	if err := sim.RunFor(ctx, 10*time.Second); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+
+	want := []*pb.Event{
+		{Type: "FloorChange", Payload: &pb.Event_Floor{Floor: 2}},
+		{Type: "FloorChange", Payload: &pb.Event_Floor{Floor: 3}},
+		{Type: "Arrived", Payload: &pb.Event_Arrival{Arrival: &pb.Arrival{Floor: 3, OpenDoorSide: pb.DoorSide_DOOR_SIDE_FRONT}}},
+	}
+	for i, w := range want {
+		ev, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv %d: %v", i, err)
+		}
+		w.Time = ev.Time
+		if ev.Time == nil || !proto.Equal(ev, w) {
+			t.Errorf("event %d = %v, want %v", i, ev, w)
+		}
+	}
+}
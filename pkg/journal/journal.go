@@ -0,0 +1,258 @@
+// Package journal records elevator sessions to append-only JSON Lines files.
+// 이 패키지는 세션의 입력 명령과 엔진 이벤트를 JSON Lines 파일에 순서대로 기록하고 읽습니다.
+//
+// The first line of a journal is a start entry carrying the wall-clock start of
+// the session. Every further line is either an inbound command, stored
+// verbatim, or an event published by a car, stamped with its offset from start.
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+// Version is the journal format version written in start entries.
+const Version = 1
+
+// Kind is the type of a journal entry.
+type Kind string
+
+const (
+	KindStart   Kind = "start"
+	KindCommand Kind = "command"
+	KindEvent   Kind = "event"
+)
+
+// Entry is one line of a journal.
+// Entry는 저널의 한 줄(기록 단위)입니다.
+type Entry struct {
+	Seq     int64           `json:"seq"`
+	At      time.Duration   `json:"at"` // 세션 시작 후 경과 시간 (ns)
+	Kind    Kind            `json:"kind"`
+	Start   time.Time       `json:"start,omitempty"`   // start
+	Version int             `json:"version,omitempty"` // start
+	Command json.RawMessage `json:"command,omitempty"` // command: 수신 메시지 원문
+	Car     int             `json:"car,omitempty"`     // event
+	Event   string          `json:"event,omitempty"`   // event: 이벤트 타입
+	Payload json.RawMessage `json:"payload,omitempty"` // event
+}
+
+// EventEntry converts a car event into an (unsequenced) journal entry.
+func EventEntry(start time.Time, car int, ev elevator.Event) (Entry, error) {
+	payload, err := json.Marshal(ev.Payload)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to encode %s payload: %w", ev.Type, err)
+	}
+	return Entry{
+		At:      ev.Timestamp.Sub(start),
+		Kind:    KindEvent,
+		Car:     car,
+		Event:   string(ev.Type),
+		Payload: payload,
+	}, nil
+}
+
+// Writer appends entries to a journal. It is safe for concurrent use.
+type Writer struct {
+	mu     sync.Mutex
+	w      *bufio.Writer
+	closer io.Closer
+	start  time.Time
+	seq    int64
+	err    error
+}
+
+// NewWriter starts a journal on w for a session that started at start.
+func NewWriter(w io.Writer, start time.Time) (*Writer, error) {
+	jw := &Writer{w: bufio.NewWriter(w), start: start}
+	if c, ok := w.(io.Closer); ok {
+		jw.closer = c
+	}
+	if err := jw.append(Entry{Kind: KindStart, Start: start, Version: Version}); err != nil {
+		return nil, err
+	}
+	return jw, jw.Flush()
+}
+
+// Create starts a journal in a new file at path.
+func Create(path string, start time.Time) (*Writer, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create journal: %w", err)
+	}
+	w, err := NewWriter(f, start)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Start returns the session start time.
+func (w *Writer) Start() time.Time {
+	return w.start
+}
+
+// Command records an inbound command received at.
+func (w *Writer) Command(at time.Time, command json.RawMessage) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.append(Entry{At: at.Sub(w.start), Kind: KindCommand, Command: command})
+}
+
+// Event records an event published by car.
+func (w *Writer) Event(car int, ev elevator.Event) error {
+	entry, err := EventEntry(w.start, car, ev)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.append(entry)
+}
+
+// append writes entry; the first error sticks and is returned from then on.
+func (w *Writer) append(entry Entry) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.seq++
+	entry.Seq = w.seq
+	data, err := json.Marshal(entry)
+	if err != nil {
+		w.err = fmt.Errorf("failed to encode journal entry: %w", err)
+		return w.err
+	}
+	data = append(data, '\n')
+	if _, err := w.w.Write(data); err != nil {
+		w.err = fmt.Errorf("failed to write journal: %w", err)
+	}
+	return w.err
+}
+
+// Flush writes buffered entries to the underlying writer.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	if err := w.w.Flush(); err != nil {
+		w.err = fmt.Errorf("failed to flush journal: %w", err)
+	}
+	return w.err
+}
+
+// Close flushes the journal and closes the underlying writer if it is an io.Closer.
+func (w *Writer) Close() error {
+	err := w.Flush()
+	if w.closer != nil {
+		if cerr := w.closer.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Journal is a journal read back into memory.
+// Journal은 메모리로 읽어 들인 저널입니다.
+type Journal struct {
+	Start   time.Time
+	Entries []Entry // start 항목 제외
+}
+
+// Read parses a journal. A truncated last line, as left by a crash, is ignored.
+func Read(r io.Reader) (*Journal, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var j *Journal
+	line := 0
+	var pending error
+	for sc.Scan() {
+		line++
+		if pending != nil {
+			return nil, pending // a bad line that was not the last one
+		}
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			pending = fmt.Errorf("invalid journal line %d: %w", line, err)
+			continue
+		}
+		if j == nil {
+			if e.Kind != KindStart {
+				return nil, fmt.Errorf("invalid journal: first entry is %q, want %q", e.Kind, KindStart)
+			}
+			if e.Version > Version {
+				return nil, fmt.Errorf("unsupported journal version %d", e.Version)
+			}
+			j = &Journal{Start: e.Start}
+			continue
+		}
+		j.Entries = append(j.Entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	if j == nil {
+		return nil, fmt.Errorf("invalid journal: empty")
+	}
+	return j, nil
+}
+
+// Open reads the journal file at path.
+func Open(path string) (*Journal, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Duration is the offset of the last entry.
+func (j *Journal) Duration() time.Duration {
+	if len(j.Entries) == 0 {
+		return 0
+	}
+	return j.Entries[len(j.Entries)-1].At
+}
+
+// Filter returns the entries of the given kind in journal order.
+func (j *Journal) Filter(kind Kind) []Entry {
+	var out []Entry
+	for _, e := range j.Entries {
+		if e.Kind == kind {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Compare reports the first difference between recorded and replayed event
+// entries. Sequence numbers are ignored; offsets, cars, types and payloads must
+// match exactly.
+func Compare(recorded, replayed []Entry) error {
+	for i := 0; i < len(recorded) && i < len(replayed); i++ {
+		r, p := recorded[i], replayed[i]
+		if r.At != p.At || r.Car != p.Car || r.Event != p.Event || !bytes.Equal(r.Payload, p.Payload) {
+			return fmt.Errorf("event %d differs: recorded %v car %d %s %s, replayed %v car %d %s %s",
+				i, r.At, r.Car, r.Event, r.Payload, p.At, p.Car, p.Event, p.Payload)
+		}
+	}
+	if len(recorded) != len(replayed) {
+		return fmt.Errorf("recorded %d events, replayed %d", len(recorded), len(replayed))
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+var epoch = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+func TestWriter_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Command(epoch, json.RawMessage(`{"action":"init"}`)); err != nil {
+		t.Fatal(err)
+	}
+	ev := elevator.Event{Type: elevator.EventArrived, Timestamp: epoch.Add(3 * time.Second), Payload: 5}
+	if err := w.Event(1, ev); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Command(epoch.Add(4*time.Second), json.RawMessage(`{"action":"addCall","floor":3}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	j, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !j.Start.Equal(epoch) {
+		t.Errorf("Start = %v, want %v", j.Start, epoch)
+	}
+	if got := len(j.Entries); got != 3 {
+		t.Fatalf("len(Entries) = %d, want 3", got)
+	}
+	if got := j.Duration(); got != 4*time.Second {
+		t.Errorf("Duration = %v, want 4s", got)
+	}
+	if got := j.Entries[2].Seq; got != 4 {
+		t.Errorf("Seq = %d, want 4 (start entry is 1)", got)
+	}
+
+	events := j.Filter(KindEvent)
+	want, _ := EventEntry(epoch, 1, ev)
+	if err := Compare(events, []Entry{want}); err != nil {
+		t.Error(err)
+	}
+	if cmds := j.Filter(KindCommand); string(cmds[1].Command) != `{"action":"addCall","floor":3}` {
+		t.Errorf("command = %s", cmds[1].Command)
+	}
+}
+
+func TestRead(t *testing.T) {
+	start := `{"seq":1,"at":0,"kind":"start","start":"2024-01-01T09:00:00Z","version":1}`
+	event := `{"seq":2,"at":1000,"kind":"event","event":"Arrived","payload":1}`
+
+	tests := []struct {
+		name    string
+		input   string
+		entries int
+		wantErr bool
+	}{
+		{"complete", start + "\n" + event + "\n", 1, false},
+		{"truncated last line", start + "\n" + event + "\n" + `{"seq":3,"at":20`, 1, false},
+		{"corrupt middle line", start + "\n" + `{"seq":2,` + "\n" + event + "\n", 0, true},
+		{"missing start", event + "\n", 0, true},
+		{"empty", "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j, err := Read(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(j.Entries) != tt.entries {
+				t.Errorf("len(Entries) = %d, want %d", len(j.Entries), tt.entries)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := Entry{At: time.Second, Kind: KindEvent, Event: "Arrived", Payload: json.RawMessage(`1`)}
+	b := a
+	b.Payload = json.RawMessage(`2`)
+
+	if err := Compare([]Entry{a}, []Entry{a}); err != nil {
+		t.Errorf("identical: %v", err)
+	}
+	if err := Compare([]Entry{a}, []Entry{b}); err == nil {
+		t.Error("different payload: want error")
+	}
+	if err := Compare([]Entry{a, a}, []Entry{a}); err == nil {
+		t.Error("missing event: want error")
+	}
+}
@@ -0,0 +1,386 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+// DefaultStart is the virtual start time of scenarios that do not set one.
+var DefaultStart = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+// Record is an event observed during a run.
+type Record struct {
+	At  time.Duration // 시작 후 경과 시간
+	Car int
+	elevator.Event
+}
+
+func (r Record) String() string {
+	return fmt.Sprintf("%8v car %d %-16s %v", r.At, r.Car, r.Type, r.Payload)
+}
+
+// Result is the outcome of a scenario run.
+// Result는 시나리오 실행 결과입니다.
+type Result struct {
+	Name     string
+	Events   []Record
+	Failures []string
+}
+
+// Passed reports whether every expectation held.
+func (r *Result) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+func (r *Result) failf(format string, args ...interface{}) {
+	r.Failures = append(r.Failures, fmt.Sprintf(format, args...))
+}
+
+// Run executes s on a discrete-event simulation and checks its expectations.
+// opts are applied to every car, e.g. elevator.WithLogger.
+// Errors are returned for scenarios that cannot be set up; failed
+// expectations are reported in the Result.
+func Run(ctx context.Context, s *Scenario, opts ...elevator.Option) (*Result, error) {
+	cfg, err := s.Building.GroupConfig("SCN")
+	if err != nil {
+		return nil, err
+	}
+	start := s.Start
+	if start.IsZero() {
+		start = DefaultStart
+	}
+
+	sim := elevator.NewSimulation(start)
+	defer sim.Close()
+	g, err := sim.NewGroup(cfg, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create elevator group: %w", err)
+	}
+
+	res := &Result{Name: s.Name}
+	g.OnEvent(func(ev elevator.GroupEvent) {
+		res.Events = append(res.Events, Record{At: ev.Timestamp.Sub(start), Car: ev.Car, Event: ev.Event})
+	})
+
+	for i, st := range s.Steps {
+		i, st := i, st
+		sim.At(time.Duration(st.At), func() {
+			if err := apply(g, st); err != nil {
+				res.failf("step %d (%s at %v): %v", i, st.Action, time.Duration(st.At), err)
+			}
+		})
+	}
+	for i, a := range s.Asserts {
+		i, a := i, a
+		sim.At(time.Duration(a.At), func() {
+			for _, msg := range check(g, a) {
+				res.failf("assert %d at %v: %s", i, time.Duration(a.At), msg)
+			}
+		})
+	}
+
+	if err := sim.RunFor(ctx, time.Duration(s.Duration)); err != nil {
+		return nil, err
+	}
+
+	res.checkEvents(s)
+	return res, nil
+}
+
+// GroupConfig converts the building into the configuration of a group with
+// the given ID.
+func (b Building) GroupConfig(id string) (elevator.GroupConfig, error) {
+	scheduler, err := elevator.NewScheduler(b.Car.Strategy)
+	if err != nil {
+		return elevator.GroupConfig{}, err
+	}
+	policy, err := elevator.NewAssignmentPolicy(b.Policy)
+	if err != nil {
+		return elevator.GroupConfig{}, err
+	}
+	cars := b.Cars
+	if cars < 1 {
+		cars = 1
+	}
+	var motion *elevator.MotionProfile
+	if m := b.Car.Motion; m != nil {
+		motion = &elevator.MotionProfile{
+			RatedSpeed:   m.RatedSpeed,
+			Acceleration: m.Acceleration,
+			Jerk:         m.Jerk,
+			FloorHeight:  m.FloorHeight,
+		}
+	}
+	var recall *elevator.RecallConfig
+	if r := b.Car.Recall; r != nil {
+		recall = &elevator.RecallConfig{Floor: r.Floor, AlternateFloor: r.AlternateFloor}
+	}
+	return elevator.GroupConfig{
+		ID:     id,
+		Cars:   cars,
+		Policy: policy,
+		Car: elevator.Config{
+			MinFloor:        b.Car.MinFloor,
+			MaxFloor:        b.Car.MaxFloor,
+			InitialFloor:    b.Car.InitialFloor,
+			TravelTime:      time.Duration(b.Car.TravelTime),
+			TravelTimeEdge:  time.Duration(b.Car.TravelTimeEdge),
+			DoorSpeed:       time.Duration(b.Car.DoorSpeed),
+			DoorOpenTime:    time.Duration(b.Car.DoorOpenTime),
+			DoorReopenTime:  time.Duration(b.Car.DoorReopenTime),
+			MaxWeight:       b.Car.MaxWeight,
+			Scheduler:       scheduler,
+			Motion:          motion,
+			Recall:          recall,
+			InspectionSpeed: b.Car.InspectionSpeed,
+			NudgingTime:     time.Duration(b.Car.NudgingTime),
+		},
+	}, nil
+}
+
+func callType(s string) elevator.CallType {
+	if s == "" {
+		return elevator.CallCar
+	}
+	return elevator.CallType(s)
+}
+
+// apply performs a step against the group.
+func apply(g *elevator.Group, st Step) error {
+	car, err := g.Car(st.Car)
+	if err != nil {
+		return err
+	}
+
+	switch st.Action {
+	case ActionAddCall:
+		switch ct := callType(st.CallType); ct {
+		case elevator.CallHallUp:
+			_, err = g.AddHallCall(st.Floor, elevator.DirUp)
+		case elevator.CallHallDown:
+			_, err = g.AddHallCall(st.Floor, elevator.DirDown)
+		default:
+			err = car.AddCall(st.Floor, ct)
+		}
+		return err
+	case ActionRemoveCall:
+		switch ct := callType(st.CallType); ct {
+		case elevator.CallHallUp:
+			g.RemoveHallCall(st.Floor, elevator.DirUp)
+		case elevator.CallHallDown:
+			g.RemoveHallCall(st.Floor, elevator.DirDown)
+		default:
+			car.RemoveCall(st.Floor, ct)
+		}
+	case ActionPressOpen:
+		car.PressOpenButton()
+	case ActionReleaseOpen:
+		car.ReleaseOpenButton()
+	case ActionPressClose:
+		car.PressCloseButton()
+	case ActionReleaseClose:
+		car.ReleaseCloseButton()
+	case ActionSetMode:
+		mode, err := elevator.ParseMode(st.Mode)
+		if err != nil {
+			return err
+		}
+		car.SetMode(mode)
+	case ActionAddWeight:
+		car.AddWeight(st.Weight)
+	case ActionSetWeight:
+		car.AddWeight(st.Weight - car.Weight())
+	case ActionPassenger:
+		_, err = g.AddPassenger(&elevator.Passenger{
+			ID:          st.ID,
+			Origin:      st.Floor,
+			Destination: st.Destination,
+			Mass:        st.Weight,
+		})
+		return err
+	case ActionPressJog:
+		return car.PressJog(elevator.Direction(st.Direction))
+	case ActionReleaseJog:
+		car.ReleaseJog()
+	case ActionResetEmergency:
+		return car.ResetEmergency()
+	case ActionRescue:
+		return car.Rescue()
+	case ActionObstruct, ActionClearObstruct:
+		car.SetObstruction(st.Action == ActionObstruct)
+	case ActionInjectFault:
+		return car.InjectFault(elevator.Fault{
+			Kind:  elevator.FaultKind(st.Fault),
+			Floor: st.Floor,
+			Dir:   elevator.Direction(st.Direction),
+		})
+	case ActionClearFault:
+		return car.ClearFault(elevator.FaultKind(st.Fault))
+	case ActionSmoke, ActionClearSmoke:
+		return g.SetSmokeDetector(st.Floor, st.Action == ActionSmoke)
+	case ActionReset:
+		g.Reset()
+	}
+	return nil
+}
+
+// check compares the state of a car with an assertion.
+func check(g *elevator.Group, a Assert) []string {
+	car, err := g.Car(a.Car)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var msgs []string
+	floor, dir, doors, weight := car.CurrentState()
+	if a.Floor != nil && floor != *a.Floor {
+		msgs = append(msgs, fmt.Sprintf("car %d floor = %d, want %d", a.Car, floor, *a.Floor))
+	}
+	if a.Direction != "" && string(dir) != a.Direction {
+		msgs = append(msgs, fmt.Sprintf("car %d direction = %s, want %s", a.Car, dir, a.Direction))
+	}
+	if a.Door != "" && string(doors[elevator.Front]) != a.Door {
+		msgs = append(msgs, fmt.Sprintf("car %d door = %s, want %s", a.Car, doors[elevator.Front], a.Door))
+	}
+	if a.Mode != "" {
+		if want, _ := elevator.ParseMode(a.Mode); car.CurrentMode() != want {
+			msgs = append(msgs, fmt.Sprintf("car %d mode = %s, want %s", a.Car, car.CurrentMode(), want))
+		}
+	}
+	if a.Weight != nil && weight != *a.Weight {
+		msgs = append(msgs, fmt.Sprintf("car %d weight = %d, want %d", a.Car, weight, *a.Weight))
+	}
+	calls := car.CallFloors()
+	if a.CarCalls != nil && !equalFloors(calls.Car, *a.CarCalls) {
+		msgs = append(msgs, fmt.Sprintf("car %d car calls = %v, want %v", a.Car, calls.Car, *a.CarCalls))
+	}
+	if a.HallCalls != nil {
+		hall := g.HallCalls()
+		got := append(append([]int(nil), hall.HallUp...), hall.HallDown...)
+		if !equalFloors(got, *a.HallCalls) {
+			msgs = append(msgs, fmt.Sprintf("hall calls = %v, want %v", got, *a.HallCalls))
+		}
+	}
+	return msgs
+}
+
+// equalFloors compares floor sets ignoring order and duplicates.
+func equalFloors(got, want []int) bool {
+	set := func(fs []int) []int {
+		m := make(map[int]bool)
+		for _, f := range fs {
+			m[f] = true
+		}
+		out := make([]int, 0, len(m))
+		for f := range m {
+			out = append(out, f)
+		}
+		sort.Ints(out)
+		return out
+	}
+	g, w := set(got), set(want)
+	if len(g) != len(w) {
+		return false
+	}
+	for i := range g {
+		if g[i] != w[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkEvents verifies the expected event sequence and forbidden events.
+func (r *Result) checkEvents(s *Scenario) {
+	next := 0
+expect:
+	for _, m := range s.Expect {
+		found := false
+		for next < len(r.Events) {
+			rec := r.Events[next]
+			next++
+			if m.matches(rec) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			r.failf("expected event not seen (in order): %s", m)
+			break expect
+		}
+	}
+	for _, m := range s.Forbid {
+		for _, rec := range r.Events {
+			if m.matches(rec) {
+				r.failf("forbidden event at %v: %s", rec.At, m)
+				break
+			}
+		}
+	}
+}
+
+func (m Match) matches(r Record) bool {
+	if m.Event != "" && string(r.Type) != m.Event {
+		return false
+	}
+	if m.Car != nil && r.Car != *m.Car {
+		return false
+	}
+	if m.After != nil && r.At < time.Duration(*m.After) {
+		return false
+	}
+	if m.Before != nil && r.At > time.Duration(*m.Before) {
+		return false
+	}
+
+	switch p := r.Payload.(type) {
+	case int: // FloorChange
+		return m.Floor == nil || p == *m.Floor
+	case elevator.ArrivedPayload:
+		return m.Floor == nil || p.Floor == *m.Floor
+	case elevator.DoorChangePayload:
+		return m.Door == "" || string(p.State) == m.Door
+	case elevator.Direction:
+		return m.Direction == "" || string(p) == m.Direction
+	case elevator.OperationMode:
+		want, err := elevator.ParseMode(m.Mode)
+		return m.Mode == "" || err == nil && p == want
+	case elevator.SmokeDetectorPayload:
+		return m.Floor == nil || p.Floor == *m.Floor
+	case elevator.RecallPayload:
+		return m.Floor == nil || p.Floor == *m.Floor
+	case elevator.EmergencyPayload:
+		return m.Floor == nil || p.Floor == *m.Floor
+	case elevator.ErrorPayload:
+		return m.Floor == nil || p.Floor == *m.Floor
+	case elevator.PassengerPayload:
+		return (m.Floor == nil || p.Floor == *m.Floor) && (m.Passenger == "" || p.Passenger.ID == m.Passenger)
+	}
+	return m.Floor == nil && m.Door == "" && m.Direction == "" && m.Mode == "" && m.Passenger == ""
+}
+
+func (m Match) String() string {
+	s := m.Event
+	if m.Car != nil {
+		s += fmt.Sprintf(" car=%d", *m.Car)
+	}
+	if m.Floor != nil {
+		s += fmt.Sprintf(" floor=%d", *m.Floor)
+	}
+	for _, kv := range [][2]string{{"door", m.Door}, {"direction", m.Direction}, {"mode", m.Mode}, {"passenger", m.Passenger}} {
+		if kv[1] != "" {
+			s += fmt.Sprintf(" %s=%s", kv[0], kv[1])
+		}
+	}
+	if m.After != nil {
+		s += fmt.Sprintf(" after=%v", time.Duration(*m.After))
+	}
+	if m.Before != nil {
+		s += fmt.Sprintf(" before=%v", time.Duration(*m.Before))
+	}
+	return s
+}
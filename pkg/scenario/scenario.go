@@ -0,0 +1,228 @@
+// Package scenario describes elevator test scenarios declaratively and runs them headless.
+// 이 패키지는 JSON으로 기술한 시나리오(건물 설정, 시간별 조작, 기대 이벤트/상태)를 실행하고 검증합니다.
+package scenario
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+// Duration is a time.Duration written as a Go duration string ("1.5s") or a
+// number of seconds in JSON.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case float64:
+		*d = Duration(v * float64(time.Second))
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", v, err)
+		}
+		*d = Duration(parsed)
+	default:
+		return fmt.Errorf("invalid duration %s", b)
+	}
+	return nil
+}
+
+// Scenario is a scripted run of an elevator bank.
+// Scenario는 엘리베이터 뱅크의 스크립트 실행 정의입니다.
+type Scenario struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Start       time.Time `json:"start,omitempty"` // 가상 시작 시각 (기본 2024-01-01 09:00 UTC)
+	Duration    Duration  `json:"duration"`        // 실행 시간
+	Building    Building  `json:"building"`
+	Steps       []Step    `json:"steps"`             // 시간별 조작
+	Expect      []Match   `json:"expect,omitempty"`  // 이 순서대로 발생해야 하는 이벤트
+	Forbid      []Match   `json:"forbid,omitempty"`  // 발생하면 안 되는 이벤트
+	Asserts     []Assert  `json:"asserts,omitempty"` // 특정 시각의 상태 검증
+}
+
+// Building is the elevator bank under test.
+type Building struct {
+	Cars   int    `json:"cars,omitempty"`   // 카 대수 (기본 1)
+	Policy string `json:"policy,omitempty"` // 홀 호출 할당 정책 (eta, nearest)
+	Car    Car    `json:"car"`
+}
+
+// Car is the configuration shared by every car.
+type Car struct {
+	MinFloor        int      `json:"minFloor"`
+	MaxFloor        int      `json:"maxFloor"`
+	InitialFloor    int      `json:"initialFloor"`
+	TravelTime      Duration `json:"travelTime"`
+	TravelTimeEdge  Duration `json:"travelTimeEdge,omitempty"`
+	DoorSpeed       Duration `json:"doorSpeed"`
+	DoorOpenTime    Duration `json:"doorOpenTime"`
+	DoorReopenTime  Duration `json:"doorReopenTime,omitempty"`
+	MaxWeight       int      `json:"maxWeight,omitempty"`
+	Strategy        string   `json:"strategy,omitempty"` // 배차 전략
+	Motion          *Motion  `json:"motion,omitempty"`
+	Recall          *Recall  `json:"recall,omitempty"`          // 소방 귀환 층
+	InspectionSpeed float64  `json:"inspectionSpeed,omitempty"` // 점검 운전 속도 m/s
+	NudgingTime     Duration `json:"nudgingTime,omitempty"`     // 문 감지기 차단 후 넛징까지 시간
+}
+
+// Recall sets the fire service recall floors; see elevator.RecallConfig.
+type Recall struct {
+	Floor          int `json:"floor"`
+	AlternateFloor int `json:"alternateFloor"`
+}
+
+// Motion is the kinematic model of the drive; see elevator.MotionProfile.
+type Motion struct {
+	RatedSpeed   float64 `json:"ratedSpeed"`
+	Acceleration float64 `json:"acceleration"`
+	Jerk         float64 `json:"jerk"`
+	FloorHeight  float64 `json:"floorHeight,omitempty"`
+}
+
+// Step actions. They mirror the WebSocket actions of the web simulator.
+const (
+	ActionAddCall        = "addCall"
+	ActionRemoveCall     = "removeCall"
+	ActionPressOpen      = "pressOpen"
+	ActionReleaseOpen    = "releaseOpen"
+	ActionPressClose     = "pressClose"
+	ActionReleaseClose   = "releaseClose"
+	ActionSetMode        = "setMode"
+	ActionAddWeight      = "addWeight"
+	ActionSetWeight      = "setWeight"
+	ActionPassenger      = "passenger"
+	ActionPressJog       = "pressJog"
+	ActionReleaseJog     = "releaseJog"
+	ActionResetEmergency = "resetEmergency"
+	ActionRescue         = "rescue"
+	ActionObstruct       = "obstruct"
+	ActionClearObstruct  = "clearObstruction"
+	ActionInjectFault    = "injectFault"
+	ActionClearFault     = "clearFault"
+	ActionSmoke          = "smokeDetector"
+	ActionClearSmoke     = "clearSmokeDetector"
+	ActionReset          = "reset"
+)
+
+// Step is an input applied at a point in time.
+// Step은 특정 시각에 적용되는 입력입니다.
+type Step struct {
+	At          Duration `json:"at"`
+	Action      string   `json:"action"`
+	Car         int      `json:"car,omitempty"`
+	Floor       int      `json:"floor,omitempty"`
+	CallType    string   `json:"callType,omitempty"` // Car(기본), HallUp, HallDown
+	Mode        string   `json:"mode,omitempty"`     // Auto, Manual, Moving, Emergency, FireRecall, FireService
+	Weight      int      `json:"weight,omitempty"`
+	Destination int      `json:"destination,omitempty"` // passenger: 목적 층 (출발 층은 Floor)
+	ID          string   `json:"id,omitempty"`          // passenger: 승객 ID
+	Direction   string   `json:"direction,omitempty"`   // pressJog: Up, Down / injectFault: 고착된 홀 버튼 방향
+	Fault       string   `json:"fault,omitempty"`       // injectFault, clearFault: 고장 종류 (DoorJam, DriveTrip, ...)
+}
+
+// Match selects events. Empty fields match anything.
+// Match는 이벤트 조건입니다. 비어 있는 필드는 모든 값과 일치합니다.
+type Match struct {
+	Event     string    `json:"event"`
+	Car       *int      `json:"car,omitempty"`
+	Floor     *int      `json:"floor,omitempty"`     // FloorChange, Arrived, 승객·소방 이벤트
+	Door      string    `json:"door,omitempty"`      // DoorChange 상태
+	Direction string    `json:"direction,omitempty"` // DirectionChange
+	Mode      string    `json:"mode,omitempty"`      // ModeChange
+	Passenger string    `json:"passenger,omitempty"` // 승객 이벤트의 승객 ID
+	After     *Duration `json:"after,omitempty"`     // 이 시각 이후 (포함)
+	Before    *Duration `json:"before,omitempty"`    // 이 시각 이전 (포함)
+}
+
+// Assert checks the state of a car at a point in time.
+// Assert는 특정 시각의 카 상태를 검증합니다.
+type Assert struct {
+	At        Duration `json:"at"`
+	Car       int      `json:"car,omitempty"`
+	Floor     *int     `json:"floor,omitempty"`
+	Direction string   `json:"direction,omitempty"`
+	Door      string   `json:"door,omitempty"` // 앞문 상태
+	Mode      string   `json:"mode,omitempty"`
+	Weight    *int     `json:"weight,omitempty"`
+	CarCalls  *[]int   `json:"carCalls,omitempty"`
+	HallCalls *[]int   `json:"hallCalls,omitempty"` // 상·하향 홀 호출 층 (합집합)
+}
+
+// Load reads a scenario file.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Parse decodes and validates a JSON scenario. Unknown fields are rejected so
+// that typos do not silently weaken a scenario.
+func Parse(data []byte) (*Scenario, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var s Scenario
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("invalid scenario: %w", err)
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *Scenario) validate() error {
+	if s.Duration <= 0 {
+		return fmt.Errorf("invalid scenario: duration must be positive")
+	}
+	for i, st := range s.Steps {
+		switch st.Action {
+		case ActionAddCall, ActionRemoveCall, ActionPressOpen, ActionReleaseOpen, ActionPressClose, ActionReleaseClose,
+			ActionAddWeight, ActionSetWeight, ActionPassenger, ActionSmoke, ActionClearSmoke, ActionReleaseJog,
+			ActionResetEmergency, ActionRescue, ActionObstruct, ActionClearObstruct, ActionReset:
+		case ActionPressJog:
+			if st.Direction != string(elevator.DirUp) && st.Direction != string(elevator.DirDown) {
+				return fmt.Errorf("invalid step %d: unknown jog direction %q", i, st.Direction)
+			}
+		case ActionInjectFault, ActionClearFault:
+			if !slices.Contains(elevator.FaultKinds, elevator.FaultKind(st.Fault)) {
+				return fmt.Errorf("invalid step %d: unknown fault %q", i, st.Fault)
+			}
+		case ActionSetMode:
+			if _, err := elevator.ParseMode(st.Mode); err != nil {
+				return fmt.Errorf("invalid step %d: %w", i, err)
+			}
+		default:
+			return fmt.Errorf("invalid step %d: unknown action %q", i, st.Action)
+		}
+	}
+	for i, a := range s.Asserts {
+		if a.Mode == "" {
+			continue
+		}
+		if _, err := elevator.ParseMode(a.Mode); err != nil {
+			return fmt.Errorf("invalid assert %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package scenario
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+var quiet = elevator.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"unknown field", `{"duration": "1s", "stepz": []}`, "unknown field"},
+		{"bad duration", `{"duration": "soon"}`, "invalid duration"},
+		{"no duration", `{"name": "x"}`, "duration must be positive"},
+		{"unknown action", `{"duration": 1, "steps": [{"action": "fly"}]}`, "unknown action"},
+		{"unknown mode", `{"duration": 1, "steps": [{"action": "setMode", "mode": "Turbo"}]}`, "unknown mode"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.json))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_Examples(t *testing.T) {
+	paths, err := filepath.Glob("../../scenarios/*.json")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("No example scenarios found: %v", err)
+	}
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			s, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			res, err := Run(context.Background(), s, quiet)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			for _, f := range res.Failures {
+				t.Error(f)
+			}
+		})
+	}
+}
+
+func TestRun_ReportsFailures(t *testing.T) {
+	s, err := Parse([]byte(`{
+		"name": "wrong expectations",
+		"duration": "20s",
+		"building": {"car": {"minFloor": 1, "maxFloor": 5, "initialFloor": 1,
+			"travelTime": "1s", "doorSpeed": "1s", "doorOpenTime": "1s"}},
+		"steps": [
+			{"at": "0s", "action": "addCall", "floor": 3},
+			{"at": "1s", "action": "addCall", "floor": 9}
+		],
+		"expect": [{"event": "Arrived", "floor": 4}],
+		"forbid": [{"event": "Arrived", "floor": 3}],
+		"asserts": [{"at": "15s", "floor": 1, "mode": "Manual"}]
+	}`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	res, err := Run(context.Background(), s, quiet)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := []string{
+		"step 1 (addCall", // floor 9 out of range
+		"assert 0 at 15s: car 0 floor = 3",
+		"assert 0 at 15s: car 0 mode = Auto",
+		"expected event not seen (in order): Arrived floor=4",
+		"forbidden event at 2.1s: Arrived floor=3",
+	}
+	if len(res.Failures) != len(want) {
+		t.Fatalf("Failures = %q, want %d", res.Failures, len(want))
+	}
+	for i, w := range want {
+		if !strings.HasPrefix(res.Failures[i], w) {
+			t.Errorf("Failure %d = %q, want prefix %q", i, res.Failures[i], w)
+		}
+	}
+}
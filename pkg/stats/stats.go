@@ -0,0 +1,253 @@
+// Package stats collects lift traffic performance statistics from elevator events.
+// 이 패키지는 엘리베이터 이벤트로부터 운행 성능 통계(AWT, ART, 수송 능력)를 수집합니다.
+package stats
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+// Default thresholds.
+const (
+	DefaultLongWait = 60 * time.Second
+	DefaultWindow   = 5 * time.Minute
+)
+
+// Config holds collector thresholds. Zero values use the defaults.
+// Config는 수집기 기준값 설정입니다.
+type Config struct {
+	LongWait time.Duration // 장시간 대기 기준 (기본 60초)
+	Window   time.Duration // 수송 능력 측정 구간 (기본 5분)
+}
+
+// Trip is a completed passenger journey.
+// Trip은 완료된 승객 이동 기록입니다.
+type Trip struct {
+	Car       int
+	Passenger elevator.Passenger
+	Stops     int // 탑승 중 정차 횟수 (목적 층 포함)
+}
+
+// Report is a point-in-time summary of the collected statistics.
+// Report는 수집된 통계의 시점별 요약입니다.
+type Report struct {
+	Waiting   int // 현재 대기 승객 수
+	Riding    int // 현재 탑승 승객 수
+	Boarded   int // 누적 탑승 승객 수
+	Delivered int // 누적 하차 승객 수
+	Evacuated int // 목적 층 전에 대피로 내린 승객 수 (Delivered와 시간 통계에서 제외)
+
+	AvgWait    time.Duration // 평균 대기 시간 (AWT)
+	MaxWait    time.Duration
+	AvgRide    time.Duration // 평균 탑승 시간 (ART)
+	AvgJourney time.Duration
+	JourneyP50 time.Duration
+	JourneyP90 time.Duration
+	JourneyP95 time.Duration
+
+	LongWaits    int     // 대기 시간이 LongWait를 넘은 승객 수 (대기 중 포함)
+	StopsPerTrip float64 // 승객 한 명이 탑승 중 겪은 평균 정차 횟수
+
+	HandlingCapacity int // 가장 붐빈 Window 구간의 하차 승객 수
+	RecentHandling   int // 최근 Window 구간의 하차 승객 수
+}
+
+type rider struct {
+	car   int
+	stops int // car stop count at boarding
+}
+
+// Collector accumulates passenger and car events. It is safe for concurrent use.
+// Collector는 승객과 카 이벤트를 누적합니다.
+type Collector struct {
+	cfg Config
+
+	mu      sync.Mutex
+	waiting map[string]time.Time // 대기 승객의 도착 시각
+	riding  map[string]rider
+	stops   map[int]int // 카별 정차 횟수
+	waits   []time.Duration
+	trips   []Trip
+	evac    int // 대피한 승객 수
+}
+
+// NewCollector creates an empty Collector.
+func NewCollector(cfg Config) *Collector {
+	if cfg.LongWait <= 0 {
+		cfg.LongWait = DefaultLongWait
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultWindow
+	}
+	c := &Collector{cfg: cfg}
+	c.Reset()
+	return c
+}
+
+// Attach subscribes c to every event of e.
+func (c *Collector) Attach(e *elevator.Elevator) {
+	e.OnEvent(func(ev elevator.Event) { c.Observe(0, ev) })
+}
+
+// AttachGroup subscribes c to every car of g.
+func (c *Collector) AttachGroup(g *elevator.Group) {
+	g.OnEvent(func(ev elevator.GroupEvent) { c.Observe(ev.Car, ev.Event) })
+}
+
+// Observe records a single event of the given car.
+func (c *Collector) Observe(car int, ev elevator.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch ev.Type {
+	case elevator.EventArrived:
+		c.stops[car]++
+	case elevator.EventPassengerWaiting:
+		if pp, ok := ev.Payload.(elevator.PassengerPayload); ok {
+			c.waiting[pp.Passenger.ID] = pp.Passenger.ArrivedAt
+		}
+	case elevator.EventPassengerBoarded:
+		if pp, ok := ev.Payload.(elevator.PassengerPayload); ok {
+			delete(c.waiting, pp.Passenger.ID)
+			c.riding[pp.Passenger.ID] = rider{car: car, stops: c.stops[car]}
+			c.waits = append(c.waits, pp.Passenger.WaitTime())
+		}
+	case elevator.EventPassengerAlighted:
+		if pp, ok := ev.Payload.(elevator.PassengerPayload); ok {
+			r, ok := c.riding[pp.Passenger.ID]
+			if !ok {
+				r = rider{car: car, stops: c.stops[car]}
+			}
+			delete(c.riding, pp.Passenger.ID)
+			c.trips = append(c.trips, Trip{
+				Car:       r.car,
+				Passenger: pp.Passenger,
+				Stops:     c.stops[r.car] - r.stops,
+			})
+		}
+	case elevator.EventPassengerEvacuated:
+		if pp, ok := ev.Payload.(elevator.PassengerPayload); ok {
+			delete(c.riding, pp.Passenger.ID)
+			c.evac++
+		}
+	}
+}
+
+// Trips returns the completed journeys in delivery order.
+func (c *Collector) Trips() []Trip {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Trip(nil), c.trips...)
+}
+
+// Reset discards everything collected so far.
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.waiting = make(map[string]time.Time)
+	c.riding = make(map[string]rider)
+	c.stops = make(map[int]int)
+	c.waits = nil
+	c.trips = nil
+	c.evac = 0
+}
+
+// Report summarises the statistics as of now. Passengers still waiting count
+// towards LongWaits once they have waited longer than the threshold.
+func (c *Collector) Report(now time.Time) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r := Report{
+		Waiting:   len(c.waiting),
+		Riding:    len(c.riding),
+		Boarded:   len(c.waits),
+		Delivered: len(c.trips),
+		Evacuated: c.evac,
+	}
+
+	var waitSum time.Duration
+	for _, w := range c.waits {
+		waitSum += w
+		if w > r.MaxWait {
+			r.MaxWait = w
+		}
+		if w > c.cfg.LongWait {
+			r.LongWaits++
+		}
+	}
+	for _, arrived := range c.waiting {
+		if w := now.Sub(arrived); w > c.cfg.LongWait {
+			r.LongWaits++
+		}
+	}
+	if len(c.waits) > 0 {
+		r.AvgWait = waitSum / time.Duration(len(c.waits))
+	}
+
+	if len(c.trips) == 0 {
+		return r
+	}
+
+	var rideSum, journeySum time.Duration
+	var stopSum int
+	journeys := make([]time.Duration, len(c.trips))
+	delivered := make([]time.Time, len(c.trips))
+	for i, t := range c.trips {
+		rideSum += t.Passenger.RideTime()
+		journeySum += t.Passenger.JourneyTime()
+		stopSum += t.Stops
+		journeys[i] = t.Passenger.JourneyTime()
+		delivered[i] = t.Passenger.AlightedAt
+	}
+	n := time.Duration(len(c.trips))
+	r.AvgRide = rideSum / n
+	r.AvgJourney = journeySum / n
+	r.StopsPerTrip = float64(stopSum) / float64(len(c.trips))
+
+	sort.Slice(journeys, func(i, j int) bool { return journeys[i] < journeys[j] })
+	r.JourneyP50 = Percentile(journeys, 50)
+	r.JourneyP90 = Percentile(journeys, 90)
+	r.JourneyP95 = Percentile(journeys, 95)
+
+	sort.Slice(delivered, func(i, j int) bool { return delivered[i].Before(delivered[j]) })
+	r.HandlingCapacity, r.RecentHandling = handling(delivered, c.cfg.Window, now)
+	return r
+}
+
+// Percentile returns the nearest-rank p-th percentile of sorted values.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// handling returns the peak number of deliveries in any window and the number
+// delivered in the window ending at now. times must be sorted.
+func handling(times []time.Time, window time.Duration, now time.Time) (peak, recent int) {
+	start := 0
+	for end, t := range times {
+		for t.Sub(times[start]) >= window {
+			start++
+		}
+		if n := end - start + 1; n > peak {
+			peak = n
+		}
+		if now.Sub(t) < window {
+			recent++
+		}
+	}
+	return peak, recent
+}
@@ -0,0 +1,135 @@
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+var epoch = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+func passengerEvent(t elevator.EventType, p elevator.Passenger) elevator.Event {
+	return elevator.Event{Type: t, Payload: elevator.PassengerPayload{Passenger: p}}
+}
+
+func TestCollector_Report(t *testing.T) {
+	c := NewCollector(Config{})
+	at := func(s int) time.Time { return epoch.Add(time.Duration(s) * time.Second) }
+
+	// p1: waits 10s, rides 20s with one intermediate stop.
+	// p2: waits 70s (long), rides 10s.
+	// p3: still waiting at report time for 90s (long).
+	// p4: waits 10s, evacuated after 5s: neither delivered nor a journey.
+	p1 := elevator.Passenger{ID: "p1", ArrivedAt: at(0), BoardedAt: at(10), AlightedAt: at(30)}
+	p2 := elevator.Passenger{ID: "p2", ArrivedAt: at(0), BoardedAt: at(70), AlightedAt: at(80)}
+	p3 := elevator.Passenger{ID: "p3", ArrivedAt: at(10)}
+	p4 := elevator.Passenger{ID: "p4", ArrivedAt: at(20), BoardedAt: at(30), AlightedAt: at(35)}
+
+	for _, p := range []elevator.Passenger{p1, p2, p3} {
+		c.Observe(0, passengerEvent(elevator.EventPassengerWaiting, p))
+	}
+	c.Observe(0, passengerEvent(elevator.EventPassengerBoarded, p1))
+	c.Observe(0, elevator.Event{Type: elevator.EventArrived})
+	c.Observe(1, elevator.Event{Type: elevator.EventArrived}) // other car: not counted
+	c.Observe(0, elevator.Event{Type: elevator.EventArrived})
+	c.Observe(0, passengerEvent(elevator.EventPassengerAlighted, p1))
+	c.Observe(1, passengerEvent(elevator.EventPassengerBoarded, p2))
+	c.Observe(1, elevator.Event{Type: elevator.EventArrived})
+	c.Observe(1, passengerEvent(elevator.EventPassengerAlighted, p2))
+	c.Observe(1, passengerEvent(elevator.EventPassengerWaiting, p4))
+	c.Observe(1, passengerEvent(elevator.EventPassengerBoarded, p4))
+	c.Observe(1, passengerEvent(elevator.EventPassengerEvacuated, p4))
+
+	r := c.Report(at(100))
+	checks := []struct {
+		name      string
+		got, want any
+	}{
+		{"Waiting", r.Waiting, 1},
+		{"Riding", r.Riding, 0},
+		{"Delivered", r.Delivered, 2},
+		{"Evacuated", r.Evacuated, 1},
+		{"AvgWait", r.AvgWait, 30 * time.Second},
+		{"MaxWait", r.MaxWait, 70 * time.Second},
+		{"AvgRide", r.AvgRide, 15 * time.Second},
+		{"AvgJourney", r.AvgJourney, 55 * time.Second},
+		{"JourneyP50", r.JourneyP50, 30 * time.Second},
+		{"JourneyP95", r.JourneyP95, 80 * time.Second},
+		{"LongWaits", r.LongWaits, 2},
+		{"StopsPerTrip", r.StopsPerTrip, 1.5},
+		{"HandlingCapacity", r.HandlingCapacity, 2},
+		{"RecentHandling", r.RecentHandling, 2},
+	}
+	for _, tt := range checks {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if r := c.Report(at(400)); r.RecentHandling != 0 {
+		t.Errorf("RecentHandling after window = %d, want 0", r.RecentHandling)
+	}
+
+	c.Reset()
+	if r := c.Report(at(100)); r.Delivered != 0 || r.Waiting != 0 {
+		t.Errorf("Report after Reset = %+v", r)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 1}, {10, 1}, {50, 5}, {90, 9}, {95, 10}, {100, 10},
+	}
+	for _, tt := range tests {
+		if got := Percentile(values, tt.p); got != tt.want {
+			t.Errorf("Percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := Percentile(nil, 50); got != 0 {
+		t.Errorf("Percentile(nil) = %v, want 0", got)
+	}
+}
+
+func TestCollector_AttachSimulation(t *testing.T) {
+	sim := elevator.NewSimulation(epoch)
+	defer sim.Close()
+
+	e, err := sim.NewElevator(elevator.Config{
+		ID: "E1", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+		DoorSpeed: time.Second, DoorOpenTime: 2 * time.Second, TravelTime: time.Second,
+		MaxWeight: 1000,
+	})
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+	c := NewCollector(Config{})
+	c.Attach(e)
+
+	for i, dest := range []int{5, 8} {
+		p := &elevator.Passenger{ID: string(rune('a' + i)), Origin: 1, Destination: dest, Mass: 70}
+		if err := e.AddPassenger(p); err != nil {
+			t.Fatalf("AddPassenger: %v", err)
+		}
+	}
+	if err := sim.RunFor(context.Background(), 2*time.Minute); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+
+	r := c.Report(sim.Now())
+	if r.Delivered != 2 || r.Waiting != 0 || r.Riding != 0 {
+		t.Fatalf("Report = %+v, want both passengers delivered", r)
+	}
+	// Both board together at the lobby; the second rider also stops at 5.
+	if r.StopsPerTrip != 1.5 {
+		t.Errorf("StopsPerTrip = %v, want 1.5", r.StopsPerTrip)
+	}
+	if r.AvgRide <= 0 || r.AvgJourney < r.AvgRide {
+		t.Errorf("AvgRide %v, AvgJourney %v", r.AvgRide, r.AvgJourney)
+	}
+}
@@ -0,0 +1,99 @@
+package sweep
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"go-elevator-simulator/pkg/stats"
+)
+
+// tTable holds the two-sided 95% critical values of Student's t
+// distribution for 1 to 30 degrees of freedom.
+var tTable = [...]float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// zCritical is the two-sided 95% critical value of the normal distribution,
+// used beyond the t table.
+const zCritical = 1.960
+
+// Stat is a metric over the seeds of a cell.
+// Stat은 한 셀의 시드별 값을 모은 지표입니다.
+type Stat struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"` // 표본 표준편차
+	CI     float64 `json:"ci"`     // 평균의 95% 신뢰 구간 반폭 (시드 1개면 0)
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+// newStat computes the mean of values with a 95% confidence interval from
+// Student's t distribution, or the normal distribution past 30 degrees of
+// freedom.
+func newStat(values []float64) Stat {
+	n := len(values)
+	if n == 0 {
+		return Stat{}
+	}
+	s := Stat{Min: values[0], Max: values[0]}
+	var sum float64
+	for _, v := range values {
+		sum += v
+		s.Min = math.Min(s.Min, v)
+		s.Max = math.Max(s.Max, v)
+	}
+	s.Mean = sum / float64(n)
+	if n < 2 {
+		return s
+	}
+	var ss float64
+	for _, v := range values {
+		ss += (v - s.Mean) * (v - s.Mean)
+	}
+	s.StdDev = math.Sqrt(ss / float64(n-1))
+	s.CI = critical(n-1) * s.StdDev / math.Sqrt(float64(n))
+	return s
+}
+
+func critical(df int) float64 {
+	if df <= len(tTable) {
+		return tTable[df-1]
+	}
+	return zCritical
+}
+
+// Distribution is the pooled distribution of a time over every passenger of
+// every seed of a cell, in seconds.
+// Distribution은 한 셀의 모든 시드·승객을 합친 시간 분포(초)입니다.
+type Distribution struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P90   float64 `json:"p90"`
+	P95   float64 `json:"p95"`
+	P99   float64 `json:"p99"`
+	Max   float64 `json:"max"`
+}
+
+func newDistribution(values []time.Duration) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	var sum time.Duration
+	for _, v := range values {
+		sum += v
+	}
+	return Distribution{
+		Count: len(values),
+		Mean:  (sum / time.Duration(len(values))).Seconds(),
+		P50:   stats.Percentile(values, 50).Seconds(),
+		P90:   stats.Percentile(values, 90).Seconds(),
+		P95:   stats.Percentile(values, 95).Seconds(),
+		P99:   stats.Percentile(values, 99).Seconds(),
+		Max:   values[len(values)-1].Seconds(),
+	}
+}
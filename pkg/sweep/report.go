@@ -0,0 +1,261 @@
+package sweep
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-elevator-simulator/pkg/batch"
+)
+
+// Files written by WriteCSV, and the conventional name of the Markdown report.
+const (
+	CellsFile  = "cells.csv"
+	RunsFile   = "runs.csv"
+	ReportFile = "report.md"
+)
+
+var allAxes = []string{AxisStrategy, AxisPolicy, AxisCars, AxisTravelTime, AxisDoorOpenTime, AxisIntensity}
+
+// axis returns the value of the named axis.
+func (c Cell) axis(name string) string {
+	switch name {
+	case AxisStrategy:
+		return c.Strategy
+	case AxisPolicy:
+		return c.Policy
+	case AxisCars:
+		return strconv.Itoa(c.Cars)
+	case AxisTravelTime:
+		return time.Duration(c.TravelTime).String()
+	case AxisDoorOpenTime:
+		return time.Duration(c.DoorOpenTime).String()
+	case AxisIntensity:
+		return ftoa(c.Intensity)
+	}
+	return ""
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteMarkdown writes the comparison tables: the per-seed means with their
+// confidence intervals, the pooled distributions, and the best cells. Only
+// the axes that vary get a column; the others are listed once.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	axes := r.Axes
+	if len(axes) == 0 {
+		axes = []string{AxisStrategy}
+	}
+	name := r.Name
+	if name == "" {
+		name = "Sweep"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", name)
+	fmt.Fprintf(&b, "%d cells × %d seeds, %v simulated per run. Values are means over the seeds ± the half-width of their 95%% confidence interval; times in seconds.\n",
+		len(r.Cells), len(r.Seeds), time.Duration(r.Duration*float64(time.Second)))
+	if len(r.Cells) > 0 {
+		var fixed []string
+		for _, a := range allAxes {
+			if !slices.Contains(axes, a) {
+				fixed = append(fixed, a+" "+r.Cells[0].axis(a))
+			}
+		}
+		if len(fixed) > 0 {
+			fmt.Fprintf(&b, "Fixed: %s.\n", strings.Join(fixed, ", "))
+		}
+	}
+
+	b.WriteString("\n## Means over seeds\n\n")
+	table(&b, append(slices.Clone(axes), "delivered", "avg wait", "wait p90", "max wait", "avg journey", "journey p90", "long waits", "Wh/passenger"),
+		r.Cells, axes, func(c CellResult) []string {
+			return []string{pm(c.Delivered, 0), pm(c.AvgWait, 1), pm(c.WaitP90, 1), pm(c.MaxWait, 1),
+				pm(c.AvgJourney, 1), pm(c.JourneyP90, 1), pm(c.LongWaits, 1), pm(c.WhPerPassenger, 1)}
+		})
+
+	b.WriteString("\n## Pooled distributions\n\n")
+	table(&b, append(slices.Clone(axes), "passengers", "wait p50", "wait p90", "wait p95", "wait p99", "wait max",
+		"journey p50", "journey p90", "journey p95", "journey p99"),
+		r.Cells, axes, func(c CellResult) []string {
+			return []string{strconv.Itoa(c.Wait.Count), f1(c.Wait.P50), f1(c.Wait.P90), f1(c.Wait.P95), f1(c.Wait.P99), f1(c.Wait.Max),
+				f1(c.Journey.P50), f1(c.Journey.P90), f1(c.Journey.P95), f1(c.Journey.P99)}
+		})
+
+	if len(r.Cells) > 1 {
+		b.WriteString("\n## Best\n\n")
+		for _, m := range []struct {
+			name string
+			stat func(CellResult) Stat
+		}{
+			{"avg wait", func(c CellResult) Stat { return c.AvgWait }},
+			{"avg journey", func(c CellResult) Stat { return c.AvgJourney }},
+			{"Wh/passenger", func(c CellResult) Stat { return c.WhPerPassenger }},
+		} {
+			best := slices.MinFunc(r.Cells, func(a, b CellResult) int {
+				return cmp.Compare(m.stat(a).Mean, m.stat(b).Mean)
+			})
+			fmt.Fprintf(&b, "- Lowest %s: %s (%s)\n", m.name, label(best, axes), pm(m.stat(best), 1))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func table(b *strings.Builder, header []string, cells []CellResult, axes []string, row func(CellResult) []string) {
+	fmt.Fprintf(b, "| %s |\n", strings.Join(header, " | "))
+	sep := make([]string, len(header))
+	for i := range sep {
+		sep[i] = "---"
+		if i >= len(axes) {
+			sep[i] = "---:"
+		}
+	}
+	fmt.Fprintf(b, "|%s|\n", strings.Join(sep, "|"))
+	for _, c := range cells {
+		values := make([]string, 0, len(header))
+		for _, a := range axes {
+			values = append(values, c.axis(a))
+		}
+		fmt.Fprintf(b, "| %s |\n", strings.Join(append(values, row(c)...), " | "))
+	}
+}
+
+func label(c CellResult, axes []string) string {
+	parts := make([]string, len(axes))
+	for i, a := range axes {
+		parts[i] = a + " " + c.axis(a)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func pm(s Stat, prec int) string {
+	return strconv.FormatFloat(s.Mean, 'f', prec, 64) + " ± " + strconv.FormatFloat(s.CI, 'f', prec, 64)
+}
+
+func f1(f float64) string {
+	return strconv.FormatFloat(f, 'f', 1, 64)
+}
+
+// WriteCSV writes the aggregated cells and the summary of every run as two
+// CSV files in dir, creating it if needed.
+func (r *Report) WriteCSV(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	files := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{CellsFile, r.WriteCellsCSV},
+		{RunsFile, r.WriteRunsCSV},
+	}
+	for _, f := range files {
+		if err := writeFile(filepath.Join(dir, f.name), f.write); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// WriteCellsCSV writes one row per cell: the axis values, the mean,
+// standard deviation and confidence half-width of every metric, and the
+// pooled distributions.
+func (r *Report) WriteCellsCSV(w io.Writer) error {
+	metrics := []struct {
+		name string
+		stat func(CellResult) Stat
+	}{
+		{"delivered", func(c CellResult) Stat { return c.Delivered }},
+		{"rejected", func(c CellResult) Stat { return c.Rejected }},
+		{"avg_wait", func(c CellResult) Stat { return c.AvgWait }},
+		{"wait_p90", func(c CellResult) Stat { return c.WaitP90 }},
+		{"max_wait", func(c CellResult) Stat { return c.MaxWait }},
+		{"avg_journey", func(c CellResult) Stat { return c.AvgJourney }},
+		{"journey_p90", func(c CellResult) Stat { return c.JourneyP90 }},
+		{"long_waits", func(c CellResult) Stat { return c.LongWaits }},
+		{"energy_kwh", func(c CellResult) Stat { return c.Energy }},
+		{"wh_per_passenger", func(c CellResult) Stat { return c.WhPerPassenger }},
+	}
+	header := append(slices.Clone(allAxes), "seeds")
+	for _, m := range metrics {
+		header = append(header, m.name+"_mean", m.name+"_sd", m.name+"_ci95")
+	}
+	for _, d := range []string{"wait", "journey"} {
+		header = append(header, d+"_count", d+"_mean", d+"_p50", d+"_p90", d+"_p95", d+"_p99", d+"_max")
+	}
+
+	rows := make([][]string, 0, len(r.Cells))
+	for _, c := range r.Cells {
+		row := append(c.axes(), strconv.Itoa(len(c.Runs)))
+		for _, m := range metrics {
+			s := m.stat(c)
+			row = append(row, ftoa(s.Mean), ftoa(s.StdDev), ftoa(s.CI))
+		}
+		for _, d := range []Distribution{c.Wait, c.Journey} {
+			row = append(row, strconv.Itoa(d.Count), ftoa(d.Mean), ftoa(d.P50), ftoa(d.P90), ftoa(d.P95), ftoa(d.P99), ftoa(d.Max))
+		}
+		rows = append(rows, row)
+	}
+	return writeCSV(w, header, rows)
+}
+
+// WriteRunsCSV writes one row per run: the axis values followed by the
+// batch summary columns.
+func (r *Report) WriteRunsCSV(w io.Writer) error {
+	header := append(slices.Clone(allAxes), batch.SummaryHeader...)
+	var rows [][]string
+	for _, c := range r.Cells {
+		for _, s := range c.Runs {
+			rows = append(rows, append(c.axes(), s.Row()...))
+		}
+	}
+	return writeCSV(w, header, rows)
+}
+
+func (c Cell) axes() []string {
+	values := make([]string, len(allAxes))
+	for i, a := range allAxes {
+		values[i] = c.axis(a)
+	}
+	return values
+}
+
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func ftoa(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
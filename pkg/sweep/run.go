@@ -0,0 +1,205 @@
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"go-elevator-simulator/pkg/batch"
+	"go-elevator-simulator/pkg/elevator"
+)
+
+// Matrix axes, as named in reports.
+const (
+	AxisStrategy     = "strategy"
+	AxisPolicy       = "policy"
+	AxisCars         = "cars"
+	AxisTravelTime   = "travelTime"
+	AxisDoorOpenTime = "doorOpenTime"
+	AxisIntensity    = "intensity"
+)
+
+// Report is the outcome of a sweep.
+// Report는 스윕 실행 결과입니다.
+type Report struct {
+	Name     string       `json:"name,omitempty"`
+	Duration float64      `json:"duration"` // 실행 1회의 시뮬레이션 시간 (초)
+	Seeds    []int64      `json:"seeds"`
+	Axes     []string     `json:"axes"` // 값이 둘 이상인 축
+	Cells    []CellResult `json:"cells"`
+}
+
+// CellResult aggregates the runs of one cell. Stats are taken over the
+// per-seed summaries, times in seconds; Wait and Journey pool every
+// passenger of every seed.
+// CellResult는 한 셀의 시드별 실행을 집계한 결과입니다.
+type CellResult struct {
+	Cell
+	Delivered      Stat `json:"delivered"`
+	Rejected       Stat `json:"rejected"`
+	AvgWait        Stat `json:"avgWait"`
+	WaitP90        Stat `json:"waitP90"`
+	MaxWait        Stat `json:"maxWait"`
+	AvgJourney     Stat `json:"avgJourney"`
+	JourneyP90     Stat `json:"journeyP90"`
+	LongWaits      Stat `json:"longWaits"`
+	Energy         Stat `json:"energy"` // kWh
+	WhPerPassenger Stat `json:"whPerPassenger"`
+
+	Wait    Distribution    `json:"wait"`
+	Journey Distribution    `json:"journey"`
+	Runs    []batch.Summary `json:"runs"` // 시드 순서
+}
+
+// run is the part of a batch result kept for the report.
+type run struct {
+	summary  batch.Summary
+	waits    []time.Duration
+	journeys []time.Duration
+}
+
+// Run simulates every cell of the matrix with every seed, Workers at a time,
+// and aggregates the results. opts are applied to every car, e.g.
+// elevator.WithLogger. The report does not depend on the number of workers.
+func Run(ctx context.Context, cfg *Config, opts ...elevator.Option) (*Report, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cells := cfg.Cells()
+	seeds := cfg.seeds()
+	workers := cfg.Workers
+	if workers == 0 {
+		workers = runtime.NumCPU()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type job struct{ cell, seed int }
+	jobs := make(chan job)
+	runs := make([][]run, len(cells))
+	for i := range runs {
+		runs[i] = make([]run, len(seeds))
+	}
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for range min(workers, len(cells)*len(seeds)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				res, err := batch.Run(ctx, cfg.batchConfig(cells[j.cell], seeds[j.seed]), opts...)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("cell %s, seed %d: %w", cells[j.cell], seeds[j.seed], err)
+						cancel()
+					})
+					continue
+				}
+				runs[j.cell][j.seed] = keep(res)
+			}
+		}()
+	}
+feed:
+	for c := range cells {
+		for s := range seeds {
+			select {
+			case jobs <- job{c, s}:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Name:     cfg.Name,
+		Duration: time.Duration(cfg.Base.Duration).Seconds(),
+		Seeds:    seeds,
+		Axes:     cfg.Matrix.axes(),
+	}
+	for i, cell := range cells {
+		report.Cells = append(report.Cells, aggregate(cell, runs[i]))
+	}
+	return report, nil
+}
+
+func keep(res *batch.Result) run {
+	r := run{summary: res.Summary}
+	for _, p := range res.Passengers {
+		if p.Wait != nil {
+			r.waits = append(r.waits, seconds(*p.Wait))
+		}
+		if p.Journey != nil {
+			r.journeys = append(r.journeys, seconds(*p.Journey))
+		}
+	}
+	return r
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func aggregate(cell Cell, runs []run) CellResult {
+	c := CellResult{Cell: cell}
+	metric := func(f func(batch.Summary) float64) Stat {
+		values := make([]float64, len(runs))
+		for i, r := range runs {
+			values[i] = f(r.summary)
+		}
+		return newStat(values)
+	}
+	c.Delivered = metric(func(s batch.Summary) float64 { return float64(s.Delivered) })
+	c.Rejected = metric(func(s batch.Summary) float64 { return float64(s.Rejected) })
+	c.AvgWait = metric(func(s batch.Summary) float64 { return s.AvgWait })
+	c.WaitP90 = metric(func(s batch.Summary) float64 { return s.WaitP90 })
+	c.MaxWait = metric(func(s batch.Summary) float64 { return s.MaxWait })
+	c.AvgJourney = metric(func(s batch.Summary) float64 { return s.AvgJourney })
+	c.JourneyP90 = metric(func(s batch.Summary) float64 { return s.JourneyP90 })
+	c.LongWaits = metric(func(s batch.Summary) float64 { return float64(s.LongWaits) })
+	c.Energy = metric(func(s batch.Summary) float64 { return s.Energy.Total })
+	c.WhPerPassenger = metric(func(s batch.Summary) float64 { return s.WhPerPassenger })
+
+	var waits, journeys []time.Duration
+	for _, r := range runs {
+		c.Runs = append(c.Runs, r.summary)
+		waits = append(waits, r.waits...)
+		journeys = append(journeys, r.journeys...)
+	}
+	c.Wait = newDistribution(waits)
+	c.Journey = newDistribution(journeys)
+	return c
+}
+
+// axes returns the names of the axes with more than one value.
+func (m Matrix) axes() []string {
+	var axes []string
+	for _, a := range []struct {
+		name string
+		n    int
+	}{
+		{AxisStrategy, len(m.Strategies)},
+		{AxisPolicy, len(m.Policies)},
+		{AxisCars, len(m.Cars)},
+		{AxisTravelTime, len(m.TravelTimes)},
+		{AxisDoorOpenTime, len(m.DoorOpenTimes)},
+		{AxisIntensity, len(m.Intensities)},
+	} {
+		if a.n > 1 {
+			axes = append(axes, a.name)
+		}
+	}
+	return axes
+}
@@ -0,0 +1,217 @@
+// Package sweep runs a matrix of batch simulations to compare dispatch strategies and building parameters.
+// 이 패키지는 배차 전략·카 수·주행 시간·문 열림 시간·교통량의 조합마다 여러 시드로 배치 시뮬레이션을 실행하고, 신뢰 구간이 포함된 비교 보고서를 만듭니다.
+//
+// A Config names a base batch.Config and the values to try on each axis of
+// the Matrix. Every combination is a Cell, run once per seed on all CPU
+// cores; the Report aggregates the per-seed summaries of each cell into
+// means with 95% confidence intervals, and pools the waiting and journey
+// times of every passenger into distributions.
+package sweep
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"go-elevator-simulator/pkg/batch"
+	"go-elevator-simulator/pkg/elevator"
+	"go-elevator-simulator/pkg/scenario"
+)
+
+// DefaultSeeds is the number of seeds run per cell when not configured.
+const DefaultSeeds = 5
+
+// Config is a parameter sweep.
+// Config는 파라미터 스윕 설정입니다.
+type Config struct {
+	Name    string       `json:"name,omitempty"`
+	Base    batch.Config `json:"base"`              // 모든 셀의 기준 설정
+	Matrix  Matrix       `json:"matrix"`            // 축별로 시험할 값
+	Seeds   int          `json:"seeds,omitempty"`   // 셀당 시드 수 (기본 5, Base.Seed부터 연속)
+	Workers int          `json:"workers,omitempty"` // 동시 실행 수 (기본 CPU 코어 수)
+}
+
+// Matrix lists the values to try on each axis. An empty axis keeps the
+// value of the base configuration.
+// Matrix는 축별 시험 값 목록입니다. 비어 있는 축은 기준 설정 값을 씁니다.
+type Matrix struct {
+	Strategies    []string            `json:"strategies,omitempty"`    // 카 배차 전략 (collective, scan, look, fcfs, sstf)
+	Policies      []string            `json:"policies,omitempty"`      // 홀 호출 할당 정책 (eta, nearest)
+	Cars          []int               `json:"cars,omitempty"`          // 카 대수
+	TravelTimes   []scenario.Duration `json:"travelTimes,omitempty"`   // 한 층 주행 시간
+	DoorOpenTimes []scenario.Duration `json:"doorOpenTimes,omitempty"` // 문 열림 유지 시간
+	Intensities   []float64           `json:"intensities,omitempty"`   // 교통량 배율 (도착률에 곱함)
+}
+
+// Cell is one combination of the matrix.
+// Cell은 매트릭스의 한 조합입니다.
+type Cell struct {
+	Strategy     string            `json:"strategy"`
+	Policy       string            `json:"policy"`
+	Cars         int               `json:"cars"`
+	TravelTime   scenario.Duration `json:"travelTime"`
+	DoorOpenTime scenario.Duration `json:"doorOpenTime"`
+	Intensity    float64           `json:"intensity"`
+}
+
+// String returns a short label such as "look/eta 4 cars 1.5s 3s x1.2".
+func (c Cell) String() string {
+	return fmt.Sprintf("%s/%s %d cars %v %v x%s", c.Strategy, c.Policy, c.Cars,
+		time.Duration(c.TravelTime), time.Duration(c.DoorOpenTime), strconv.FormatFloat(c.Intensity, 'f', -1, 64))
+}
+
+// Load reads a sweep configuration file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sweep: %w", err)
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse decodes and validates a JSON sweep configuration. Unknown fields are
+// rejected.
+func Parse(data []byte) (*Config, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid sweep: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate checks the matrix and the batch configuration of every cell.
+func (c *Config) Validate() error {
+	if c.Seeds < 0 {
+		return fmt.Errorf("invalid sweep: negative seed count")
+	}
+	if c.Workers < 0 {
+		return fmt.Errorf("invalid sweep: negative worker count")
+	}
+	m := c.Matrix
+	for _, n := range m.Cars {
+		if n < 1 {
+			return fmt.Errorf("invalid sweep: %d cars", n)
+		}
+	}
+	for _, d := range append(append([]scenario.Duration{}, m.TravelTimes...), m.DoorOpenTimes...) {
+		if d <= 0 {
+			return fmt.Errorf("invalid sweep: non-positive time %v", time.Duration(d))
+		}
+	}
+	if len(m.TravelTimes) > 0 && c.Base.Building.Car.Motion != nil {
+		return fmt.Errorf("invalid sweep: travelTimes have no effect with a motion profile")
+	}
+	for _, k := range m.Intensities {
+		if k <= 0 {
+			return fmt.Errorf("invalid sweep: non-positive intensity %v", k)
+		}
+	}
+	for _, cell := range c.Cells() {
+		if err := c.batchConfig(cell, c.Base.Seed).Validate(); err != nil {
+			return fmt.Errorf("cell %s: %w", cell, err)
+		}
+	}
+	return nil
+}
+
+// Cells expands the matrix in order, the last axis (intensity) varying
+// fastest.
+func (c *Config) Cells() []Cell {
+	b := c.Base.Building
+	strategies := orDefault(c.Matrix.Strategies, b.Car.Strategy)
+	policies := orDefault(c.Matrix.Policies, b.Policy)
+	cars := orDefault(c.Matrix.Cars, max(b.Cars, 1))
+	travel := orDefault(c.Matrix.TravelTimes, b.Car.TravelTime)
+	doorOpen := orDefault(c.Matrix.DoorOpenTimes, b.Car.DoorOpenTime)
+	intensities := orDefault(c.Matrix.Intensities, 1)
+
+	var cells []Cell
+	for _, s := range strategies {
+		for _, p := range policies {
+			for _, n := range cars {
+				for _, tt := range travel {
+					for _, do := range doorOpen {
+						for _, k := range intensities {
+							cells = append(cells, Cell{
+								Strategy: schedulerName(s), Policy: policyName(p), Cars: n,
+								TravelTime: tt, DoorOpenTime: do, Intensity: k,
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+	return cells
+}
+
+// seeds returns the seeds run for every cell.
+func (c *Config) seeds() []int64 {
+	n := c.Seeds
+	if n == 0 {
+		n = DefaultSeeds
+	}
+	seeds := make([]int64, n)
+	for i := range seeds {
+		seeds[i] = c.Base.Seed + int64(i)
+	}
+	return seeds
+}
+
+// batchConfig returns the base configuration with the values of cell and
+// the given seed.
+func (c *Config) batchConfig(cell Cell, seed int64) *batch.Config {
+	cfg := c.Base
+	cfg.Name = cell.String()
+	if c.Name != "" {
+		cfg.Name = c.Name + ": " + cfg.Name
+	}
+	cfg.Seed = seed
+	cfg.Building.Cars = cell.Cars
+	cfg.Building.Policy = cell.Policy
+	cfg.Building.Car.Strategy = cell.Strategy
+	cfg.Building.Car.TravelTime = cell.TravelTime
+	cfg.Building.Car.DoorOpenTime = cell.DoorOpenTime
+	cfg.Traffic.Rate *= cell.Intensity
+	cfg.Traffic.Periods = make([]batch.Period, len(c.Base.Traffic.Periods))
+	for i, p := range c.Base.Traffic.Periods {
+		p.Rate *= cell.Intensity
+		cfg.Traffic.Periods[i] = p
+	}
+	return &cfg
+}
+
+func orDefault[T any](values []T, def T) []T {
+	if len(values) == 0 {
+		return []T{def}
+	}
+	return values
+}
+
+// schedulerName normalizes a strategy name; unknown names are left for
+// batch validation to report.
+func schedulerName(name string) string {
+	if s, err := elevator.NewScheduler(name); err == nil {
+		return s.Name()
+	}
+	return name
+}
+
+func policyName(name string) string {
+	if p, err := elevator.NewAssignmentPolicy(name); err == nil {
+		return p.Name()
+	}
+	return name
+}
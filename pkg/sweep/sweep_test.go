@@ -0,0 +1,230 @@
+package sweep
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+	"go-elevator-simulator/pkg/scenario"
+)
+
+var quiet = elevator.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+const testConfig = `{
+  "name": "test",
+  "seeds": 3,
+  "base": {
+    "duration": "20m",
+    "seed": 10,
+    "building": {
+      "cars": 2,
+      "car": {"minFloor": 1, "maxFloor": 8, "initialFloor": 1, "travelTime": "1.5s",
+              "doorSpeed": "1s", "doorOpenTime": "3s", "maxWeight": 800}
+    },
+    "traffic": {"pattern": "lunch", "rate": 3}
+  },
+  "matrix": {"strategies": ["collective", "LOOK"], "intensities": [1, 2]}
+}`
+
+func TestParse_Invalid(t *testing.T) {
+	base := `"base": {"duration": "1h", "building": {"car": {"minFloor": 1, "maxFloor": 8, "initialFloor": 1, "travelTime": 1, "doorSpeed": 1, "doorOpenTime": 3}}, "traffic": {"pattern": "lunch", "rate": 1}}`
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"unknown field", `{` + base + `, "runs": 3}`, "unknown field"},
+		{"negative seeds", `{` + base + `, "seeds": -1}`, "negative seed count"},
+		{"zero cars", `{` + base + `, "matrix": {"cars": [2, 0]}}`, "0 cars"},
+		{"zero door open time", `{` + base + `, "matrix": {"doorOpenTimes": [0]}}`, "non-positive time"},
+		{"zero intensity", `{` + base + `, "matrix": {"intensities": [0]}}`, "non-positive intensity"},
+		{"unknown strategy", `{` + base + `, "matrix": {"strategies": ["look", "random"]}}`, `unknown scheduler "random"`},
+		{"unknown policy", `{` + base + `, "matrix": {"policies": ["fastest"]}}`, `unknown assignment policy "fastest"`},
+		{"invalid base", `{"base": {"duration": "1h"}}`, "pattern is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.json))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_Cells(t *testing.T) {
+	cfg, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := []Cell{
+		{Strategy: "collective", Policy: "eta", Cars: 2, TravelTime: dur(1500 * time.Millisecond), DoorOpenTime: dur(3 * time.Second), Intensity: 1},
+		{Strategy: "collective", Policy: "eta", Cars: 2, TravelTime: dur(1500 * time.Millisecond), DoorOpenTime: dur(3 * time.Second), Intensity: 2},
+		{Strategy: "look", Policy: "eta", Cars: 2, TravelTime: dur(1500 * time.Millisecond), DoorOpenTime: dur(3 * time.Second), Intensity: 1},
+		{Strategy: "look", Policy: "eta", Cars: 2, TravelTime: dur(1500 * time.Millisecond), DoorOpenTime: dur(3 * time.Second), Intensity: 2},
+	}
+	if got := cfg.Cells(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Cells() = %v, want %v", got, want)
+	}
+	if got := cfg.Matrix.axes(); !reflect.DeepEqual(got, []string{AxisStrategy, AxisIntensity}) {
+		t.Errorf("axes() = %v", got)
+	}
+}
+
+func TestConfig_BatchConfig(t *testing.T) {
+	cfg, err := Parse([]byte(`{"base": {"duration": "1h", "seed": 3,
+		"building": {"car": {"minFloor": 1, "maxFloor": 8, "initialFloor": 1, "travelTime": 1, "doorSpeed": 1, "doorOpenTime": 3}},
+		"traffic": {"periods": [{"start": "8h", "end": "9h", "rate": 4, "pattern": "up-peak"}]}}}`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	cell := Cell{Strategy: "sstf", Policy: "nearest", Cars: 3, TravelTime: dur(2 * time.Second), DoorOpenTime: dur(5 * time.Second), Intensity: 1.5}
+	b := cfg.batchConfig(cell, 9)
+
+	if b.Seed != 9 || b.Building.Cars != 3 || b.Building.Policy != "nearest" || b.Building.Car.Strategy != "sstf" ||
+		b.Building.Car.TravelTime != cell.TravelTime || b.Building.Car.DoorOpenTime != cell.DoorOpenTime {
+		t.Errorf("batchConfig = %+v", b)
+	}
+	if got := b.Traffic.Periods[0].Rate; got != 6 {
+		t.Errorf("scaled rate = %v, want 6", got)
+	}
+	if got := cfg.Base.Traffic.Periods[0].Rate; got != 4 {
+		t.Errorf("base rate changed to %v", got)
+	}
+}
+
+func TestNewStat(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   Stat
+	}{
+		{"empty", nil, Stat{}},
+		{"one", []float64{4}, Stat{Mean: 4, Min: 4, Max: 4}},
+		{"t", []float64{1, 2, 3, 4, 5}, Stat{Mean: 3, StdDev: math.Sqrt(2.5), CI: 2.776 * math.Sqrt(2.5) / math.Sqrt(5), Min: 1, Max: 5}},
+		{"normal", make([]float64, 40), Stat{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newStat(tt.values)
+			if math.Abs(got.Mean-tt.want.Mean) > 1e-9 || math.Abs(got.StdDev-tt.want.StdDev) > 1e-9 ||
+				math.Abs(got.CI-tt.want.CI) > 1e-9 || got.Min != tt.want.Min || got.Max != tt.want.Max {
+				t.Errorf("newStat(%v) = %+v, want %+v", tt.values, got, tt.want)
+			}
+		})
+	}
+	if got := critical(100); got != zCritical {
+		t.Errorf("critical(100) = %v, want %v", got, zCritical)
+	}
+}
+
+func TestRun(t *testing.T) {
+	cfg, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	cfg.Workers = 1
+	serial, err := Run(context.Background(), cfg, quiet)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	cfg.Workers = 4
+	parallel, err := Run(context.Background(), cfg, quiet)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Error("report depends on the number of workers")
+	}
+
+	if len(serial.Cells) != 4 || !reflect.DeepEqual(serial.Seeds, []int64{10, 11, 12}) {
+		t.Fatalf("%d cells, seeds %v", len(serial.Cells), serial.Seeds)
+	}
+	for _, c := range serial.Cells {
+		if len(c.Runs) != 3 {
+			t.Errorf("cell %s: %d runs, want 3", c.Cell, len(c.Runs))
+		}
+		var sum float64
+		for i, s := range c.Runs {
+			if s.Seed != int64(10+i) {
+				t.Errorf("cell %s: run %d has seed %d", c.Cell, i, s.Seed)
+			}
+			sum += s.AvgWait
+		}
+		if math.Abs(c.AvgWait.Mean-sum/3) > 1e-9 || c.Wait.Count == 0 || c.Journey.P90 < c.Journey.P50 {
+			t.Errorf("cell %s: %+v", c.Cell, c)
+		}
+	}
+	if low, high := serial.Cells[0].Delivered.Mean, serial.Cells[1].Delivered.Mean; high <= low {
+		t.Errorf("delivered %v at intensity 2, %v at 1", high, low)
+	}
+
+	var md bytes.Buffer
+	if err := serial.WriteMarkdown(&md); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+	for _, want := range []string{"# test", "| strategy | intensity | delivered |", "| look | 2 |", "Fixed: policy eta, cars 2", "- Lowest avg wait:"} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Markdown lacks %q:\n%s", want, md.String())
+		}
+	}
+
+	_, err = Run(context.Background(), &Config{Base: cfg.Base, Matrix: Matrix{Strategies: []string{"random"}}}, quiet)
+	if err == nil {
+		t.Error("Run accepted an invalid matrix")
+	}
+}
+
+func TestReport_WriteCSV(t *testing.T) {
+	cfg, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	cfg.Seeds = 2
+	report, err := Run(context.Background(), cfg, quiet)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	dir := t.TempDir()
+	if err := report.WriteCSV(dir); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+
+	tests := []struct {
+		file string
+		rows int
+	}{
+		{CellsFile, 1 + 4},
+		{RunsFile, 1 + 4*2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			f, err := os.Open(filepath.Join(dir, tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			records, err := csv.NewReader(f).ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll: %v", err)
+			}
+			if len(records) != tt.rows {
+				t.Errorf("%d rows, want %d", len(records), tt.rows)
+			}
+		})
+	}
+}
+
+func dur(d time.Duration) scenario.Duration {
+	return scenario.Duration(d)
+}
@@ -0,0 +1,29 @@
+package traffic
+
+import "time"
+
+// Constant returns a profile with the same rate and pattern all day.
+func Constant(rate float64, pattern Pattern) []Period {
+	return []Period{{Start: 0, End: 24 * time.Hour, Rate: rate, Pattern: pattern}}
+}
+
+// OfficeDay returns a typical office building day scaled by peak, the
+// up-peak arrival rate in passengers per minute.
+//
+//	07:30-09:30 up-peak (100%)
+//	09:30-11:45 interfloor (30%)
+//	11:45-13:30 lunch two-way (60%)
+//	13:30-16:45 interfloor (30%)
+//	16:45-18:30 down-peak (90%)
+func OfficeDay(peak float64) []Period {
+	at := func(h, m int) time.Duration {
+		return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute
+	}
+	return []Period{
+		{Start: at(7, 30), End: at(9, 30), Rate: peak, Pattern: PatternUpPeak},
+		{Start: at(9, 30), End: at(11, 45), Rate: peak * 0.3, Pattern: PatternInterfloor},
+		{Start: at(11, 45), End: at(13, 30), Rate: peak * 0.6, Pattern: PatternLunch},
+		{Start: at(13, 30), End: at(16, 45), Rate: peak * 0.3, Pattern: PatternInterfloor},
+		{Start: at(16, 45), End: at(18, 30), Rate: peak * 0.9, Pattern: PatternDownPeak},
+	}
+}
@@ -0,0 +1,327 @@
+// Package traffic generates passenger arrivals from statistical building traffic models.
+// 이 패키지는 통계적 건물 교통 모델(포아송 도착, 출근/퇴근/점심/층간 패턴)로 승객을 생성합니다.
+package traffic
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+// Pattern is a standard building traffic pattern.
+// Pattern은 표준 건물 교통 패턴입니다.
+type Pattern string
+
+const (
+	PatternUpPeak     Pattern = "up-peak"    // 출근: 로비에서 위층으로
+	PatternDownPeak   Pattern = "down-peak"  // 퇴근: 위층에서 로비로
+	PatternLunch      Pattern = "lunch"      // 점심: 양방향
+	PatternInterfloor Pattern = "interfloor" // 층간 이동
+)
+
+// Mix is the share of incoming (lobby to floor), outgoing (floor to lobby) and
+// interfloor trips. Shares are relative and need not sum to 1.
+type Mix struct {
+	Incoming   float64
+	Outgoing   float64
+	Interfloor float64
+}
+
+// Mix returns the trip mix of the pattern.
+func (p Pattern) Mix() (Mix, error) {
+	switch p {
+	case PatternUpPeak:
+		return Mix{Incoming: 0.85, Outgoing: 0.05, Interfloor: 0.10}, nil
+	case PatternDownPeak:
+		return Mix{Incoming: 0.05, Outgoing: 0.85, Interfloor: 0.10}, nil
+	case PatternLunch:
+		return Mix{Incoming: 0.40, Outgoing: 0.40, Interfloor: 0.20}, nil
+	case PatternInterfloor:
+		return Mix{Incoming: 0.05, Outgoing: 0.05, Interfloor: 0.90}, nil
+	}
+	return Mix{}, fmt.Errorf("unknown traffic pattern %q", p)
+}
+
+// Period is a time-of-day window with a constant arrival rate.
+// Period는 일정한 도착률을 갖는 하루 중 시간대입니다.
+type Period struct {
+	Start   time.Duration // 자정 기준 시작 시각
+	End     time.Duration // 자정 기준 종료 시각 (미포함)
+	Rate    float64       // 건물 전체 도착률 (명/분)
+	Pattern Pattern
+}
+
+// Config holds the building and traffic model parameters.
+// Config는 건물 및 교통 모델 설정입니다.
+type Config struct {
+	MinFloor     int
+	MaxFloor     int
+	Lobby        int             // 로비 층
+	FloorWeights map[int]float64 // 층별 상대 인구 (기본: 로비 외 층 1)
+	Profile      []Period        // 시간대별 도착률
+	MassMean     float64         // 평균 체중 kg (기본 75)
+	MassStdDev   float64         // 체중 표준편차 kg (기본 10)
+	Seed         int64
+}
+
+// Arrival is a generated passenger together with its arrival time.
+type Arrival struct {
+	At        time.Time
+	Passenger elevator.Passenger
+}
+
+// Sink receives generated passengers. *elevator.Elevator satisfies it; use
+// GroupSink for a *elevator.Group.
+type Sink interface {
+	AddPassenger(p *elevator.Passenger) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(p *elevator.Passenger) error
+
+func (f SinkFunc) AddPassenger(p *elevator.Passenger) error { return f(p) }
+
+// GroupSink feeds passengers to a group controller.
+func GroupSink(g *elevator.Group) Sink {
+	return SinkFunc(func(p *elevator.Passenger) error {
+		_, err := g.AddPassenger(p)
+		return err
+	})
+}
+
+// Generator draws passengers from a non-homogeneous Poisson process whose
+// rate follows the configured time-of-day profile. Each floor's arrival
+// stream is itself Poisson, thinned by the pattern mix and floor weights.
+// Generator is not safe for concurrent use except through Attach.
+type Generator struct {
+	cfg    Config
+	floors []int     // non-lobby floors
+	cum    []float64 // cumulative floor weights
+	rng    *rand.Rand
+	seq    int
+}
+
+// New validates cfg and creates a Generator.
+func New(cfg Config) (*Generator, error) {
+	if cfg.MinFloor >= cfg.MaxFloor {
+		return nil, fmt.Errorf("invalid traffic config: MinFloor (%d) >= MaxFloor (%d)", cfg.MinFloor, cfg.MaxFloor)
+	}
+	if cfg.Lobby < cfg.MinFloor || cfg.Lobby > cfg.MaxFloor {
+		return nil, fmt.Errorf("invalid traffic config: lobby %d out of range", cfg.Lobby)
+	}
+	for i, p := range cfg.Profile {
+		if p.Start < 0 || p.End > 24*time.Hour || p.Start >= p.End {
+			return nil, fmt.Errorf("invalid traffic period %d: [%v, %v)", i, p.Start, p.End)
+		}
+		if p.Rate < 0 {
+			return nil, fmt.Errorf("invalid traffic period %d: negative rate", i)
+		}
+		if _, err := p.Pattern.Mix(); err != nil {
+			return nil, fmt.Errorf("invalid traffic period %d: %w", i, err)
+		}
+	}
+	if cfg.MassMean == 0 {
+		cfg.MassMean = 75
+	}
+	if cfg.MassStdDev == 0 {
+		cfg.MassStdDev = 10
+	}
+
+	g := &Generator{cfg: cfg, rng: rand.New(rand.NewSource(cfg.Seed))}
+	total := 0.0
+	for f := cfg.MinFloor; f <= cfg.MaxFloor; f++ {
+		if f == cfg.Lobby {
+			continue
+		}
+		w := 1.0
+		if cfg.FloorWeights != nil {
+			w = cfg.FloorWeights[f]
+		}
+		if w <= 0 {
+			continue
+		}
+		total += w
+		g.floors = append(g.floors, f)
+		g.cum = append(g.cum, total)
+	}
+	if len(g.floors) == 0 {
+		return nil, fmt.Errorf("invalid traffic config: no populated floors")
+	}
+	return g, nil
+}
+
+// Next returns the first arrival strictly after t, or false if the profile
+// has no traffic at all.
+func (g *Generator) Next(t time.Time) (Arrival, bool) {
+	at, period, ok := g.nextTime(t)
+	if !ok {
+		return Arrival{}, false
+	}
+	return Arrival{At: at, Passenger: g.passenger(at, period.Pattern)}, true
+}
+
+// Generate returns every arrival in [from, to).
+func (g *Generator) Generate(from, to time.Time) []Arrival {
+	var out []Arrival
+	t := from
+	for {
+		a, ok := g.Next(t)
+		if !ok || !a.At.Before(to) {
+			return out
+		}
+		out = append(out, a)
+		t = a.At
+	}
+}
+
+// Feed is a generator attached to a clock; see Attach.
+type Feed struct {
+	mu      sync.Mutex
+	timer   elevator.Timer
+	stopped bool
+}
+
+// Stop cancels the pending arrival. It is safe to call more than once.
+func (f *Feed) Stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopped = true
+	if f.timer != nil {
+		f.timer.Stop()
+	}
+}
+
+// Attach schedules arrivals on clock and delivers each passenger to sink at its
+// arrival time, until the returned Feed is stopped. Rejected passengers are
+// passed to onError if it is non-nil.
+func (g *Generator) Attach(clock elevator.Clock, sink Sink, onError func(elevator.Passenger, error)) *Feed {
+	feed := &Feed{}
+	var schedule func(after time.Time)
+	schedule = func(after time.Time) {
+		a, ok := g.Next(after)
+		if !ok {
+			return
+		}
+		feed.mu.Lock()
+		defer feed.mu.Unlock()
+		if feed.stopped {
+			return
+		}
+		feed.timer = clock.AfterFunc(a.At.Sub(clock.Now()), func() {
+			p := a.Passenger
+			if err := sink.AddPassenger(&p); err != nil && onError != nil {
+				onError(p, err)
+			}
+			schedule(a.At)
+		})
+	}
+	schedule(clock.Now())
+	return feed
+}
+
+// nextTime draws the next arrival time after t by walking the piecewise
+// constant rate profile; inter-arrival times are exponential within a period.
+func (g *Generator) nextTime(t time.Time) (time.Time, Period, bool) {
+	if !g.hasTraffic() {
+		return time.Time{}, Period{}, false
+	}
+	for {
+		day := midnight(t)
+		period, end, ok := g.periodAt(t.Sub(day))
+		if !ok {
+			t = day.Add(end) // jump to the start of the next period (or next midnight)
+			continue
+		}
+		perSecond := period.Rate / 60
+		gap := time.Duration(g.rng.ExpFloat64() / perSecond * float64(time.Second))
+		if gap <= 0 {
+			gap = time.Nanosecond
+		}
+		if at := t.Add(gap); at.Before(day.Add(end)) {
+			return at, period, true
+		}
+		// Memoryless: restart the draw at the period boundary.
+		t = day.Add(end)
+	}
+}
+
+// periodAt returns the active period at time-of-day tod and its end. If no
+// period with traffic is active, ok is false and end is the next period start.
+func (g *Generator) periodAt(tod time.Duration) (Period, time.Duration, bool) {
+	next := 24 * time.Hour
+	for _, p := range g.cfg.Profile {
+		if p.Rate <= 0 {
+			continue
+		}
+		if tod >= p.Start && tod < p.End {
+			return p, p.End, true
+		}
+		if p.Start > tod && p.Start < next {
+			next = p.Start
+		}
+	}
+	return Period{}, next, false
+}
+
+func (g *Generator) hasTraffic() bool {
+	for _, p := range g.cfg.Profile {
+		if p.Rate > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Generator) passenger(at time.Time, pattern Pattern) elevator.Passenger {
+	mix, _ := pattern.Mix()
+	g.seq++
+
+	var origin, dest int
+	switch r := g.rng.Float64() * (mix.Incoming + mix.Outgoing + mix.Interfloor); {
+	case r < mix.Incoming:
+		origin, dest = g.cfg.Lobby, g.floor()
+	case r < mix.Incoming+mix.Outgoing:
+		origin, dest = g.floor(), g.cfg.Lobby
+	default:
+		origin = g.floor()
+		dest = origin
+		if len(g.floors) == 1 {
+			dest = g.cfg.Lobby
+		}
+		for dest == origin {
+			dest = g.floor()
+		}
+	}
+
+	mass := int(math.Round(g.rng.NormFloat64()*g.cfg.MassStdDev + g.cfg.MassMean))
+	if mass < 20 {
+		mass = 20
+	}
+	return elevator.Passenger{
+		ID:          fmt.Sprintf("P%06d", g.seq),
+		Origin:      origin,
+		Destination: dest,
+		Mass:        mass,
+		ArrivedAt:   at,
+	}
+}
+
+// floor draws a non-lobby floor weighted by population.
+func (g *Generator) floor() int {
+	r := g.rng.Float64() * g.cum[len(g.cum)-1]
+	for i, c := range g.cum {
+		if r < c {
+			return g.floors[i]
+		}
+	}
+	return g.floors[len(g.floors)-1]
+}
+
+func midnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
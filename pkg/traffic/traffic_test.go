@@ -0,0 +1,157 @@
+package traffic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-elevator-simulator/pkg/elevator"
+)
+
+var day = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestGenerator(t *testing.T, profile []Period) *Generator {
+	t.Helper()
+	g, err := New(Config{MinFloor: 1, MaxFloor: 10, Lobby: 1, Profile: profile, Seed: 42})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return g
+}
+
+func TestNew_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"floor range", Config{MinFloor: 5, MaxFloor: 5, Lobby: 5}},
+		{"lobby out of range", Config{MinFloor: 1, MaxFloor: 10, Lobby: 0}},
+		{"period reversed", Config{MinFloor: 1, MaxFloor: 10, Lobby: 1,
+			Profile: []Period{{Start: time.Hour, End: time.Minute, Rate: 1, Pattern: PatternUpPeak}}}},
+		{"unknown pattern", Config{MinFloor: 1, MaxFloor: 10, Lobby: 1, Profile: Constant(1, "rush")}},
+		{"no population", Config{MinFloor: 1, MaxFloor: 2, Lobby: 1, FloorWeights: map[int]float64{1: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.cfg); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestGenerator_Patterns(t *testing.T) {
+	tests := []struct {
+		pattern   Pattern
+		fromLobby float64 // 로비 출발 비율 기대값
+		toLobby   float64 // 로비 도착 비율 기대값
+	}{
+		{PatternUpPeak, 0.85, 0.05},
+		{PatternDownPeak, 0.05, 0.85},
+		{PatternLunch, 0.40, 0.40},
+		{PatternInterfloor, 0.05, 0.05},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.pattern), func(t *testing.T) {
+			g := newTestGenerator(t, Constant(60, tt.pattern))
+			arrivals := g.Generate(day, day.Add(2*time.Hour))
+
+			// 60/min for 2h: expect ~7200 arrivals.
+			if n := len(arrivals); n < 6800 || n > 7600 {
+				t.Fatalf("Generated %d arrivals, want ~7200", n)
+			}
+			var from, to int
+			for _, a := range arrivals {
+				p := a.Passenger
+				if p.Origin == p.Destination {
+					t.Fatalf("Passenger %s travels to its own floor", p.ID)
+				}
+				if p.Origin == 1 {
+					from++
+				}
+				if p.Destination == 1 {
+					to++
+				}
+			}
+			n := float64(len(arrivals))
+			if got := float64(from) / n; got < tt.fromLobby-0.03 || got > tt.fromLobby+0.03 {
+				t.Errorf("From lobby %.3f, want ~%.2f", got, tt.fromLobby)
+			}
+			if got := float64(to) / n; got < tt.toLobby-0.03 || got > tt.toLobby+0.03 {
+				t.Errorf("To lobby %.3f, want ~%.2f", got, tt.toLobby)
+			}
+		})
+	}
+}
+
+func TestGenerator_TimeOfDay(t *testing.T) {
+	g := newTestGenerator(t, OfficeDay(10))
+	arrivals := g.Generate(day, day.Add(48*time.Hour))
+
+	counts := map[int]int{}
+	for _, a := range arrivals {
+		tod := a.At.Sub(midnight(a.At))
+		if tod < 7*time.Hour+30*time.Minute || tod >= 18*time.Hour+30*time.Minute {
+			t.Fatalf("Arrival at %v outside the office day", a.At)
+		}
+		counts[a.At.Hour()]++
+	}
+	// 08시(출근, 10/분)는 10시(층간, 3/분)보다 붐벼야 한다.
+	if counts[8] <= counts[10]*2 {
+		t.Errorf("Up-peak hour %d arrivals, interfloor hour %d", counts[8], counts[10])
+	}
+}
+
+func TestGenerator_Deterministic(t *testing.T) {
+	a := newTestGenerator(t, OfficeDay(5)).Generate(day, day.Add(24*time.Hour))
+	b := newTestGenerator(t, OfficeDay(5)).Generate(day, day.Add(24*time.Hour))
+	if len(a) != len(b) {
+		t.Fatalf("Runs differ in length: %d vs %d", len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("Arrival %d differs: %+v vs %+v", i, a[i], b[i])
+		}
+	}
+}
+
+func TestGenerator_AttachSimulation(t *testing.T) {
+	sim := elevator.NewSimulation(day.Add(8 * time.Hour))
+	defer sim.Close()
+
+	e, err := sim.NewElevator(elevator.Config{
+		ID: "E1", MinFloor: 1, MaxFloor: 10, InitialFloor: 1,
+		DoorSpeed: time.Second, DoorOpenTime: 2 * time.Second, TravelTime: time.Second,
+		MaxWeight: 100000,
+	})
+	if err != nil {
+		t.Fatalf("NewElevator: %v", err)
+	}
+
+	var alighted int
+	e.OnEvent(func(ev elevator.Event) {
+		if ev.Type == elevator.EventPassengerAlighted {
+			alighted++
+		}
+	})
+
+	g := newTestGenerator(t, Constant(2, PatternUpPeak))
+	feed := g.Attach(sim.Clock(), e, func(p elevator.Passenger, err error) {
+		t.Errorf("Passenger %s rejected: %v", p.ID, err)
+	})
+	if err := sim.RunFor(context.Background(), 30*time.Minute); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+	feed.Stop()
+	if err := sim.RunFor(context.Background(), 10*time.Minute); err != nil {
+		t.Fatalf("RunFor: %v", err)
+	}
+
+	// 2/min for 30 min: expect ~60 trips, all completed after draining.
+	if alighted < 40 || alighted > 80 {
+		t.Errorf("Alighted %d passengers, want ~60", alighted)
+	}
+	if n := len(e.Waiting()) + len(e.Riders()); n != 0 {
+		t.Errorf("%d passengers still in the system", n)
+	}
+}